@@ -0,0 +1,50 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import "strings"
+
+// Annotate renders e in the manner of a compiler diagnostic: the error message, then the
+// input on a line of its own with a caret under the byte at which parsing failed.
+//
+//	cannot parse 2013-02-29: day out of valid range (day at byte 8)
+//	    2013-02-29
+//	            ^
+//
+// If e has no position, Annotate returns just the error message.  The caret is placed by
+// display column, so wide characters such as full-width digits count for two.
+func (e *ParseError) Annotate() string {
+	if e.Pos < 0 || e.Pos > len(e.Datetime) {
+		return e.Error()
+	}
+	const indent = "    "
+	col := 0
+	for _, r := range e.Datetime[:e.Pos] {
+		col += runeWidth(r)
+	}
+	var b strings.Builder
+	b.WriteString(e.Error())
+	b.WriteString("\n" + indent)
+	b.WriteString(e.Datetime)
+	b.WriteString("\n" + indent)
+	b.WriteString(strings.Repeat(" ", col))
+	b.WriteByte('^')
+	return b.String()
+}
+
+// runeWidth returns the number of columns r occupies in a terminal: 2 for East Asian wide
+// and full-width characters, and 1 for everything else.
+func runeWidth(r rune) int {
+	switch {
+	case 0x1100 <= r && r <= 0x115F, // Hangul Jamo
+		0x2E80 <= r && r <= 0xA4CF, // CJK through Yi
+		0xAC00 <= r && r <= 0xD7A3, // Hangul syllables
+		0xF900 <= r && r <= 0xFAFF, // CJK compatibility ideographs
+		0xFE30 <= r && r <= 0xFE4F, // CJK compatibility forms
+		0xFF00 <= r && r <= 0xFF60, // Full-width forms
+		0xFFE0 <= r && r <= 0xFFE6:
+		return 2
+	}
+	return 1
+}
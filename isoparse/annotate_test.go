@@ -0,0 +1,37 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import "testing"
+
+func TestAnnotate(t *testing.T) {
+	cases := map[string]string{
+		"2013-02-29": "cannot parse 2013-02-29: day out of valid range (day at byte 8)\n" +
+			"    2013-02-29\n" +
+			"            ^",
+		"2013-01-01T12:6x": "cannot parse 2013-01-01T12:6x: time components must be two digits in time \"12:6x\" (minute at byte 14)\n" +
+			"    2013-01-01T12:6x\n" +
+			"                  ^",
+		"２０１４-03-14": "cannot parse ２０１４-03-14: found U+FF12 FULLWIDTH DIGIT TWO where '2' expected (at byte 0)\n" +
+			"    ２０１４-03-14\n" +
+			"    ^",
+		"2014-03-14T12：30": "cannot parse 2014-03-14T12：30: found U+FF1A FULLWIDTH COLON where ':' expected (at byte 13)\n" +
+			"    2014-03-14T12：30\n" +
+			"                 ^",
+	}
+	for s, want := range cases {
+		_, err := ParseISODatetime(s)
+		e, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf(`ParseISODatetime(%q) -> %v (should be a *ParseError)`, s, err)
+		} else if got := e.Annotate(); got != want {
+			t.Errorf("Annotate() for %q ->\n%s\n(should be)\n%s", s, got, want)
+		}
+	}
+
+	e := &ParseError{"2013-01-01", "no position", -1, "", ErrSyntax}
+	if got := e.Annotate(); got != e.Error() {
+		t.Errorf(`Annotate() -> %q (should be %q)`, got, e.Error())
+	}
+}
@@ -0,0 +1,77 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"strings"
+	"time"
+)
+
+// Value is the result of ParseAny: one of the kinds of value that this package parses.
+// Kind says which, and so which of the other fields is set.
+type Value struct {
+	Kind      ValueKind
+	Date      Date      // For DateValue
+	TimeParts TimeParts // For TimeValue
+	Time      time.Time // For DateTimeValue
+	Period    Period    // For DurationValue
+	Interval  Interval  // For IntervalValue
+}
+
+// ParseAny parses s as whichever of a date, a time, a datetime, a duration, or an interval
+// it is, for input such as a form field that might hold any of them.  It tells them apart
+// by their shape: an interval has a '/' or "--" separator, a duration starts with 'P' (or
+// a sign and 'P'), and the rest are classified as by Classify, so that "2018" is a year
+// rather than the time 20:18.  Each is then parsed as by the package-level function for
+// its kind, and if s isn't valid, the error is the one from that function, or from
+// ParseISODatetime if s doesn't look like any of them.
+func ParseAny(s string) (Value, error) {
+	return defaultParser.ParseAny(s)
+}
+
+// ParseAny is like the package-level ParseAny, but parses with p.
+func (p *Parser) ParseAny(s string) (Value, error) {
+	switch {
+	case strings.IndexByte(s, '/') >= 0 || strings.Contains(s, "--"):
+		iv, err := p.ParseInterval(s)
+		if err != nil {
+			return Value{}, err
+		}
+		return Value{Kind: IntervalValue, Interval: iv}, nil
+	case strings.HasPrefix(s, "P") || strings.HasPrefix(s, "-P") || strings.HasPrefix(s, "+P"):
+		period, err := p.ParseDuration(s)
+		if err != nil {
+			return Value{}, err
+		}
+		return Value{Kind: DurationValue, Period: period}, nil
+	}
+	kind := UnknownValue
+	if format, _, err := detectFormat(s); err == nil {
+		kind = format.Kind()
+	} else if _, _, _, err := parseISOTime(s); err == nil {
+		// A time of the right shape with a component out of range, such as "25:00".
+		kind = TimeValue
+	}
+	switch kind {
+	case DateValue:
+		d, err := p.ParseDate(s)
+		if err != nil {
+			return Value{}, err
+		}
+		return Value{Kind: DateValue, Date: d}, nil
+	case TimeValue:
+		tp, err := p.ParseTime(s)
+		if err != nil {
+			return Value{}, err
+		}
+		return Value{Kind: TimeValue, TimeParts: tp}, nil
+	}
+	// Datetimes, and anything detectFormat can't place, such as a string with an RFC 9557
+	// annotation, are left to Parse.
+	t, err := p.Parse(s)
+	if err != nil {
+		return Value{}, err
+	}
+	return Value{Kind: DateTimeValue, Time: t}, nil
+}
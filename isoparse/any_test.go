@@ -0,0 +1,52 @@
+package isoparse
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseAny(t *testing.T) {
+	p := NewParser(WithLocation(time.UTC))
+	for _, c := range []struct {
+		s    string
+		want Value
+	}{
+		{"2018-09-27", Value{Kind: DateValue, Date: Date{2018, time.September, 27}}},
+		{"2018", Value{Kind: DateValue, Date: Date{2018, time.January, 1}}},
+		{"2018-W39-4", Value{Kind: DateValue, Date: Date{2018, time.September, 27}}},
+		{"0530", Value{Kind: DateValue, Date: Date{530, time.January, 1}}},
+		{"05:00:00Z", Value{Kind: TimeValue, TimeParts: TimeParts{TimeOfDay{5, 0, 0, 0}, time.UTC, true}}},
+		{"05:30", Value{Kind: TimeValue, TimeParts: TimeParts{TimeOfDay{5, 30, 0, 0}, time.UTC, false}}},
+		{"2018-09-27T05:00:00Z", Value{Kind: DateTimeValue, Time: time.Date(2018, 9, 27, 5, 0, 0, 0, time.UTC)}},
+		{"2018-09-27T05:00:00Z[UTC]", Value{Kind: DateTimeValue, Time: time.Date(2018, 9, 27, 5, 0, 0, 0, time.UTC)}},
+		{"P1DT2H", Value{Kind: DurationValue, Period: Period{Days: 1, Hours: 2}}},
+		{"-P1D", Value{Kind: DurationValue, Period: Period{Negative: true, Days: 1}}},
+		{"2018-09-27T00:00Z/P1D", Value{Kind: IntervalValue, Interval: Interval{
+			time.Date(2018, 9, 27, 0, 0, 0, 0, time.UTC), time.Date(2018, 9, 28, 0, 0, 0, 0, time.UTC)}}},
+		{"2018-09-27--2018-09-28", Value{Kind: IntervalValue, Interval: Interval{
+			time.Date(2018, 9, 27, 0, 0, 0, 0, time.UTC), time.Date(2018, 9, 28, 0, 0, 0, 0, time.UTC)}}},
+	} {
+		got, err := p.ParseAny(c.s)
+		if err != nil || got.Kind != c.want.Kind || got.Date != c.want.Date || got.TimeParts != c.want.TimeParts ||
+			!got.Time.Equal(c.want.Time) || got.Period != c.want.Period || !got.Interval.Equal(c.want.Interval) {
+			t.Errorf(`ParseAny(%q) -> (%+v, %v) (should be %+v)`, c.s, got, err, c.want)
+		}
+	}
+}
+
+func TestParseAnyErrors(t *testing.T) {
+	for s, want := range map[string]error{
+		"2018-02-30":                ErrInvalidDay,
+		"25:00":                     ErrTimeRange,
+		"2018-09-27T05:00:00+25:00": ErrInvalidOffset,
+		"P1X":                       ErrSyntax,
+		"2018-09-28/2018-09-27":     ErrIntervalOrder,
+		"yesterday":                 ErrSyntax,
+		"":                          ErrSyntax,
+	} {
+		if v, err := ParseAny(s); !errors.Is(err, want) || v.Kind != UnknownValue {
+			t.Errorf(`ParseAny(%q) -> (%+v, %v) (should wrap %v)`, s, v, err, want)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// An ItemError records an element of a batch that could not be parsed.
+type ItemError struct {
+	Index int // 0-based index into the batch
+	Err   error
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("isoparse: item %d: %v", e.Index, e.Err)
+}
+
+func (e *ItemError) Unwrap() error { return e.Err }
+
+// A BatchError collects every failure from parsing a batch, rather than just the first,
+// so that a bad record doesn't hide the others.  It unwraps to the individual
+// *ItemErrors, as errors.Join does, so errors.Is and errors.As look through all of them.
+type BatchError struct {
+	Errors []*ItemError // In order of Index
+}
+
+func (e *BatchError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// ByIndex returns the errors keyed by the index of the item that caused them.
+func (e *BatchError) ByIndex() map[int]error {
+	m := make(map[int]error, len(e.Errors))
+	for _, err := range e.Errors {
+		m[err.Index] = err.Err
+	}
+	return m
+}
+
+// ParseAll parses each of datetimes as with ParseISODatetime.  It parses the whole batch
+// even if some items fail: the result has an element for every input, zero where that
+// input failed, and the error is a *BatchError recording each failure.
+func ParseAll(datetimes []string) ([]time.Time, error) {
+	return defaultParser.ParseAll(datetimes)
+}
+
+// ParseAll is like the package-level ParseAll, but parses with p.
+func (p *Parser) ParseAll(datetimes []string) ([]time.Time, error) {
+	times := make([]time.Time, len(datetimes))
+	var batchErr BatchError
+	for i, s := range datetimes {
+		t, err := p.Parse(s)
+		if err != nil {
+			batchErr.Errors = append(batchErr.Errors, &ItemError{i, err})
+			continue
+		}
+		times[i] = t
+	}
+	if batchErr.Errors != nil {
+		return times, &batchErr
+	}
+	return times, nil
+}
+
+// ParseISODatetimes parses each of inputs as with a Parser created with opts, sharding the
+// work across GOMAXPROCS goroutines.  It is meant for bulk jobs with millions of inputs;
+// the result is in input order, and failures are reported as with ParseAll.
+func ParseISODatetimes(inputs []string, opts ...Option) ([]time.Time, error) {
+	p := NewParser(opts...)
+	times := make([]time.Time, len(inputs))
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(inputs) {
+		workers = len(inputs)
+	}
+	if workers <= 1 {
+		return p.ParseAll(inputs)
+	}
+
+	// Each worker takes a contiguous shard, so the errors it collects are in order, and
+	// the shards' errors can simply be concatenated.
+	shardErrs := make([][]*ItemError, workers)
+	shardSize := (len(inputs) + workers - 1) / workers
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		start, end := w*shardSize, (w+1)*shardSize
+		if end > len(inputs) {
+			end = len(inputs)
+		}
+		wg.Add(1)
+		go func(w, start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				t, err := p.Parse(inputs[i])
+				if err != nil {
+					shardErrs[w] = append(shardErrs[w], &ItemError{i, err})
+					continue
+				}
+				times[i] = t
+			}
+		}(w, start, end)
+	}
+	wg.Wait()
+
+	var batchErr BatchError
+	for _, errs := range shardErrs {
+		batchErr.Errors = append(batchErr.Errors, errs...)
+	}
+	if batchErr.Errors != nil {
+		return times, &batchErr
+	}
+	return times, nil
+}
@@ -0,0 +1,68 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseAll(t *testing.T) {
+	times, err := ParseAll([]string{"2018-09-27T05:00Z", "2018-02-30", "20180927T0600Z", "2018-09-27Tnoon"})
+	if len(times) != 4 || !times[0].Equal(time.Date(2018, 9, 27, 5, 0, 0, 0, time.UTC)) || !times[1].IsZero() || times[3] != (time.Time{}) {
+		t.Errorf(`ParseAll -> %v`, times)
+	}
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 2 {
+		t.Fatalf(`ParseAll -> %v (should be a *BatchError with 2 errors)`, err)
+	}
+	byIndex := batchErr.ByIndex()
+	if !errors.Is(byIndex[1], ErrInvalidDay) || !errors.Is(byIndex[3], ErrSyntax) || byIndex[0] != nil {
+		t.Errorf(`ByIndex() -> %v`, byIndex)
+	}
+	if !errors.Is(err, ErrInvalidDay) {
+		t.Errorf(`errors.Is(%v, ErrInvalidDay) -> false (should be true)`, err)
+	}
+	var itemErr *ItemError
+	if !errors.As(err, &itemErr) || itemErr.Index != 1 {
+		t.Errorf(`errors.As(err, *ItemError) -> %v (should be item 1)`, itemErr)
+	}
+	if want := "isoparse: item 1: " + byIndex[1].Error() + "\nisoparse: item 3: " + byIndex[3].Error(); err.Error() != want {
+		t.Errorf(`BatchError.Error() -> %q (should be %q)`, err.Error(), want)
+	}
+
+	if _, err := ParseAll([]string{"2018-09-27", "2018-W39"}); err != nil {
+		t.Errorf(`ParseAll -> non-nil error (%v) for valid batch`, err)
+	}
+}
+
+func TestParseISODatetimes(t *testing.T) {
+	inputs := make([]string, 1000)
+	for i := range inputs {
+		inputs[i] = time.Date(2018, 9, 27, 0, i, 0, 0, time.UTC).Format(time.RFC3339)
+	}
+	inputs[10], inputs[999] = "2018-02-30", "bad"
+	times, err := ParseISODatetimes(inputs, WithLocation(time.UTC))
+	for i, tm := range times {
+		if i == 10 || i == 999 {
+			if !tm.IsZero() {
+				t.Errorf(`ParseISODatetimes()[%d] -> %v (should be zero)`, i, tm)
+			}
+		} else if want := time.Date(2018, 9, 27, 0, i, 0, 0, time.UTC); !tm.Equal(want) {
+			t.Errorf(`ParseISODatetimes()[%d] -> %v (should be %v)`, i, tm, want)
+		}
+	}
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 2 || batchErr.Errors[0].Index != 10 || batchErr.Errors[1].Index != 999 {
+		t.Errorf(`ParseISODatetimes -> %v (should be a *BatchError for items 10 and 999)`, err)
+	}
+
+	if times, err := ParseISODatetimes(nil); len(times) != 0 || err != nil {
+		t.Errorf(`ParseISODatetimes(nil) -> %v, %v`, times, err)
+	}
+	if times, err := ParseISODatetimes([]string{"2018-09-27"}, WithLocation(time.UTC)); err != nil || !times[0].Equal(time.Date(2018, 9, 27, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf(`ParseISODatetimes(one) -> %v, %v`, times, err)
+	}
+}
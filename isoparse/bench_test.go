@@ -0,0 +1,112 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// Benchmarks and allocation limits
+//
+// benchDatetimes covers each format class, with and without offsets and fractions.
+// maxAllocs is the most allocations that parsing the string may make; TestAllocs fails if
+// a change goes over it.  Lower it when an optimization lands, so that it stays lowered.
+
+var benchDatetimes = []struct {
+	name      string
+	datetime  string
+	maxAllocs int // -1 for no limit
+}{
+	{"Date", "2018-09-27", 0},
+	{"DateBasic", "20180927", 0},
+	{"YearMonth", "2018-09", 0},
+	// The common-date attempt builds an error before the week or ordinal parse takes over.
+	{"WeekDate", "2018-W39-4", 1},
+	{"WeekDateBasic", "2018W394", 1},
+	{"OrdinalDate", "2018-270", 1},
+	{"Naive", "2018-09-27T05:00:00", 0},
+	{"NaiveBasic", "20180927T050000", 0},
+	{"NaiveFraction", "2018-09-27T05:00:00.123456", 0},
+	{"UTC", "2018-09-27T05:00:00Z", 0},
+	{"Offset", "2018-09-27T05:00:00+05:30", 0},
+	{"OffsetFraction", "2018-09-27T05:00:00.123456789+05:30", 0},
+	{"OffsetBasic", "20180927T050000.123-0800", 0},
+	{"OffsetHoursOnly", "2018-09-27T05:00-08", 0},
+	// Loading a zone reads the tz database, so there's no sensible limit.
+	{"Annotated", "2018-09-27T05:00:00+02:00[Europe/Paris]", -1},
+	// One for strictDate's range error and one for the ParseError that replaces it.
+	{"Invalid", "2018-02-30T05:00:00Z", 2},
+}
+
+func BenchmarkParseISODatetime(b *testing.B) {
+	for _, c := range benchDatetimes {
+		b.Run(c.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				ParseISODatetime(c.datetime)
+			}
+		})
+	}
+}
+
+func BenchmarkParseISODate(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ParseISODate("2018-09-27")
+	}
+}
+
+func BenchmarkParseISOTimeParts(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ParseISOTimeParts("05:00:00.123+05:30")
+	}
+}
+
+func BenchmarkParseISODuration(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ParseISODuration("P1Y2M3DT4H5M6.5S")
+	}
+}
+
+func BenchmarkParseISODatetimes(b *testing.B) {
+	inputs := make([]string, 10000)
+	for i := range inputs {
+		inputs[i] = time.Date(2018, 9, 27, 0, 0, i, 0, time.UTC).Format(time.RFC3339Nano)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ParseISODatetimes(inputs)
+	}
+}
+
+// assertMaxAllocs fails t if f allocates more than max times on average.
+// f is run once beforehand, so that caches (such as for offset zones) are warm.
+func assertMaxAllocs(t *testing.T, name string, max int, f func()) {
+	t.Helper()
+	f()
+	if n := testing.AllocsPerRun(100, f); n > float64(max) {
+		t.Errorf(`%s allocates %v times (should be at most %d)`, name, n, max)
+	}
+}
+
+func TestAllocs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping allocation counts in short mode")
+	}
+	for _, c := range benchDatetimes {
+		if c.maxAllocs >= 0 {
+			assertMaxAllocs(t, fmt.Sprintf("ParseISODatetime(%q)", c.datetime), c.maxAllocs, func() { ParseISODatetime(c.datetime) })
+		}
+	}
+	assertMaxAllocs(t, "ParseISODate", 0, func() { ParseISODate("2018-09-27") })
+	assertMaxAllocs(t, "ParseISOTimeParts", 0, func() { ParseISOTimeParts("05:00:00.123+05:30") })
+	assertMaxAllocs(t, "ParseISODuration", 0, func() { ParseISODuration("P1Y2M3DT4H5M6.5S") })
+	b := []byte("2018-09-27T05:00:00Z")
+	assertMaxAllocs(t, "ParseISODatetimeBytes", 0, func() { ParseISODatetimeBytes(b) })
+}
@@ -0,0 +1,223 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+// Binary encoding
+//
+// Each value type implements encoding.BinaryMarshaler and encoding.BinaryUnmarshaler,
+// which binary codecs such as MessagePack and CBOR libraries (and encoding/gob) fall back on,
+// so values don't have to round trip through their string forms.
+//
+// The layout is a version byte (currently 1) followed by the type's fields in declaration
+// order.  Integer fields, including a time.Month, are signed varints as written by
+// encoding/binary.AppendVarint; a Period's Negative field is a single byte, 0 or 1.
+// An Interval's two instants are each written as a uvarint length followed by the output of
+// time.Time.MarshalBinary.  So, for example, Date{2018, time.September, 27} is
+//
+//	01 c4 1f 12 36
+//
+// The encoding is lossless: values are not validated, and invalid values round trip as-is.
+
+const binaryVersion = 1
+
+var errBinary = errors.New("isoparse: invalid binary encoding")
+
+var (
+	_ encoding.BinaryMarshaler   = Date{}
+	_ encoding.BinaryUnmarshaler = (*Date)(nil)
+	_ encoding.BinaryMarshaler   = YearMonth{}
+	_ encoding.BinaryUnmarshaler = (*YearMonth)(nil)
+	_ encoding.BinaryMarshaler   = TimeOfDay{}
+	_ encoding.BinaryUnmarshaler = (*TimeOfDay)(nil)
+	_ encoding.BinaryMarshaler   = DateTime{}
+	_ encoding.BinaryUnmarshaler = (*DateTime)(nil)
+	_ encoding.BinaryMarshaler   = Period{}
+	_ encoding.BinaryUnmarshaler = (*Period)(nil)
+	_ encoding.BinaryMarshaler   = Interval{}
+	_ encoding.BinaryUnmarshaler = (*Interval)(nil)
+)
+
+// appendInts writes the version byte followed by each of ns as a varint.
+func appendInts(b []byte, ns ...int) []byte {
+	if len(b) == 0 {
+		b = append(b, binaryVersion)
+	}
+	for _, n := range ns {
+		b = binary.AppendVarint(b, int64(n))
+	}
+	return b
+}
+
+// binaryReader consumes an encoding produced by appendInts and friends.
+// The first error sticks, so callers check it once at the end.
+type binaryReader struct {
+	data []byte
+	err  error
+}
+
+func newBinaryReader(data []byte) *binaryReader {
+	if len(data) == 0 || data[0] != binaryVersion {
+		return &binaryReader{err: errBinary}
+	}
+	return &binaryReader{data: data[1:]}
+}
+
+func (r *binaryReader) int() int {
+	if r.err != nil {
+		return 0
+	}
+	n, size := binary.Varint(r.data)
+	if size <= 0 || int64(int(n)) != n {
+		r.err = errBinary
+		return 0
+	}
+	r.data = r.data[size:]
+	return int(n)
+}
+
+func (r *binaryReader) bytes() []byte {
+	if r.err != nil {
+		return nil
+	}
+	n, size := binary.Uvarint(r.data)
+	if size <= 0 || n > uint64(len(r.data)-size) {
+		r.err = errBinary
+		return nil
+	}
+	b := r.data[size : size+int(n)]
+	r.data = r.data[size+int(n):]
+	return b
+}
+
+// done reports the sticky error, or an error if anything is left unread.
+func (r *binaryReader) done() error {
+	if r.err == nil && len(r.data) != 0 {
+		return errBinary
+	}
+	return r.err
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (d Date) MarshalBinary() ([]byte, error) {
+	return appendInts(nil, d.Year, int(d.Month), d.Day), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (d *Date) UnmarshalBinary(data []byte) error {
+	r := newBinaryReader(data)
+	parsed := Date{r.int(), time.Month(r.int()), r.int()}
+	if err := r.done(); err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (ym YearMonth) MarshalBinary() ([]byte, error) {
+	return appendInts(nil, ym.Year, int(ym.Month)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (ym *YearMonth) UnmarshalBinary(data []byte) error {
+	r := newBinaryReader(data)
+	parsed := YearMonth{r.int(), time.Month(r.int())}
+	if err := r.done(); err != nil {
+		return err
+	}
+	*ym = parsed
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (t TimeOfDay) MarshalBinary() ([]byte, error) {
+	return appendInts(nil, t.Hour, t.Minute, t.Second, t.Nanosecond), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (t *TimeOfDay) UnmarshalBinary(data []byte) error {
+	r := newBinaryReader(data)
+	parsed := TimeOfDay{r.int(), r.int(), r.int(), r.int()}
+	if err := r.done(); err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (dt DateTime) MarshalBinary() ([]byte, error) {
+	d, t := dt.Date, dt.Time
+	return appendInts(nil, d.Year, int(d.Month), d.Day, t.Hour, t.Minute, t.Second, t.Nanosecond), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (dt *DateTime) UnmarshalBinary(data []byte) error {
+	r := newBinaryReader(data)
+	parsed := DateTime{Date{r.int(), time.Month(r.int()), r.int()}, TimeOfDay{r.int(), r.int(), r.int(), r.int()}}
+	if err := r.done(); err != nil {
+		return err
+	}
+	*dt = parsed
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (p Period) MarshalBinary() ([]byte, error) {
+	b := []byte{binaryVersion, byte(btoi(p.Negative))}
+	return appendInts(b, p.Years, p.Months, p.Weeks, p.Days, p.Hours, p.Minutes, p.Seconds, p.Nanoseconds), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (p *Period) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 || data[1] > 1 {
+		return errBinary
+	}
+	r := newBinaryReader(append([]byte{data[0]}, data[2:]...))
+	parsed := Period{data[1] == 1, r.int(), r.int(), r.int(), r.int(), r.int(), r.int(), r.int(), r.int()}
+	if err := r.done(); err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (iv Interval) MarshalBinary() ([]byte, error) {
+	b := []byte{binaryVersion}
+	for _, t := range [...]time.Time{iv.Start, iv.End} {
+		enc, err := t.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		b = binary.AppendUvarint(b, uint64(len(enc)))
+		b = append(b, enc...)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (iv *Interval) UnmarshalBinary(data []byte) error {
+	r := newBinaryReader(data)
+	start, end := r.bytes(), r.bytes()
+	if err := r.done(); err != nil {
+		return err
+	}
+	var parsed Interval
+	if err := parsed.Start.UnmarshalBinary(start); err != nil {
+		return err
+	}
+	if err := parsed.End.UnmarshalBinary(end); err != nil {
+		return err
+	}
+	*iv = parsed
+	return nil
+}
@@ -0,0 +1,89 @@
+package isoparse
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type binaryValue interface {
+	encoding.BinaryMarshaler
+}
+
+// Values and fresh pointers of the same type to decode them into.
+var binaryValues = []struct {
+	v    binaryValue
+	into encoding.BinaryUnmarshaler
+}{
+	{Date{2018, time.September, 27}, new(Date)},
+	{Date{-43, time.March, 15}, new(Date)},
+	{Date{}, new(Date)},
+	{YearMonth{2018, time.September}, new(YearMonth)},
+	{TimeOfDay{24, 0, 0, 0}, new(TimeOfDay)},
+	{TimeOfDay{11, 52, 59, 999999999}, new(TimeOfDay)},
+	{DateTime{Date{2018, time.September, 27}, TimeOfDay{11, 52, 59, 1}}, new(DateTime)},
+	{Period{Negative: true, Years: 1, Months: 2, Weeks: 3, Days: 4, Hours: 5, Minutes: 6, Seconds: 7, Nanoseconds: 8}, new(Period)},
+	{Period{}, new(Period)},
+	{Interval{time.Date(2018, 9, 27, 0, 0, 0, 0, time.UTC), time.Date(2018, 9, 28, 0, 0, 0, 1, time.FixedZone("", 3600))}, new(Interval)},
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	for _, c := range binaryValues {
+		data, err := c.v.MarshalBinary()
+		if err != nil {
+			t.Errorf(`%v.MarshalBinary() -> non-nil error (%v)`, c.v, err)
+			continue
+		}
+		if err := c.into.UnmarshalBinary(data); err != nil {
+			t.Errorf(`UnmarshalBinary(%x) into %T -> non-nil error (%v)`, data, c.into, err)
+			continue
+		}
+		got := reflect.ValueOf(c.into).Elem().Interface()
+		if iv, ok := got.(Interval); ok {
+			if !iv.Equal(c.v.(Interval)) {
+				t.Errorf(`UnmarshalBinary(%x) into %T -> %v (should be %v)`, data, c.into, got, c.v)
+			}
+		} else if got != c.v {
+			t.Errorf(`UnmarshalBinary(%x) into %T -> %v (should be %v)`, data, c.into, got, c.v)
+		}
+		// Any truncation of the encoding should be an error, not a panic.
+		for i := 0; i < len(data); i++ {
+			if err := c.into.UnmarshalBinary(data[:i]); err == nil {
+				t.Errorf(`UnmarshalBinary(%x) into %T returned nil error for truncated data`, data[:i], c.into)
+			}
+		}
+		if err := c.into.UnmarshalBinary(append(data, 0)); err == nil {
+			t.Errorf(`UnmarshalBinary(%x) into %T returned nil error for trailing data`, append(data, 0), c.into)
+		}
+	}
+}
+
+// The layout is documented, so pin it down.
+func TestBinaryLayout(t *testing.T) {
+	data, _ := Date{2018, time.September, 27}.MarshalBinary()
+	if want := []byte{0x01, 0xc4, 0x1f, 0x12, 0x36}; !bytes.Equal(data, want) {
+		t.Errorf(`Date{2018, 9, 27}.MarshalBinary() -> % x (should be % x)`, data, want)
+	}
+	var d Date
+	if err := d.UnmarshalBinary([]byte{0x02, 0xc4, 0x1f, 0x12, 0x36}); err == nil {
+		t.Errorf(`Date.UnmarshalBinary with unknown version returned nil error`)
+	}
+}
+
+func TestBinaryGob(t *testing.T) {
+	in := sampleCivilRecord
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf(`gob Encode(%v) -> non-nil error (%v)`, in, err)
+	}
+	var out civilRecord
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatalf(`gob Decode -> non-nil error (%v)`, err)
+	}
+	if out.Date != in.Date || out.Time != in.Time || out.DateTime != in.DateTime || out.Period != in.Period || !out.Interval.Equal(in.Interval) {
+		t.Errorf(`gob round trip of %v -> %v`, in, out)
+	}
+}
@@ -0,0 +1,65 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"bytes"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+// The Bytes variants parse directly from a []byte, for callers reading from bufio,
+// JSON decoders, or network buffers, without the copy that a string conversion makes.
+// The input is only read, and nothing that refers to it outlives the call: errors get
+// their own copy of it, and so do the zone names of RFC 9557 annotations (which are
+// rare enough that such inputs are simply converted).
+
+// ParseISODatetimeBytes is like ParseISODatetime, but parses b.
+func ParseISODatetimeBytes(b []byte) (time.Time, error) {
+	return defaultParser.ParseBytes(b)
+}
+
+// ParseISODateBytes is like ParseISODate, but parses b.
+func ParseISODateBytes(b []byte) (time.Time, error) {
+	d, err := defaultParser.ParseDate(unsafeString(b))
+	if err != nil {
+		return time.Time{}, ownError(err)
+	}
+	return defaultParser.startOfDay(d), nil
+}
+
+// ParseISOTimeBytes is like ParseISOTimeParts, but parses b.
+func ParseISOTimeBytes(b []byte) (TimeParts, error) {
+	parts, err := defaultParser.ParseTime(unsafeString(b))
+	return parts, ownError(err)
+}
+
+// ParseBytes is like Parse, but parses b.
+func (p *Parser) ParseBytes(b []byte) (time.Time, error) {
+	if bytes.IndexByte(b, '[') >= 0 {
+		// The zone name would be kept by time.LoadLocation.
+		return p.Parse(string(b))
+	}
+	t, err := p.Parse(unsafeString(b))
+	return t, ownError(err)
+}
+
+// unsafeString returns a string that shares b's memory.  It must not outlive the call
+// that it's passed to, since the caller may reuse b.
+func unsafeString(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	return unsafe.String(&b[0], len(b))
+}
+
+// ownError copies the input held by a ParseError from unsafeString, so that the error may
+// safely be kept.
+func ownError(err error) error {
+	if e, ok := err.(*ParseError); ok {
+		e.Datetime = strings.Clone(e.Datetime)
+	}
+	return err
+}
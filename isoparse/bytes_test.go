@@ -0,0 +1,43 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseBytes(t *testing.T) {
+	inputs := append([]string{}, invalidDatetimes...)
+	for datetime := range differentSepISODatetimes {
+		inputs = append(inputs, datetime)
+	}
+	for _, datetime := range inputs {
+		want, wantErr := ParseISODatetime(datetime)
+		got, err := ParseISODatetimeBytes([]byte(datetime))
+		if !got.Equal(want) || (err == nil) != (wantErr == nil) {
+			t.Errorf(`ParseISODatetimeBytes(%q) -> %v, %v (should be %v, %v)`, datetime, got, err, want, wantErr)
+		}
+	}
+	if got, err := ParseISODatetimeBytes([]byte("2022-07-08T00:14:07+02:00[Europe/Paris]")); err != nil || got.Location().String() != "Europe/Paris" {
+		t.Errorf(`ParseISODatetimeBytes(annotated) -> %v, %v`, got, err)
+	}
+	if got, err := ParseISODateBytes([]byte("2018-W39-4")); err != nil || !got.Equal(time.Date(2018, 9, 27, 0, 0, 0, 0, time.Local)) {
+		t.Errorf(`ParseISODateBytes("2018-W39-4") -> %v, %v`, got, err)
+	}
+	if parts, err := ParseISOTimeBytes([]byte("13:47:30.5Z")); err != nil || parts.Second != 30 || parts.Nanosecond != 5e8 || !parts.HasOffset {
+		t.Errorf(`ParseISOTimeBytes("13:47:30.5Z") -> %v, %v`, parts, err)
+	}
+	if _, err := ParseISODatetimeBytes(nil); err == nil {
+		t.Errorf(`ParseISODatetimeBytes(nil) -> nil error`)
+	}
+
+	// The error must not change when the caller reuses its buffer.
+	b := []byte("2013-02-29")
+	_, err := ParseISODatetimeBytes(b)
+	copy(b, "XXXXXXXXXX")
+	if err == nil || err.(*ParseError).Datetime != "2013-02-29" {
+		t.Errorf(`ParseISODatetimeBytes("2013-02-29") -> %v after buffer reuse`, err)
+	}
+}
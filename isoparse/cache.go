@@ -0,0 +1,74 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WithCache gives a Parser a cache of the results for the n most recently parsed strings,
+// so that Parse can skip the work for repeated inputs.  It pays off for data such as
+// telemetry, where the same truncated timestamps (minute-precision bucket labels and the
+// like) turn up again and again; for inputs that rarely repeat, it only adds overhead.
+//
+// Only successful results are cached.  Results in time.Local are cached like any other,
+// so a Parser with a cache should not be used across a change to time.Local.
+// An n of zero or less removes the cache.
+func WithCache(n int) Option {
+	return func(p *Parser) {
+		p.cache = nil
+		if n > 0 {
+			p.cache = newParseCache(n)
+		}
+	}
+}
+
+// parseCache is a bounded LRU cache from input strings to parsed times.
+type parseCache struct {
+	mu    sync.Mutex
+	size  int
+	order *list.List               // Front is the most recently used
+	items map[string]*list.Element // Values are *cacheEntry
+}
+
+type cacheEntry struct {
+	key string
+	t   time.Time
+}
+
+func newParseCache(size int) *parseCache {
+	return &parseCache{size: size, order: list.New(), items: make(map[string]*list.Element, size)}
+}
+
+func (c *parseCache) get(key string) (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[key]
+	if !ok {
+		return time.Time{}, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*cacheEntry).t, true
+}
+
+func (c *parseCache) add(key string, t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[key]; ok {
+		// Another goroutine parsed the same string in the meantime.
+		c.order.MoveToFront(e)
+		return
+	}
+	if c.order.Len() >= c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*cacheEntry).key)
+	}
+	// The key may share memory with a []byte (see ParseBytes), so it must be copied.
+	key = strings.Clone(key)
+	c.items[key] = c.order.PushFront(&cacheEntry{key, t})
+}
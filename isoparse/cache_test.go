@@ -0,0 +1,52 @@
+package isoparse
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestParserWithCache(t *testing.T) {
+	p := NewParser(WithLocation(time.UTC), WithCache(2))
+	for datetime, c := range allFormats {
+		want := SetLoc(c.t, time.UTC)
+		if c.t.Location() != time.Local {
+			want = c.t
+		}
+		for i := 0; i < 2; i++ {
+			if got, err := p.Parse(datetime); err != nil || !got.Equal(want) {
+				t.Errorf(`Parse(%q) with cache, call %d -> (%v, %v) (should be %v)`, datetime, i+1, got, err, want)
+			}
+		}
+	}
+	if n := len(p.cache.items); n != 2 {
+		t.Errorf(`cache holds %d entries (should be 2)`, n)
+	}
+	if _, err := p.Parse("2018-02-30"); err == nil {
+		t.Errorf(`Parse("2018-02-30") with cache -> nil error for invalid date`)
+	}
+	if _, ok := p.cache.get("2018-02-30"); ok {
+		t.Errorf(`cache holds "2018-02-30" (errors should not be cached)`)
+	}
+}
+
+func TestParseCacheEviction(t *testing.T) {
+	c := newParseCache(3)
+	for i := 0; i < 3; i++ {
+		c.add(fmt.Sprint(i), time.Unix(int64(i), 0))
+	}
+	c.get("0") // "1" is now the least recently used.
+	c.add("3", time.Unix(3, 0))
+	for key, want := range map[string]bool{"0": true, "1": false, "2": true, "3": true} {
+		if _, ok := c.get(key); ok != want {
+			t.Errorf(`cache.get(%q) -> %v (should be %v)`, key, ok, want)
+		}
+	}
+}
+
+func TestWithCacheDisabled(t *testing.T) {
+	p := NewParser(WithCache(10), WithCache(0))
+	if p.cache != nil {
+		t.Errorf(`WithCache(0) left a cache in place`)
+	}
+}
@@ -0,0 +1,415 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"fmt"
+	"time"
+)
+
+// Civil (calendar) value types.
+//
+// A time.Time is an instant and always carries a location.  Much of the data that gets
+// fed through this package is really just calendar data, though: "2018-09-27" names a
+// day on the wall calendar, not a 24-hour span starting at some particular instant.
+// The types here represent those values directly, with no location attached.
+
+// Date represents a date on the proleptic Gregorian calendar, with no time-of-day
+// and no location.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// YearMonth represents a calendar month of a given year, such as "2018-09".
+type YearMonth struct {
+	Year  int
+	Month time.Month
+}
+
+// DateOf returns the date on which t falls, in t's own location.
+// To get the date in some other location, convert t first with time.Time.In.
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{year, month, day}
+}
+
+// TimeOfDayOf returns the wall-clock time of t, in t's own location.
+// The result never has an Hour of 24.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	return TimeOfDay{t.Hour(), t.Minute(), t.Second(), t.Nanosecond()}
+}
+
+// DateTimeOf returns the wall-clock date and time of t, in t's own location.
+func DateTimeOf(t time.Time) DateTime {
+	return DateTime{DateOf(t), TimeOfDayOf(t)}
+}
+
+// clampDay builds a Date, pulling `day` back to the last day of the month if it
+// would otherwise overflow.
+func clampDay(year int, month time.Month, day int) Date {
+	if last := daysInMonth(year, month); day > last {
+		day = last
+	}
+	return Date{year, month, day}
+}
+
+// addMonths moves (year, month) by n months, with no notion of days.
+func addMonths(year int, month time.Month, n int) (int, time.Month) {
+	// Work 0-indexed so that the floor division below is straightforward.
+	m := year*12 + int(month) - 1 + n
+	year, rem := m/12, m%12
+	if rem < 0 {
+		year, rem = year-1, rem+12
+	}
+	return year, time.Month(rem + 1)
+}
+
+// IsValid reports whether d is a valid date, i.e. whether each component is
+// within its range as defined for the given year and month.
+func (d Date) IsValid() bool {
+	return d.Month >= minMonth && d.Month <= maxMonth && d.Day >= 1 && d.Day <= daysInMonth(d.Year, d.Month)
+}
+
+// String returns the date in ISO-8601 extended format, YYYY-MM-DD.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// In returns the instant of midnight at the start of d in the given location.
+// If midnight does not exist on d (a few zones have made their DST transitions at
+// midnight), the result is the first instant of the day; see DateTime.In.
+//
+// There is deliberately no default location: like time.Date, In panics if loc is nil.
+func (d Date) In(loc *time.Location) time.Time {
+	return DateTime{Date: d}.In(loc)
+}
+
+// YearDay returns the day of the year on which d falls, in the range [1, 365] in
+// non-leap years and [1, 366] in leap years: the DDD of an ordinal date, YYYY-DDD.
+func (d Date) YearDay() int {
+	return daysBeforeMonth(d.Year, d.Month) + d.Day
+}
+
+// DateFromYearDay returns the date of day yearDay of year, the inverse of Date.YearDay.
+// yearDay must be in the range [1, 365], or [1, 366] in a leap year; otherwise the error
+// wraps ErrInvalidDay.
+func DateFromYearDay(year, yearDay int) (Date, error) {
+	if yearDay < 1 || yearDay > 365+btoi(isLeapYear(year)) {
+		return Date{}, fmt.Errorf("isoparse: day %d out of range for year %d: %w", yearDay, year, ErrInvalidDay)
+	}
+	month := time.December
+	for daysBeforeMonth(year, month) >= yearDay {
+		month--
+	}
+	return Date{year, month, yearDay - daysBeforeMonth(year, month)}, nil
+}
+
+// YearMonth returns the month that d falls in.
+func (d Date) YearMonth() YearMonth {
+	return YearMonth{d.Year, d.Month}
+}
+
+// AddDays returns the date n days after d.  (n may be negative.)
+// There is no clamping involved here; this is plain day counting.
+func (d Date) AddDays(n int) Date {
+	return DateOf(time.Date(d.Year, d.Month, d.Day+n, 0, 0, 0, 0, time.UTC))
+}
+
+// AddMonths returns the date n months after d.  (n may be negative.)
+//
+// Unlike time.Time.AddDate, the day is clamped to the end of the resulting month
+// rather than overflowing into the next one: January 31 plus one month is February 28
+// (or 29 in a leap year), not March 3.
+func (d Date) AddMonths(n int) Date {
+	year, month := addMonths(d.Year, d.Month, n)
+	return clampDay(year, month, d.Day)
+}
+
+// AddYears returns the date n years after d.  (n may be negative.)
+//
+// As with AddMonths, the result is clamped rather than normalized:
+// February 29 plus one year is February 28 of the following year.
+func (d Date) AddYears(n int) Date {
+	return clampDay(d.Year+n, d.Month, d.Day)
+}
+
+// IsValid reports whether ym has a valid month.
+func (ym YearMonth) IsValid() bool {
+	return ym.Month >= minMonth && ym.Month <= maxMonth
+}
+
+// String returns the month in ISO-8601 extended format, YYYY-MM.
+func (ym YearMonth) String() string {
+	return fmt.Sprintf("%04d-%02d", ym.Year, ym.Month)
+}
+
+// Days returns the number of days in the month, or 0 if ym is not valid.
+func (ym YearMonth) Days() int {
+	if !ym.IsValid() {
+		return 0
+	}
+	return daysInMonth(ym.Year, ym.Month)
+}
+
+// FirstDay returns the first day of the month.
+func (ym YearMonth) FirstDay() Date {
+	return Date{ym.Year, ym.Month, 1}
+}
+
+// LastDay returns the last day of the month, or the zero Date if ym is not valid.
+func (ym YearMonth) LastDay() Date {
+	if !ym.IsValid() {
+		return Date{}
+	}
+	return Date{ym.Year, ym.Month, ym.Days()}
+}
+
+// AddMonths returns the month n months after ym.  (n may be negative.)
+func (ym YearMonth) AddMonths(n int) YearMonth {
+	year, month := addMonths(ym.Year, ym.Month, n)
+	return YearMonth{year, month}
+}
+
+// AddYears returns the month n years after ym.  (n may be negative.)
+func (ym YearMonth) AddYears(n int) YearMonth {
+	return YearMonth{ym.Year + n, ym.Month}
+}
+
+// TimeOfDay represents a wall-clock time of day with no date and no location.
+//
+// An Hour of 24 (with all other components zero) is permitted as in the ISO-8601
+// standard: it represents midnight at the end of a day, and compares after every
+// other time of day.
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// DateTime represents a date and a wall-clock time with no location, i.e. a
+// "naive" datetime in Python's terms.
+type DateTime struct {
+	Date Date
+	Time TimeOfDay
+}
+
+// IsValid reports whether each component of t is within its valid range.
+func (t TimeOfDay) IsValid() bool {
+	if t.Hour == maxHour {
+		return t.Minute == 0 && t.Second == 0 && t.Nanosecond == 0
+	}
+	return t.Hour >= minHour && t.Hour < maxHour &&
+		t.Minute >= minMin && t.Minute <= maxMin &&
+		t.Second >= minSec && t.Second <= maxSec &&
+		t.Nanosecond >= minNsec && t.Nanosecond <= maxNsec
+}
+
+// String returns the time in ISO-8601 extended format, HH:MM:SS, followed by
+// a fraction of a second if t has a nonzero Nanosecond.  Trailing zeros are dropped
+// from the fraction.
+func (t TimeOfDay) String() string {
+	s := fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	if t.Nanosecond == 0 {
+		return s
+	}
+	frac := fmt.Sprintf("%09d", t.Nanosecond)
+	for frac[len(frac)-1] == '0' {
+		frac = frac[:len(frac)-1]
+	}
+	return s + "." + frac
+}
+
+// SinceMidnight returns the time elapsed on the wall clock since the start of the day,
+// e.g. 14h30m for "14:30:00".  24:00 yields 24 hours.
+//
+// This is a wall-clock figure: on a day with a DST transition, the actual time elapsed
+// since midnight differs by the size of the transition.
+func (t TimeOfDay) SinceMidnight() time.Duration {
+	return time.Duration(t.Hour)*time.Hour + time.Duration(t.Minute)*time.Minute +
+		time.Duration(t.Second)*time.Second + time.Duration(t.Nanosecond)
+}
+
+// TimeOfDayFromDuration is the inverse of TimeOfDay.SinceMidnight.
+// d must be in the range [0, 24h]; exactly 24h yields 24:00.
+func TimeOfDayFromDuration(d time.Duration) (TimeOfDay, error) {
+	if d < 0 || d > 24*time.Hour {
+		return TimeOfDay{}, fmt.Errorf("isoparse: duration %v out of range for a time of day", d)
+	}
+	hour := d / time.Hour
+	d -= hour * time.Hour
+	minute := d / time.Minute
+	d -= minute * time.Minute
+	second := d / time.Second
+	d -= second * time.Second
+	return TimeOfDay{int(hour), int(minute), int(second), int(d)}, nil
+}
+
+// On returns the instant at which the wall clock in loc reads t on date d.
+// It is shorthand for DateTime{d, t}.In(loc), and follows the same policy for hour 24
+// and for wall times made ambiguous or nonexistent by DST transitions.
+func (t TimeOfDay) On(d Date, loc *time.Location) time.Time {
+	return DateTime{d, t}.In(loc)
+}
+
+// CombineDateAndTime joins a date and a time that were parsed separately, as by
+// ParseISODate and ParseISOTimeParts, into the instant that ParseISODatetime would give
+// for the two written together:
+//
+//   - The date is the year, month, and day of date in its own location; its clock is
+//     ignored.
+//   - If tp has a UTC offset, the result is in tp.Loc, whatever the location of date.
+//     ParseISODate attaches a location only as a default, so the offset written with the
+//     time wins.
+//   - Otherwise the result is in the location of date, and wall times made ambiguous or
+//     nonexistent by DST transitions are resolved as by DateTime.In.
+//   - An Hour of 24 rolls over to midnight at the start of the next day.
+//
+// If tp.TimeOfDay isn't valid, the error wraps ErrTimeRange.
+func CombineDateAndTime(date time.Time, tp TimeParts) (time.Time, error) {
+	t := tp.TimeOfDay
+	if !t.IsValid() {
+		return time.Time{}, fmt.Errorf("isoparse: time of day %v out of range: %w", t, ErrTimeRange)
+	}
+	d := DateOf(date)
+	if tp.HasOffset && tp.Loc != nil {
+		return time.Date(d.Year, d.Month, d.Day, t.Hour, t.Minute, t.Second, t.Nanosecond, tp.Loc), nil
+	}
+	return t.On(d, date.Location()), nil
+}
+
+// IsValid reports whether both the date and the time of dt are valid.
+func (dt DateTime) IsValid() bool {
+	return dt.Date.IsValid() && dt.Time.IsValid()
+}
+
+// In returns the instant at which the wall clock in the given location reads dt.
+//
+// An Hour of 24 rolls over to midnight of the following day.  Around DST transitions,
+// the policy is fixed rather than left up to time.Date:
+//
+//   - If dt occurs twice in loc (clocks fell back), the earlier instant is returned.
+//   - If dt never occurs in loc (clocks sprang forward), it is shifted forward by the
+//     length of the gap: 02:30 on a day when clocks jump from 02:00 to 03:00 becomes 03:30.
+//
+// There is deliberately no default location: like time.Date, In panics if loc is nil.
+func (dt DateTime) In(loc *time.Location) time.Time {
+	if loc == nil {
+		panic("isoparse: DateTime.In called with nil location")
+	}
+	d, t := dt.Date, dt.Time
+	earliest, latest, gap := wallCandidates(d.Year, d.Month, d.Day, t.Hour, t.Minute, t.Second, t.Nanosecond, loc)
+	if gap {
+		return latest
+	}
+	return earliest
+}
+
+// String returns the datetime in ISO-8601 extended format, YYYY-MM-DDTHH:MM:SS[.fff].
+func (dt DateTime) String() string {
+	return dt.Date.String() + "T" + dt.Time.String()
+}
+
+// Comparisons
+//
+// Every value type in this package has the same set of comparison methods: Compare,
+// Before, After, and Equal.  Compare returns -1, 0, or +1 in the manner of strings.Compare,
+// so it can be plugged directly into sort.Slice.  Equal is equivalent to Compare == 0.
+
+// cmpInt is a three-way comparison of two ints.
+func cmpInt(a, b int) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
+
+// Compare compares d and other, returning -1 if d is before other, 0 if they
+// are the same date, or +1 if d is after other.
+func (d Date) Compare(other Date) int {
+	if c := cmpInt(d.Year, other.Year); c != 0 {
+		return c
+	}
+	if c := cmpInt(int(d.Month), int(other.Month)); c != 0 {
+		return c
+	}
+	return cmpInt(d.Day, other.Day)
+}
+
+// Before reports whether d is before other.
+func (d Date) Before(other Date) bool { return d.Compare(other) < 0 }
+
+// After reports whether d is after other.
+func (d Date) After(other Date) bool { return d.Compare(other) > 0 }
+
+// Equal reports whether d and other are the same date.
+func (d Date) Equal(other Date) bool { return d.Compare(other) == 0 }
+
+// Compare compares ym and other, returning -1 if ym is before other, 0 if they
+// are the same month, or +1 if ym is after other.
+func (ym YearMonth) Compare(other YearMonth) int {
+	if c := cmpInt(ym.Year, other.Year); c != 0 {
+		return c
+	}
+	return cmpInt(int(ym.Month), int(other.Month))
+}
+
+// Before reports whether ym is before other.
+func (ym YearMonth) Before(other YearMonth) bool { return ym.Compare(other) < 0 }
+
+// After reports whether ym is after other.
+func (ym YearMonth) After(other YearMonth) bool { return ym.Compare(other) > 0 }
+
+// Equal reports whether ym and other are the same month.
+func (ym YearMonth) Equal(other YearMonth) bool { return ym.Compare(other) == 0 }
+
+// Compare compares t and other, returning -1 if t is before other, 0 if they
+// are the same time of day, or +1 if t is after other.
+func (t TimeOfDay) Compare(other TimeOfDay) int {
+	if c := cmpInt(t.Hour, other.Hour); c != 0 {
+		return c
+	}
+	if c := cmpInt(t.Minute, other.Minute); c != 0 {
+		return c
+	}
+	if c := cmpInt(t.Second, other.Second); c != 0 {
+		return c
+	}
+	return cmpInt(t.Nanosecond, other.Nanosecond)
+}
+
+// Before reports whether t is before other.
+func (t TimeOfDay) Before(other TimeOfDay) bool { return t.Compare(other) < 0 }
+
+// After reports whether t is after other.
+func (t TimeOfDay) After(other TimeOfDay) bool { return t.Compare(other) > 0 }
+
+// Equal reports whether t and other are the same time of day.
+func (t TimeOfDay) Equal(other TimeOfDay) bool { return t.Compare(other) == 0 }
+
+// Compare compares dt and other, returning -1 if dt is before other, 0 if they
+// are the same, or +1 if dt is after other.
+//
+// Note that 24:00 on one day and 00:00 on the next denote the same moment, but they
+// are different DateTime values and do not compare as equal.
+func (dt DateTime) Compare(other DateTime) int {
+	if c := dt.Date.Compare(other.Date); c != 0 {
+		return c
+	}
+	return dt.Time.Compare(other.Time)
+}
+
+// Before reports whether dt is before other.
+func (dt DateTime) Before(other DateTime) bool { return dt.Compare(other) < 0 }
+
+// After reports whether dt is after other.
+func (dt DateTime) After(other DateTime) bool { return dt.Compare(other) > 0 }
+
+// Equal reports whether dt and other are the same datetime.
+func (dt DateTime) Equal(other DateTime) bool { return dt.Compare(other) == 0 }
@@ -0,0 +1,355 @@
+package isoparse
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type dateShift struct {
+	d Date
+	n int
+}
+
+var addDaysCases = map[dateShift]Date{
+	{Date{2018, time.September, 27}, 0}:    {2018, time.September, 27},
+	{Date{2018, time.September, 27}, 4}:    {2018, time.October, 1},
+	{Date{2018, time.December, 31}, 1}:     {2019, time.January, 1},
+	{Date{2016, time.February, 28}, 1}:     {2016, time.February, 29},
+	{Date{2017, time.February, 28}, 1}:     {2017, time.March, 1},
+	{Date{2018, time.January, 1}, -1}:      {2017, time.December, 31},
+	{Date{2000, time.March, 1}, -1}:        {2000, time.February, 29},
+	{Date{1900, time.March, 1}, -1}:        {1900, time.February, 28},
+	{Date{2018, time.January, 1}, 365 * 4}: {2021, time.December, 31},
+}
+
+// The clamping cases are the interesting ones here.
+var addMonthsCases = map[dateShift]Date{
+	{Date{2018, time.January, 15}, 1}:   {2018, time.February, 15},
+	{Date{2018, time.January, 31}, 1}:   {2018, time.February, 28},
+	{Date{2016, time.January, 31}, 1}:   {2016, time.February, 29},
+	{Date{2018, time.March, 31}, 1}:     {2018, time.April, 30},
+	{Date{2018, time.March, 31}, -1}:    {2018, time.February, 28},
+	{Date{2018, time.December, 31}, 1}:  {2019, time.January, 31},
+	{Date{2018, time.January, 31}, -1}:  {2017, time.December, 31},
+	{Date{2018, time.January, 31}, -13}: {2016, time.December, 31},
+	{Date{2018, time.August, 31}, 18}:   {2020, time.February, 29},
+	{Date{2018, time.May, 31}, 0}:       {2018, time.May, 31},
+}
+
+var addYearsCases = map[dateShift]Date{
+	{Date{2016, time.February, 29}, 1}:   {2017, time.February, 28},
+	{Date{2016, time.February, 29}, 4}:   {2020, time.February, 29},
+	{Date{2016, time.February, 29}, -1}:  {2015, time.February, 28},
+	{Date{2000, time.February, 29}, 100}: {2100, time.February, 28},
+	{Date{2018, time.June, 30}, 2}:       {2020, time.June, 30},
+}
+
+type yearMonthShift struct {
+	ym YearMonth
+	n  int
+}
+
+var yearMonthAddCases = map[yearMonthShift]YearMonth{
+	{YearMonth{2018, time.January}, 1}:   {2018, time.February},
+	{YearMonth{2018, time.December}, 1}:  {2019, time.January},
+	{YearMonth{2018, time.January}, -1}:  {2017, time.December},
+	{YearMonth{2018, time.January}, -12}: {2017, time.January},
+	{YearMonth{2018, time.January}, -25}: {2015, time.December},
+	{YearMonth{2018, time.June}, 30}:     {2020, time.December},
+}
+
+var validCivilDates = []Date{
+	{2018, time.September, 27},
+	{2016, time.February, 29},
+	{1, time.January, 1},
+	{9999, time.December, 31},
+}
+
+var invalidCivilDates = []Date{
+	{2017, time.February, 29},
+	{2018, time.April, 31},
+	{2018, time.Month(0), 1},
+	{2018, time.Month(13), 1},
+	{2018, time.January, 0},
+	{},
+}
+
+func TestDateAddDays(t *testing.T) {
+	for c, want := range addDaysCases {
+		if got := c.d.AddDays(c.n); got != want {
+			t.Errorf(`%v.AddDays(%d) -> %v (should be %v)`, c.d, c.n, got, want)
+		}
+	}
+}
+
+func TestDateYearDay(t *testing.T) {
+	for _, year := range []int{1, 1900, 2000, 2018, 2020, 9999} {
+		want := 1
+		for d := (Date{year, time.January, 1}); d.Year == year; d = d.AddDays(1) {
+			if got := d.YearDay(); got != want {
+				t.Errorf(`%v.YearDay() -> %d (should be %d)`, d, got, want)
+			}
+			if got, err := DateFromYearDay(year, want); err != nil || got != d {
+				t.Errorf(`DateFromYearDay(%d, %d) -> (%v, %v) (should be %v)`, year, want, got, err, d)
+			}
+			want++
+		}
+	}
+	for _, c := range [][2]int{{2018, 0}, {2018, 366}, {2020, 367}, {2020, -1}} {
+		if got, err := DateFromYearDay(c[0], c[1]); !errors.Is(err, ErrInvalidDay) {
+			t.Errorf(`DateFromYearDay(%d, %d) -> (%v, %v) (should wrap ErrInvalidDay)`, c[0], c[1], got, err)
+		}
+	}
+}
+
+func TestDateAddMonths(t *testing.T) {
+	for c, want := range addMonthsCases {
+		if got := c.d.AddMonths(c.n); got != want {
+			t.Errorf(`%v.AddMonths(%d) -> %v (should be %v)`, c.d, c.n, got, want)
+		}
+	}
+}
+
+func TestDateAddYears(t *testing.T) {
+	for c, want := range addYearsCases {
+		if got := c.d.AddYears(c.n); got != want {
+			t.Errorf(`%v.AddYears(%d) -> %v (should be %v)`, c.d, c.n, got, want)
+		}
+	}
+}
+
+func TestYearMonthAddMonths(t *testing.T) {
+	for c, want := range yearMonthAddCases {
+		if got := c.ym.AddMonths(c.n); got != want {
+			t.Errorf(`%v.AddMonths(%d) -> %v (should be %v)`, c.ym, c.n, got, want)
+		}
+		if got := c.ym.AddYears(c.n); got != (YearMonth{c.ym.Year + c.n, c.ym.Month}) {
+			t.Errorf(`%v.AddYears(%d) -> %v (should keep month %v)`, c.ym, c.n, got, c.ym.Month)
+		}
+	}
+}
+
+var yearMonthDaysCases = map[YearMonth]int{
+	{2018, time.January}:  31,
+	{2018, time.February}: 28,
+	{2020, time.February}: 29,
+	{2018, time.April}:    30,
+	{2018, 0}:             0,
+	{2018, 13}:            0,
+	{2018, -1}:            0,
+}
+
+func TestYearMonthDays(t *testing.T) {
+	for ym, want := range yearMonthDaysCases {
+		if got := ym.Days(); got != want {
+			t.Errorf(`%v.Days() -> %d (should be %d)`, ym, got, want)
+		}
+		wantLast := Date{ym.Year, ym.Month, want}
+		if want == 0 {
+			wantLast = Date{}
+		}
+		if got := ym.LastDay(); got != wantLast {
+			t.Errorf(`%v.LastDay() -> %v (should be %v)`, ym, got, wantLast)
+		}
+	}
+}
+
+func TestDateIsValid(t *testing.T) {
+	for _, d := range validCivilDates {
+		if !d.IsValid() {
+			t.Errorf(`%v.IsValid() returned false for valid date`, d)
+		}
+	}
+	for _, d := range invalidCivilDates {
+		if d.IsValid() {
+			t.Errorf(`%v.IsValid() returned true for invalid date`, d)
+		}
+	}
+}
+
+// Each pair is ordered: the first element is strictly before the second.
+var orderedDates = [][2]Date{
+	{{2018, time.September, 27}, {2018, time.September, 28}},
+	{{2018, time.September, 30}, {2018, time.October, 1}},
+	{{2017, time.December, 31}, {2018, time.January, 1}},
+	{{1, time.January, 1}, {9999, time.December, 31}},
+}
+
+var orderedYearMonths = [][2]YearMonth{
+	{{2018, time.September}, {2018, time.October}},
+	{{2017, time.December}, {2018, time.January}},
+}
+
+var orderedTimesOfDay = [][2]TimeOfDay{
+	{{0, 0, 0, 0}, {0, 0, 0, 1}},
+	{{12, 30, 59, 999999999}, {12, 31, 0, 0}},
+	{{9, 59, 59, 0}, {10, 0, 0, 0}},
+	{{23, 59, 59, 999999999}, {24, 0, 0, 0}},
+}
+
+var orderedDateTimes = [][2]DateTime{
+	{{Date{2018, time.September, 27}, TimeOfDay{23, 0, 0, 0}}, {Date{2018, time.September, 28}, TimeOfDay{1, 0, 0, 0}}},
+	{{Date{2018, time.September, 27}, TimeOfDay{1, 0, 0, 0}}, {Date{2018, time.September, 27}, TimeOfDay{1, 0, 0, 1}}},
+	// 24:00 and the following 00:00 are distinct values.
+	{{Date{2018, time.September, 27}, TimeOfDay{24, 0, 0, 0}}, {Date{2018, time.September, 28}, TimeOfDay{0, 0, 0, 0}}},
+}
+
+var timeOfDayStrings = map[TimeOfDay]string{
+	{0, 0, 0, 0}:            "00:00:00",
+	{9, 5, 3, 0}:            "09:05:03",
+	{24, 0, 0, 0}:           "24:00:00",
+	{13, 47, 30, 500000000}: "13:47:30.5",
+	{13, 47, 30, 123456789}: "13:47:30.123456789",
+	{13, 47, 30, 1000}:      "13:47:30.000001",
+}
+
+func TestCivilCompare(t *testing.T) {
+	for _, pair := range orderedDates {
+		a, b := pair[0], pair[1]
+		if a.Compare(b) != -1 || b.Compare(a) != 1 || !a.Before(b) || !b.After(a) || a.Equal(b) || !a.Equal(a) {
+			t.Errorf(`Date comparison of %v and %v is inconsistent`, a, b)
+		}
+	}
+	for _, pair := range orderedYearMonths {
+		a, b := pair[0], pair[1]
+		if a.Compare(b) != -1 || b.Compare(a) != 1 || !a.Before(b) || !b.After(a) || a.Equal(b) || !a.Equal(a) {
+			t.Errorf(`YearMonth comparison of %v and %v is inconsistent`, a, b)
+		}
+	}
+	for _, pair := range orderedTimesOfDay {
+		a, b := pair[0], pair[1]
+		if a.Compare(b) != -1 || b.Compare(a) != 1 || !a.Before(b) || !b.After(a) || a.Equal(b) || !a.Equal(a) {
+			t.Errorf(`TimeOfDay comparison of %v and %v is inconsistent`, a, b)
+		}
+	}
+	for _, pair := range orderedDateTimes {
+		a, b := pair[0], pair[1]
+		if a.Compare(b) != -1 || b.Compare(a) != 1 || !a.Before(b) || !b.After(a) || a.Equal(b) || !a.Equal(a) {
+			t.Errorf(`DateTime comparison of %v and %v is inconsistent`, a, b)
+		}
+	}
+}
+
+func TestTimeOfDayString(t *testing.T) {
+	for tod, want := range timeOfDayStrings {
+		if got := tod.String(); got != want {
+			t.Errorf(`%#v.String() -> %q (should be %q)`, tod, got, want)
+		}
+	}
+}
+
+var civilConversionLocs = []*time.Location{
+	time.UTC,
+	offsetZone(-5 * 60 * 60),
+	offsetZone(13 * 60 * 60),
+}
+
+func TestDateOf(t *testing.T) {
+	for _, loc := range civilConversionLocs {
+		tm := time.Date(2018, time.September, 27, 23, 59, 59, 999999999, loc)
+		if d := DateOf(tm); d != (Date{2018, time.September, 27}) {
+			t.Errorf(`DateOf(%v) -> %v (should be 2018-09-27)`, tm, d)
+		}
+		if tod := TimeOfDayOf(tm); tod != (TimeOfDay{23, 59, 59, 999999999}) {
+			t.Errorf(`TimeOfDayOf(%v) -> %v (should be 23:59:59.999999999)`, tm, tod)
+		}
+		// The location given to In is the one the result is in.
+		if got := DateOf(tm).In(loc); !got.Equal(time.Date(2018, time.September, 27, 0, 0, 0, 0, loc)) || got.Location() != loc {
+			t.Errorf(`DateOf(%v).In(%v) -> %v (should be midnight in %v)`, tm, loc, got, loc)
+		}
+		if got := DateTimeOf(tm).In(loc); !got.Equal(tm) {
+			t.Errorf(`DateTimeOf(%v).In(%v) -> %v (should round trip)`, tm, loc, got)
+		}
+	}
+}
+
+func TestDateTimeInHour24(t *testing.T) {
+	dt := DateTime{Date{2018, time.December, 31}, TimeOfDay{24, 0, 0, 0}}
+	want := time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if got := dt.In(time.UTC); !got.Equal(want) {
+		t.Errorf(`%v.In(time.UTC) -> %v (should be %v)`, dt, got, want)
+	}
+}
+
+func TestTimeOfDayOn(t *testing.T) {
+	loc := loadLocation(t, "America/New_York")
+	for _, c := range newYorkWallCases() {
+		want := c.earliest
+		if c.gap {
+			want = c.latest
+		}
+		if got := c.dt.Time.On(c.dt.Date, loc); !got.Equal(want) {
+			t.Errorf(`%v.On(%v, %v) -> %v (should be %v)`, c.dt.Time, c.dt.Date, loc, got, want)
+		}
+	}
+	// 24:00 is midnight at the end of the given date.
+	tod, d := TimeOfDay{24, 0, 0, 0}, Date{2018, time.March, 10}
+	want := time.Date(2018, time.March, 11, 0, 0, 0, 0, loc)
+	if got := tod.On(d, loc); !got.Equal(want) {
+		t.Errorf(`%v.On(%v, %v) -> %v (should be %v)`, tod, d, loc, got, want)
+	}
+}
+
+// CombineDateAndTime must agree with parsing the date and time written together.
+func TestCombineDateAndTime(t *testing.T) {
+	loc := loadLocation(t, "America/New_York")
+	p := NewParser(WithLocation(loc))
+	for _, c := range []struct{ date, time string }{
+		{"2018-09-27", "05:00:00"},
+		{"2018-09-27", "05:00:00.5+05:30"},
+		{"2018-09-27", "2359Z"},
+		{"2018-12-31", "24:00"},
+		{"2018-12-31", "24:00-08:00"},
+		{"2018-03-11", "02:30"},
+		{"2018-11-04", "01:30"},
+		{"2018-W39-4", "12"},
+	} {
+		d, err1 := p.ParseDate(c.date)
+		tp, err2 := p.ParseTime(c.time)
+		want, err3 := p.Parse(c.date + "T" + c.time)
+		if err1 != nil || err2 != nil || err3 != nil {
+			t.Fatalf(`parsing %q and %q -> %v, %v, %v`, c.date, c.time, err1, err2, err3)
+		}
+		got, err := CombineDateAndTime(d.In(loc), tp)
+		if err != nil || !got.Equal(want) || got.Location() != want.Location() {
+			t.Errorf(`CombineDateAndTime(%q, %q) -> (%v, %v) (should be %v)`, c.date, c.time, got, err, want)
+		}
+	}
+
+	// The offset written with the time wins over the location of the date.
+	date := time.Date(2018, 9, 27, 23, 0, 0, 0, loc)
+	got, err := CombineDateAndTime(date, TimeParts{TimeOfDay{5, 0, 0, 0}, time.UTC, true})
+	if want := time.Date(2018, 9, 27, 5, 0, 0, 0, time.UTC); err != nil || !got.Equal(want) {
+		t.Errorf(`CombineDateAndTime(%v, 05:00Z) -> (%v, %v) (should be %v)`, date, got, err, want)
+	}
+	if _, err := CombineDateAndTime(date, TimeParts{TimeOfDay{24, 30, 0, 0}, nil, false}); !errors.Is(err, ErrTimeRange) {
+		t.Errorf(`CombineDateAndTime(%v, 24:30) -> %v (should wrap %v)`, date, err, ErrTimeRange)
+	}
+}
+
+var sinceMidnight = map[TimeOfDay]time.Duration{
+	{0, 0, 0, 0}:            0,
+	{14, 30, 0, 0}:          14*time.Hour + 30*time.Minute,
+	{23, 59, 59, 999999999}: 24*time.Hour - 1,
+	{24, 0, 0, 0}:           24 * time.Hour,
+	{0, 0, 1, 500}:          time.Second + 500,
+}
+
+func TestSinceMidnight(t *testing.T) {
+	for tod, d := range sinceMidnight {
+		if got := tod.SinceMidnight(); got != d {
+			t.Errorf(`%v.SinceMidnight() -> %v (should be %v)`, tod, got, d)
+		}
+		if got, err := TimeOfDayFromDuration(d); err != nil {
+			t.Errorf(`TimeOfDayFromDuration(%v) -> non-nil error (%v) for valid duration`, d, err)
+		} else if got != tod {
+			t.Errorf(`TimeOfDayFromDuration(%v) -> %v (should be %v)`, d, got, tod)
+		}
+	}
+	for _, d := range []time.Duration{-1, 24*time.Hour + 1, 48 * time.Hour} {
+		if got, err := TimeOfDayFromDuration(d); err == nil {
+			t.Errorf(`TimeOfDayFromDuration(%v) -> %v returned nil error (out-of-range duration should error)`, d, got)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"strings"
+	"time"
+)
+
+// Compare compares the instants named by two ISO-8601 datetimes, as accepted by
+// ParseISODatetime, and returns -1 if a is before b, +1 if a is after b, and 0 if they
+// are the same instant.  Strings in different forms and with different offsets compare
+// correctly: "2018-09-27T12:00:00+02:00" and "20180927T100000Z" are equal.
+//
+// For strings with a UTC offset, which is the usual case for data worth comparing,
+// Compare works from the parsed components and doesn't build a time.Time.  A string
+// without one is read in time.Local, as ParseISODatetime would.
+//
+// If either string is invalid, Compare returns the error from parsing it.
+func Compare(a, b string) (int, error) {
+	x, err := parseInstant(a)
+	if err != nil {
+		return 0, err
+	}
+	y, err := parseInstant(b)
+	if err != nil {
+		return 0, err
+	}
+	return x.compare(y), nil
+}
+
+// instant is a point on the time line, in seconds and nanoseconds since the Unix epoch.
+type instant struct {
+	sec  int64
+	nsec int
+}
+
+func (x instant) compare(y instant) int {
+	switch {
+	case x.sec < y.sec, x.sec == y.sec && x.nsec < y.nsec:
+		return -1
+	case x.sec > y.sec, x.sec == y.sec && x.nsec > y.nsec:
+		return +1
+	}
+	return 0
+}
+
+// unixOrdinal is the ymdToOrd ordinal of 1970-01-01.
+var unixOrdinal = ymdToOrd(1970, time.January, 1)
+
+// parseInstant parses s as ParseISODatetime would and returns its instant.
+func parseInstant(s string) (instant, error) {
+	if parts, ok := offsetParts(s); ok {
+		_, offset := time.Time{}.In(parts.tz).Zone()
+		days := ymdToOrd(parts.date[0], time.Month(parts.date[1]), parts.date[2]) - unixOrdinal
+		sec := int64(days)*24*60*60 + int64(parts.time[0]*60*60+parts.time[1]*60+parts.time[2]-offset)
+		return instant{sec, parts.time[3]}, nil
+	}
+	t, err := ParseISODatetime(s)
+	if err != nil {
+		return instant{}, err
+	}
+	return instant{t.Unix(), t.Nanosecond()}, nil
+}
+
+// offsetParts returns the parts of s if it is a valid datetime with a UTC offset and no
+// annotations.  Anything else is left to the full parser, which has the last word on
+// whether s is valid.
+func offsetParts(s string) (parts datetimeParts, ok bool) {
+	if strings.IndexByte(s, '[') >= 0 {
+		return parts, false
+	}
+	if parts, ok = scanRFC3339(s); !ok {
+		var err error
+		if parts, err = parseISODatetime(s); err != nil {
+			return parts, false
+		}
+	}
+	if !parts.hasOffset {
+		return parts, false
+	}
+	if checkRanges(parts.date[0], time.Month(parts.date[1]), parts.date[2], parts.time[0], parts.time[1], parts.time[2], parts.time[3], parts.tz) != nil {
+		return parts, false
+	}
+	return parts, true
+}
+
+// WithinTolerance reports whether a and b are the same instant to within tolerance, once
+// each is truncated to precision, as by Precision.Truncate.  Pass NanosecondPrecision to
+// compare the times as they are, or a tolerance of zero to require them to be equal at
+// precision:
+//
+//	isoparse.WithinTolerance(a, b, 0, isoparse.MillisecondPrecision)  // same millisecond
+//	isoparse.WithinTolerance(a, b, time.Second, isoparse.NanosecondPrecision)
+//
+// As with Precision.Truncate, each time is truncated on its own wall clock, so for
+// precisions coarser than a minute, the times should be in the same location.
+func WithinTolerance(a, b time.Time, tolerance time.Duration, precision Precision) bool {
+	if precision < NanosecondPrecision {
+		a, b = precision.Truncate(a), precision.Truncate(b)
+	}
+	d := a.Sub(b)
+	if d < 0 {
+		d = -d
+	}
+	return d <= tolerance
+}
+
+// StringsWithinTolerance parses a and b as ParseISODatetime does, and reports whether
+// they are within tolerance of each other at the precision of the less precise of the two,
+// as given by PrecisionOf.  This suits reconciliation jobs comparing feeds from systems
+// that write different numbers of fraction digits: "2018-09-27T05:00:00.1Z" and
+// "2018-09-27T05:00:00.123Z" are equal, since the first says nothing about milliseconds.
+// Both times are truncated in UTC, so strings written with different offsets are
+// compared as instants: "2018-09-27T05:30+05:30" and "2018-09-27T00Z" are equal.
+//
+// If either string is invalid, StringsWithinTolerance returns the error from parsing it.
+func StringsWithinTolerance(a, b string, tolerance time.Duration) (bool, error) {
+	x, err := ParseISODatetime(a)
+	if err != nil {
+		return false, err
+	}
+	y, err := ParseISODatetime(b)
+	if err != nil {
+		return false, err
+	}
+	precision := annotatedPrecision(a)
+	if pb := annotatedPrecision(b); pb < precision {
+		precision = pb
+	}
+	return WithinTolerance(x.UTC(), y.UTC(), tolerance, precision), nil
+}
+
+// annotatedPrecision returns the precision of datetime, a valid datetime that may have
+// RFC 9557 annotations.
+func annotatedPrecision(datetime string) Precision {
+	rest, _, _ := splitIXDTF(datetime)
+	precision, _ := PrecisionOf(rest)
+	return precision
+}
@@ -0,0 +1,148 @@
+package isoparse
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var comparisons = []struct {
+	a, b string
+	want int
+}{
+	{"2018-09-27T12:00:00+02:00", "20180927T100000Z", 0},
+	{"2018-09-27T12:00:00+02:00", "2018-09-27T11:00:00+00:00", -1},
+	{"2018-09-27T05:00:00-08:00", "2018-09-27T12:00:00Z", +1},
+	{"2018-09-27T05:00:00.5Z", "2018-09-27T05:00:00.25Z", +1},
+	{"2018-09-27T05:00:00.100Z", "2018-09-27T05:00:00,1Z", 0},
+	{"2018-W39-4T05Z", "2018-270T05:00Z", 0},
+	{"2018-09-27T24:00Z", "2018-09-28T00:00Z", 0},
+	{"2018-12-31T23:30:00-01:00", "2019-01-01T00:15:00Z", +1},
+	{"0001-01-01T00:00:00Z", "9999-12-31T23:59:59Z", -1},
+	{"2018-09-27T05:00:00+05:30[Asia/Kolkata]", "2018-09-26T23:30:00Z", 0},
+}
+
+func TestCompare(t *testing.T) {
+	for _, c := range comparisons {
+		if got, err := Compare(c.a, c.b); err != nil || got != c.want {
+			t.Errorf(`Compare(%q, %q) -> (%v, %v) (should be %v)`, c.a, c.b, got, err, c.want)
+		}
+		if got, err := Compare(c.b, c.a); err != nil || got != -c.want {
+			t.Errorf(`Compare(%q, %q) -> (%v, %v) (should be %v)`, c.b, c.a, got, err, -c.want)
+		}
+	}
+}
+
+// Compare must agree with comparing the parsed times, naive strings included.
+func TestCompareMatchesParse(t *testing.T) {
+	inputs := []string{"2018-09-27T05:00:00", "2018-09-27T05:00:00Z", "2018-09-27T05:00:00+01:00"}
+	for s := range allFormats {
+		inputs = append(inputs, s)
+	}
+	for _, a := range inputs {
+		ta, _ := ParseISODatetime(a)
+		for _, b := range inputs[:20] {
+			tb, _ := ParseISODatetime(b)
+			if got, err := Compare(a, b); err != nil || got != ta.Compare(tb) {
+				t.Errorf(`Compare(%q, %q) -> (%v, %v) (should be %v)`, a, b, got, err, ta.Compare(tb))
+			}
+		}
+	}
+}
+
+func TestCompareErrors(t *testing.T) {
+	for _, c := range []struct {
+		a, b string
+		want error
+	}{
+		{"2018-02-30T00:00Z", "2018-09-27T05:00Z", ErrInvalidDay},
+		{"2018-09-27T05:00Z", "not a timestamp", ErrSyntax},
+		{"2018-09-27T05:00Z", "2018-09-27T05:00+25:00", ErrInvalidOffset},
+	} {
+		if _, err := Compare(c.a, c.b); !errors.Is(err, c.want) {
+			t.Errorf(`Compare(%q, %q) -> %v (should wrap %v)`, c.a, c.b, err, c.want)
+		}
+	}
+}
+
+func TestCompareAllocs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping allocation counts in short mode")
+	}
+	a, b := "2018-09-27T12:00:00+02:00", "20180927T100000.5-0800"
+	assertMaxAllocs(t, "Compare", 0, func() { Compare(a, b) })
+}
+
+func BenchmarkCompare(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Compare("2018-09-27T12:00:00+02:00", "2018-09-27T10:00:00.5Z")
+	}
+}
+
+// BenchmarkCompareParsed is the alternative to Compare, for reference.
+func BenchmarkCompareParsed(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		x, _ := ParseISODatetime("2018-09-27T12:00:00+02:00")
+		y, _ := ParseISODatetime("2018-09-27T10:00:00.5Z")
+		_ = x.Compare(y)
+	}
+}
+
+func TestWithinTolerance(t *testing.T) {
+	a := time.Date(2018, 9, 27, 5, 0, 0, 123456789, time.UTC)
+	for _, c := range []struct {
+		b         time.Time
+		tolerance time.Duration
+		precision Precision
+		want      bool
+	}{
+		{a, 0, NanosecondPrecision, true},
+		{a.Add(time.Nanosecond), 0, NanosecondPrecision, false},
+		{a.Add(time.Nanosecond), time.Nanosecond, NanosecondPrecision, true},
+		{a.Add(-time.Second), time.Second, NanosecondPrecision, true},
+		{a.Add(-time.Second - 1), time.Second, NanosecondPrecision, false},
+		{time.Date(2018, 9, 27, 5, 0, 0, 123999999, time.UTC), 0, MillisecondPrecision, true},
+		{time.Date(2018, 9, 27, 5, 0, 0, 124000000, time.UTC), 0, MillisecondPrecision, false},
+		{time.Date(2018, 9, 27, 5, 0, 0, 124000000, time.UTC), time.Millisecond, MillisecondPrecision, true},
+		{time.Date(2018, 9, 27, 5, 0, 0, 999999999, time.UTC), 0, SecondPrecision, true},
+		{time.Date(2018, 9, 27, 6, 30, 0, 0, time.FixedZone("", 90*60)), 0, SecondPrecision, true},
+	} {
+		if got := WithinTolerance(a, c.b, c.tolerance, c.precision); got != c.want {
+			t.Errorf(`WithinTolerance(%v, %v, %v, %v) -> %v (should be %v)`, a, c.b, c.tolerance, c.precision, got, c.want)
+		}
+		if got := WithinTolerance(c.b, a, c.tolerance, c.precision); got != c.want {
+			t.Errorf(`WithinTolerance(%v, %v, %v, %v) -> %v (should be %v)`, c.b, a, c.tolerance, c.precision, got, c.want)
+		}
+	}
+}
+
+func TestStringsWithinTolerance(t *testing.T) {
+	for _, c := range []struct {
+		a, b      string
+		tolerance time.Duration
+		want      bool
+	}{
+		{"2018-09-27T05:00:00.1Z", "2018-09-27T05:00:00.123Z", 0, true},
+		{"2018-09-27T05:00:00.1Z", "2018-09-27T05:00:00.223Z", 0, false},
+		{"2018-09-27T05:00:00.1Z", "2018-09-27T05:00:00.223Z", 100 * time.Millisecond, true},
+		{"2018-09-27T05:00:00Z", "2018-09-27T07:00:00.999+02:00", 0, true},
+		{"2018-09-27T05:00Z", "20180927T050059.5Z", 0, true},
+		{"2018-09-27T05:00Z", "2018-09-27T05:01:00Z", 0, false},
+		{"2018-09-27T05:00:00Z[Europe/Paris]", "2018-09-27T07:00:00.5+02:00", 0, true},
+		{"2018-09-27T05:30+05:30", "2018-09-27T00Z", 0, true},
+		{"2018-09-27T05:30+05:30", "2018-09-27T00:00:59Z", 0, true},
+		{"2018-09-27T05:30+05:30", "2018-09-27T01Z", 0, false},
+	} {
+		if got, err := StringsWithinTolerance(c.a, c.b, c.tolerance); err != nil || got != c.want {
+			t.Errorf(`StringsWithinTolerance(%q, %q, %v) -> (%v, %v) (should be %v)`, c.a, c.b, c.tolerance, got, err, c.want)
+		}
+		if got, err := StringsWithinTolerance(c.b, c.a, c.tolerance); err != nil || got != c.want {
+			t.Errorf(`StringsWithinTolerance(%q, %q, %v) -> (%v, %v) (should be %v)`, c.b, c.a, c.tolerance, got, err, c.want)
+		}
+	}
+	if _, err := StringsWithinTolerance("2018-09-27T05:00Z", "2018-02-30", 0); !errors.Is(err, ErrInvalidDay) {
+		t.Errorf(`StringsWithinTolerance("2018-09-27T05:00Z", "2018-02-30") -> %v (should wrap %v)`, err, ErrInvalidDay)
+	}
+}
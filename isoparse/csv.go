@@ -0,0 +1,58 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ParseCSVColumn reads the remaining records from r and parses the field at the 0-based
+// column index of each as with ParseISODatetime, for bulk loading a timestamp column
+// without decoding whole records.  Configure r first: set Comma to '\t' for TSV, and read
+// past any header row.  ParseCSVColumn sets r.ReuseRecord, since it keeps only the one
+// field.
+//
+// The result has an element for every record read.  As with ParseAll, every record is
+// parsed even if some fail, and the error is a *BatchError whose ItemError indexes are the
+// 0-based record numbers, counted from where r was, of the records that failed.  A record
+// with too few fields is a failure, but an empty field is taken as a missing value and
+// left as the zero time.  An error reading r, such as a *csv.ParseError for a bare quote,
+// stops the read and is returned, with the times parsed up to that point.
+func ParseCSVColumn(r *csv.Reader, column int) ([]time.Time, error) {
+	return defaultParser.ParseCSVColumn(r, column)
+}
+
+// ParseCSVColumn is like the package-level ParseCSVColumn, but parses with p.
+func (p *Parser) ParseCSVColumn(r *csv.Reader, column int) ([]time.Time, error) {
+	r.ReuseRecord = true
+	var times []time.Time
+	var batchErr BatchError
+	for i := 0; ; i++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return times, err
+		}
+		var t time.Time
+		switch {
+		case column >= len(record):
+			err = fmt.Errorf("isoparse: record has %d fields, no column %d", len(record), column)
+		case record[column] != "":
+			t, err = p.Parse(record[column])
+		}
+		if err != nil {
+			batchErr.Errors = append(batchErr.Errors, &ItemError{i, err})
+		}
+		times = append(times, t)
+	}
+	if batchErr.Errors != nil {
+		return times, &batchErr
+	}
+	return times, nil
+}
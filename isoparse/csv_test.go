@@ -0,0 +1,58 @@
+package isoparse
+
+import (
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCSVColumn(t *testing.T) {
+	const data = "id\tcreated\tnote\n" +
+		"1\t2018-09-27T05:00:00Z\tfirst\n" +
+		"2\t\"20180927T0600Z\"\t\"second, quoted\"\n" +
+		"3\t\tmissing\n" +
+		"4\t2018-02-30T00:00Z\tbad day\n" +
+		"5\n" +
+		"6\t2018-09-27T07:00:00+00:00\tlast\n"
+	r := csv.NewReader(strings.NewReader(data))
+	r.Comma = '\t'
+	r.FieldsPerRecord = -1
+	if _, err := r.Read(); err != nil {
+		t.Fatal(err)
+	}
+	times, err := ParseCSVColumn(r, 1)
+	want := []time.Time{
+		time.Date(2018, 9, 27, 5, 0, 0, 0, time.UTC),
+		time.Date(2018, 9, 27, 6, 0, 0, 0, time.UTC),
+		{},
+		{},
+		{},
+		time.Date(2018, 9, 27, 7, 0, 0, 0, time.UTC),
+	}
+	if len(times) != len(want) {
+		t.Fatalf(`ParseCSVColumn -> %v (should be %v)`, times, want)
+	}
+	for i := range want {
+		if !times[i].Equal(want[i]) {
+			t.Errorf(`ParseCSVColumn record %d -> %v (should be %v)`, i, times[i], want[i])
+		}
+	}
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 2 || batchErr.Errors[0].Index != 3 || batchErr.Errors[1].Index != 4 {
+		t.Fatalf(`ParseCSVColumn -> %v (should be a *BatchError for records 3 and 4)`, err)
+	}
+	if !errors.Is(batchErr.Errors[0], ErrInvalidDay) {
+		t.Errorf(`ParseCSVColumn record 3 -> %v (should wrap %v)`, batchErr.Errors[0], ErrInvalidDay)
+	}
+}
+
+func TestParseCSVColumnReadError(t *testing.T) {
+	r := csv.NewReader(strings.NewReader("2018-09-27T05:00Z\n\"2018-09-27T06:00Z\n"))
+	times, err := ParseCSVColumn(r, 0)
+	var parseErr *csv.ParseError
+	if !errors.As(err, &parseErr) || len(times) != 1 {
+		t.Errorf(`ParseCSVColumn -> (%v, %v) (should be one time and a *csv.ParseError)`, times, err)
+	}
+}
@@ -0,0 +1,217 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	durationType  = reflect.TypeOf(time.Duration(0))
+	dateType      = reflect.TypeOf(Date{})
+	yearMonthType = reflect.TypeOf(YearMonth{})
+	timeOfDayType = reflect.TypeOf(TimeOfDay{})
+	dateTimeType  = reflect.TypeOf(DateTime{})
+	periodType    = reflect.TypeOf(Period{})
+	intervalType  = reflect.TypeOf(Interval{})
+)
+
+// Decode populates the fields of the struct pointed to by v from the string values in
+// values, as directed by each field's "iso" struct tag.  Fields without the tag are left
+// alone, as are tagged fields whose key is missing from values or whose value is empty.
+//
+// The tag is a comma-separated list.  The first element names the kind of value to parse,
+// and may be left empty to infer it from the field's type:
+//
+//	datetime  time.Time (as ParseISODatetime) or DateTime (as Parser.ParseDateTime)
+//	date      time.Time at midnight (as ParseISODate) or Date
+//	month     YearMonth, in the "YYYY-MM" form
+//	time      TimeOfDay
+//	duration  Period, or time.Duration if it has no years or months
+//	interval  Interval
+//
+// The remaining elements are options:
+//
+//	key=name    look up name in values rather than the field's name
+//	assume-utc  read a time.Time without a UTC offset as UTC, rather than time.Local
+//	required    make a missing or empty value an error
+//
+// Pointers to any of these types are allocated as needed.  For example:
+//
+//	var event struct {
+//		Start  time.Time      `iso:"datetime,key=start,assume-utc"`
+//		Length *time.Duration `iso:"duration,key=length"`
+//		Day    Date           `iso:",key=day,required"`
+//	}
+//	err := isoparse.Decode(map[string]string{"start": "2018-09-27T12:30", "day": "2018-W39-4"}, &event)
+//
+// Decode stops at the first error, which names the key and the field.
+func Decode(values map[string]string, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("isoparse: Decode requires a non-nil pointer to a struct, not %T", v)
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		tag, ok := field.Tag.Lookup("iso")
+		if !ok {
+			continue
+		}
+		if field.PkgPath != "" {
+			return fmt.Errorf("isoparse: iso tag on unexported field %s", field.Name)
+		}
+		opts, err := parseISOTag(field, tag)
+		if err != nil {
+			return err
+		}
+		s := values[opts.key]
+		if s == "" {
+			if opts.required {
+				return fmt.Errorf("isoparse: missing required key %q (field %s)", opts.key, field.Name)
+			}
+			continue
+		}
+		if err := decodeField(rv.Field(i), opts, s); err != nil {
+			return fmt.Errorf("isoparse: key %q (field %s): %w", opts.key, field.Name, err)
+		}
+	}
+	return nil
+}
+
+// isoTag holds a parsed "iso" struct tag.
+type isoTag struct {
+	kind      string
+	key       string
+	assumeUTC bool
+	required  bool
+}
+
+// defaultKinds maps each supported field type to the kind it is decoded as by default.
+var defaultKinds = map[reflect.Type]string{
+	timeType:      "datetime",
+	durationType:  "duration",
+	dateType:      "date",
+	yearMonthType: "month",
+	timeOfDayType: "time",
+	dateTimeType:  "datetime",
+	periodType:    "duration",
+	intervalType:  "interval",
+}
+
+// kindTypes lists the field types each kind may be decoded into.
+var kindTypes = map[string][]reflect.Type{
+	"datetime": {timeType, dateTimeType},
+	"date":     {timeType, dateType},
+	"month":    {yearMonthType},
+	"time":     {timeOfDayType},
+	"duration": {periodType, durationType},
+	"interval": {intervalType},
+}
+
+func parseISOTag(field reflect.StructField, tag string) (opts isoTag, err error) {
+	elems := strings.Split(tag, ",")
+	opts.kind, opts.key = elems[0], field.Name
+	for _, elem := range elems[1:] {
+		switch {
+		case elem == "assume-utc":
+			opts.assumeUTC = true
+		case elem == "required":
+			opts.required = true
+		case strings.HasPrefix(elem, "key=") && len(elem) > len("key="):
+			opts.key = elem[len("key="):]
+		default:
+			return opts, fmt.Errorf("isoparse: field %s: unknown iso tag option %q", field.Name, elem)
+		}
+	}
+	typ := field.Type
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if opts.kind == "" {
+		opts.kind = defaultKinds[typ]
+	}
+	for _, t := range kindTypes[opts.kind] {
+		if t == typ {
+			return opts, nil
+		}
+	}
+	if opts.kind == "" {
+		return opts, fmt.Errorf("isoparse: field %s: unsupported type %v", field.Name, field.Type)
+	}
+	return opts, fmt.Errorf("isoparse: field %s: cannot decode %q into %v", field.Name, opts.kind, field.Type)
+}
+
+// decodeField parses s as directed by opts and stores it in fv, allocating if fv is a pointer.
+func decodeField(fv reflect.Value, opts isoTag, s string) error {
+	if fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		fv = fv.Elem()
+	}
+	p := defaultParser
+	if opts.assumeUTC {
+		p = NewParser(WithLocation(time.UTC))
+	}
+
+	var parsed interface{}
+	var err error
+	switch fv.Type() {
+	case timeType:
+		if opts.kind == "date" {
+			var d Date
+			if d, err = p.ParseDate(s); err == nil {
+				parsed = p.startOfDay(d)
+			}
+		} else {
+			parsed, err = p.Parse(s)
+		}
+	case durationType:
+		var period Period
+		if period, err = p.ParseDuration(s); err == nil {
+			parsed, err = periodDuration(period)
+		}
+	case dateType:
+		parsed, err = p.ParseDate(s)
+	case dateTimeType:
+		parsed, err = p.ParseDateTime(s)
+	case periodType:
+		parsed, err = p.ParseDuration(s)
+	case intervalType:
+		parsed, err = p.ParseInterval(s)
+	case yearMonthType:
+		var ym YearMonth
+		err = ym.UnmarshalText([]byte(s))
+		parsed = ym
+	case timeOfDayType:
+		var t TimeOfDay
+		err = t.UnmarshalText([]byte(s))
+		parsed = t
+	}
+	if err != nil {
+		return err
+	}
+	fv.Set(reflect.ValueOf(parsed))
+	return nil
+}
+
+// periodDuration converts p to a time.Duration, taking days and weeks to be exactly 24
+// and 7*24 hours long, as ProtoDuration does.
+func periodDuration(p Period) (time.Duration, error) {
+	seconds, nanos, err := ProtoDuration(p)
+	if err != nil {
+		return 0, err
+	}
+	if seconds >= math.MaxInt64/int64(time.Second) || seconds <= math.MinInt64/int64(time.Second) {
+		return 0, fmt.Errorf("isoparse: %v out of range for time.Duration", p)
+	}
+	return time.Duration(seconds)*time.Second + time.Duration(nanos), nil
+}
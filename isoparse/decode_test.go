@@ -0,0 +1,118 @@
+package isoparse
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type decodeTarget struct {
+	Start    time.Time      `iso:"datetime,key=start,assume-utc"`
+	Local    time.Time      `iso:",key=local"`
+	Day      time.Time      `iso:"date,key=day,assume-utc"`
+	Date     Date           `iso:",key=date"`
+	Month    YearMonth      `iso:",key=month"`
+	At       TimeOfDay      `iso:",key=at"`
+	Civil    DateTime       `iso:",key=civil"`
+	Period   Period         `iso:",key=period"`
+	Timeout  *time.Duration `iso:"duration,key=timeout"`
+	Window   Interval       `iso:",key=window"`
+	Optional *Date          `iso:",key=optional"`
+	Untagged time.Time
+}
+
+func TestDecode(t *testing.T) {
+	values := map[string]string{
+		"start":    "2018-09-27T12:30",
+		"local":    "2018-09-27T12:30",
+		"day":      "2018-W39-4",
+		"date":     "2018-270",
+		"month":    "2018-09",
+		"at":       "12:30",
+		"civil":    "20180927T123000",
+		"period":   "P1Y2M",
+		"timeout":  "P1DT30S",
+		"window":   "2018-09-27T00:00Z/PT1H",
+		"Untagged": "2018-09-27",
+	}
+	var got decodeTarget
+	if err := Decode(values, &got); err != nil {
+		t.Fatalf(`Decode -> non-nil error (%v)`, err)
+	}
+	timeout := 24*time.Hour + 30*time.Second
+	checks := []struct {
+		name      string
+		got, want interface{}
+	}{
+		{"Start", got.Start, time.Date(2018, 9, 27, 12, 30, 0, 0, time.UTC)},
+		{"Local", got.Local, time.Date(2018, 9, 27, 12, 30, 0, 0, time.Local)},
+		{"Day", got.Day, time.Date(2018, 9, 27, 0, 0, 0, 0, time.UTC)},
+		{"Date", got.Date, Date{2018, time.September, 27}},
+		{"Month", got.Month, YearMonth{2018, time.September}},
+		{"At", got.At, TimeOfDay{12, 30, 0, 0}},
+		{"Civil", got.Civil, DateTime{Date{2018, time.September, 27}, TimeOfDay{12, 30, 0, 0}}},
+		{"Period", got.Period, Period{Years: 1, Months: 2}},
+		{"Timeout", *got.Timeout, timeout},
+		{"Window", got.Window.String(), "2018-09-27T00:00:00Z/2018-09-27T01:00:00Z"},
+		{"Optional", got.Optional, (*Date)(nil)},
+		{"Untagged", got.Untagged, time.Time{}},
+	}
+	for _, c := range checks {
+		if g, ok := c.got.(time.Time); ok {
+			if !g.Equal(c.want.(time.Time)) || g.Location() != c.want.(time.Time).Location() {
+				t.Errorf(`Decode field %s -> %v (should be %v)`, c.name, c.got, c.want)
+			}
+		} else if c.got != c.want {
+			t.Errorf(`Decode field %s -> %v (should be %v)`, c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	var tagged struct {
+		Start time.Time `iso:",key=start"`
+	}
+	err := Decode(map[string]string{"start": "2018-13-01"}, &tagged)
+	var parseErr *ParseError
+	if err == nil || !strings.Contains(err.Error(), `key "start" (field Start)`) || !errors.As(err, &parseErr) {
+		t.Errorf(`Decode with bad value -> %v (should name key and field and wrap a *ParseError)`, err)
+	}
+
+	var required struct {
+		Day Date `iso:",required"`
+	}
+	if err := Decode(nil, &required); err == nil {
+		t.Errorf(`Decode with missing required key returned nil error`)
+	}
+
+	var calendar struct {
+		D time.Duration `iso:""`
+	}
+	if err := Decode(map[string]string{"D": "P1M"}, &calendar); err == nil {
+		t.Errorf(`Decode of P1M into time.Duration returned nil error`)
+	}
+
+	targets := []interface{}{
+		nil,
+		tagged,
+		new(int),
+		&struct {
+			N int `iso:""`
+		}{},
+		&struct {
+			D Date `iso:"time"`
+		}{},
+		&struct {
+			D Date `iso:",omitempty"`
+		}{},
+		&struct {
+			d Date `iso:""`
+		}{},
+	}
+	for _, v := range targets {
+		if err := Decode(map[string]string{}, v); err == nil {
+			t.Errorf(`Decode into %T returned nil error`, v)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import "time"
+
+// WithDefault makes a Parser fill the components that a reduced-precision string leaves
+// out from t, as dateutil's default argument does, rather than from the first of the
+// month or year and the start of the day or hour.  With a default of 2000-01-15T09:30:00,
+// for example, "2018-06" is 2018-06-15T09:30:00, "2018" is 2018-01-15T09:30:00, and
+// "2018-09-27T05" is 2018-09-27T05:30:00.
+//
+// A day that doesn't exist in the month, as with a default on the 31st, is clamped to the
+// month's last day.  A week date without a day takes the weekday of t.  A fraction of a
+// second is never filled in, so a time with seconds is complete, and neither is anything
+// after an hour of 24.  Only t's wall clock is used: the result is still in the
+// string's offset or the Parser's location.  For dates alone, WithDefault overrides
+// WithDateAnchor, and WithCompleteDates still rejects dates that lack a month or a day.
+//
+// It applies to Parse, ParseDate, ParseDateTime, and the functions built on them.
+func WithDefault(t time.Time) Option {
+	return func(p *Parser) {
+		p.defaults = &t
+	}
+}
+
+// fillDefaults fills in the components of parts that s, the string parts was parsed
+// from, leaves out, if p has a default.
+func (p *Parser) fillDefaults(s string, parts *datetimeParts) {
+	if p.defaults == nil {
+		return
+	}
+	d := p.defaults
+	date := s
+	if parts.hasTime {
+		date = s[:parts.timePos-1]
+	}
+	switch format := dateFormat(date); format {
+	case YearDate:
+		parts.date[1] = int(d.Month())
+		fallthrough
+	case YearMonthDate:
+		parts.date[2] = d.Day()
+		if days := daysInMonth(parts.date[0], time.Month(parts.date[1])); parts.date[2] > days {
+			parts.date[2] = days
+		}
+	case WeekDateExtended, WeekDateBasic:
+		if len(date) == len("2018W39")+btoi(format == WeekDateExtended) {
+			monday := Date{parts.date[0], time.Month(parts.date[1]), parts.date[2]}
+			day := monday.AddDays(isoWeekday(*d) - 1)
+			parts.date = [3]int{day.Year, int(day.Month), day.Day}
+		}
+	}
+	precision := HourPrecision - 1
+	if parts.hasTime {
+		precision = timePrecision(s[parts.timePos:])
+	}
+	if precision >= SecondPrecision || parts.time[0] == maxHour {
+		return
+	}
+	clock := [...]int{d.Hour(), d.Minute(), d.Second()}
+	for i := int(precision-HourPrecision) + 1; i < len(clock); i++ {
+		parts.time[i] = clock[i]
+	}
+}
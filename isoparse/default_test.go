@@ -0,0 +1,49 @@
+package isoparse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithDefault(t *testing.T) {
+	p := NewParser(WithLocation(time.UTC), WithDefault(time.Date(2000, 1, 31, 9, 30, 45, 123, time.UTC)))
+	for s, want := range map[string]time.Time{
+		"2018":                 time.Date(2018, 1, 31, 9, 30, 45, 0, time.UTC),
+		"2018-06":              time.Date(2018, 6, 30, 9, 30, 45, 0, time.UTC),
+		"2016-02":              time.Date(2016, 2, 29, 9, 30, 45, 0, time.UTC),
+		"2018-09-27":           time.Date(2018, 9, 27, 9, 30, 45, 0, time.UTC),
+		"2018-W39":             time.Date(2018, 9, 24, 9, 30, 45, 0, time.UTC), // The default is a Monday.
+		"2018-270":             time.Date(2018, 9, 27, 9, 30, 45, 0, time.UTC),
+		"2018-09-27T05":        time.Date(2018, 9, 27, 5, 30, 45, 0, time.UTC),
+		"2018-09-27T05:10Z":    time.Date(2018, 9, 27, 5, 10, 45, 0, time.UTC),
+		"20180927T0510+01":     time.Date(2018, 9, 27, 4, 10, 45, 0, time.UTC),
+		"2018-09-27T05:10:00":  time.Date(2018, 9, 27, 5, 10, 0, 0, time.UTC),
+		"2018-09-27T24":        time.Date(2018, 9, 28, 0, 0, 0, 0, time.UTC),
+		"2018-09-27T05:00:00Z": time.Date(2018, 9, 27, 5, 0, 0, 0, time.UTC),
+	} {
+		if got, err := p.Parse(s); err != nil || !got.Equal(want) {
+			t.Errorf(`Parse(%q) with default -> (%v, %v) (should be %v)`, s, got, err, want)
+		}
+	}
+
+	// A Thursday, which also overrides the date anchor.
+	p = NewParser(WithDateAnchor(TimeOfDay{Hour: 12}), WithDefault(time.Date(2000, 1, 27, 6, 0, 0, 0, time.UTC)))
+	if got, err := p.ParseDate("2018W39"); err != nil || got != (Date{2018, time.September, 27}) {
+		t.Errorf(`ParseDate("2018W39") with default -> (%v, %v) (should be 2018-09-27)`, got, err)
+	}
+	if got, err := p.ParseDate("2018-09"); err != nil || got != (Date{2018, time.September, 27}) {
+		t.Errorf(`ParseDate("2018-09") with default -> (%v, %v) (should be 2018-09-27)`, got, err)
+	}
+	want := DateTime{Date{2018, time.September, 27}, TimeOfDay{Hour: 6}}
+	if got, err := p.ParseDateTime("2018-09"); err != nil || got != want {
+		t.Errorf(`ParseDateTime("2018-09") with default -> (%v, %v) (should be %v)`, got, err, want)
+	}
+	if got, err := p.ParseDateTime("2018-09-27T24"); err != nil || got.Time != (TimeOfDay{Hour: 24}) {
+		t.Errorf(`ParseDateTime("2018-09-27T24") with default -> (%v, %v) (should be 24:00)`, got, err)
+	}
+
+	p = NewParser(WithCompleteDates(), WithDefault(time.Date(2000, 1, 27, 6, 0, 0, 0, time.UTC)))
+	if _, err := p.Parse("2018-09"); err == nil {
+		t.Errorf(`Parse("2018-09") with complete dates and default returned nil error`)
+	}
+}
@@ -0,0 +1,36 @@
+package isoparse
+
+import (
+	"go/build"
+	"testing"
+)
+
+// The package is kept free of regexp, which is large enough to matter in TinyGo and
+// WebAssembly builds.  Strings are scanned by hand instead.
+var forbiddenDeps = []string{"regexp"}
+
+func TestForbiddenDeps(t *testing.T) {
+	pkg, err := build.ImportDir(".", 0)
+	if err != nil {
+		t.Skipf("cannot read package: %v", err)
+	}
+	seen := map[string]bool{}
+	var walk func(path string, imports []string)
+	walk = func(path string, imports []string) {
+		for _, imp := range imports {
+			for _, dep := range forbiddenDeps {
+				if imp == dep {
+					t.Errorf(`%s imports %s`, path, dep)
+				}
+			}
+			if seen[imp] || imp == "C" {
+				continue
+			}
+			seen[imp] = true
+			if p, err := build.Import(imp, "", 0); err == nil {
+				walk(imp, p.Imports)
+			}
+		}
+	}
+	walk("isoparse", pkg.Imports)
+}
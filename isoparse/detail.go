@@ -0,0 +1,83 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"strings"
+	"time"
+)
+
+// Detail is the result of ParseDetailed: a parsed datetime, along with what the string
+// said about it that a time.Time can't hold.
+type Detail struct {
+	Time time.Time
+
+	// Fraction holds the digits of the fraction of a second as written, without the
+	// decimal sign, or "" if there was none.  Time is truncated to nine digits, so where
+	// len(Fraction) > 9 the rest are only here.
+	Fraction string
+
+	// HasOffset reports whether the string had an explicit UTC offset ("Z" or ±hh[:mm]).
+	// When it is false, Time's location is a default, such as the one configured with
+	// WithLocation, or the zone named by an RFC 9557 annotation, and callers can apply
+	// their own policy to the naive reading.
+	HasOffset bool
+
+	// Offset is the UTC offset as written, in seconds east of UTC, if HasOffset.  "-00:00"
+	// gives 0, as does "Z".
+	Offset int
+}
+
+// Truncated reports whether d.Time lost digits of the fraction of a second: that is,
+// whether it was written with more than nanosecond precision.
+func (d Detail) Truncated() bool {
+	return len(d.Fraction) > 9
+}
+
+// ParseDetailed is like ParseISODatetime, but returns the Detail of the result.
+func ParseDetailed(datetime string) (Detail, error) {
+	return defaultParser.ParseDetailed(datetime)
+}
+
+// ParseDetailed is like the package-level ParseDetailed, but parses with p.
+func (p *Parser) ParseDetailed(datetime string) (Detail, error) {
+	t, err := p.Parse(datetime)
+	if err != nil {
+		return Detail{}, err
+	}
+	hasOffset, offset := writtenOffset(datetime)
+	return Detail{t, fractionDigits(datetime), hasOffset, offset}, nil
+}
+
+// writtenOffset returns whether datetime, a valid datetime, has a UTC offset, and what it
+// is.  Only a profile's own parser, as for XSD, accepts strings that parseISODatetime
+// doesn't, and those are XSD strings.
+func writtenOffset(datetime string) (has bool, offset int) {
+	rest, _, _ := splitIXDTF(datetime)
+	parts, err := parseISODatetime(rest)
+	if err != nil {
+		_, _, _, has, offset, _ = scanXSDDatetime(rest)
+		return has, offset
+	}
+	if !parts.hasOffset {
+		return false, 0
+	}
+	_, offset = time.Time{}.In(parts.tz).Zone()
+	return true, offset
+}
+
+// fractionDigits returns the digits of the fraction of a second in datetime, a valid
+// datetime.  Only the seconds may have a fraction, and the date has no '.' or ',', so the
+// first decimal sign is the one.
+func fractionDigits(datetime string) string {
+	sign := strings.IndexAny(datetime, ".,")
+	if sign < 0 {
+		return ""
+	}
+	end := sign + 1
+	for end < len(datetime) && isDigit(datetime[end]) {
+		end++
+	}
+	return datetime[sign+1 : end]
+}
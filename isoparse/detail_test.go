@@ -0,0 +1,58 @@
+package isoparse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDetailed(t *testing.T) {
+	p := NewParser(WithLocation(time.UTC))
+	for _, c := range []struct {
+		s         string
+		fraction  string
+		nsec      int
+		truncated bool
+	}{
+		{"2018-09-27T05:00:00Z", "", 0, false},
+		{"2018-09-27", "", 0, false},
+		{"2018-09-27T05:00:00.25Z", "25", 250000000, false},
+		{"2018-09-27T05:00:00,123456789+01:00", "123456789", 123456789, false},
+		{"20180927T050000.1234567891234Z", "1234567891234", 123456789, true},
+		{"2018-09-27T05:00:00.0000000009[UTC]", "0000000009", 0, true},
+	} {
+		d, err := p.ParseDetailed(c.s)
+		if err != nil || d.Fraction != c.fraction || d.Time.Nanosecond() != c.nsec || d.Truncated() != c.truncated {
+			t.Errorf(`ParseDetailed(%q) -> (%+v, %v) (should have fraction %q, %d ns, truncated %v)`, c.s, d, err, c.fraction, c.nsec, c.truncated)
+		}
+	}
+	if _, err := ParseDetailed("2018-09-27T05:00:00.Z"); err == nil {
+		t.Errorf(`ParseDetailed("2018-09-27T05:00:00.Z") -> nil error`)
+	}
+}
+
+func TestParseDetailedOffset(t *testing.T) {
+	cet := func(int, time.Time) *time.Location { return time.FixedZone("CET", 60*60) }
+	for _, c := range []struct {
+		p         *Parser
+		s         string
+		hasOffset bool
+		offset    int
+	}{
+		{defaultParser, "2018-09-27T05:00:00", false, 0},
+		{defaultParser, "2018-09-27", false, 0},
+		{defaultParser, "2018-09-27T05:00:00Z", true, 0},
+		{defaultParser, "2018-09-27T05:00:00+05:30", true, 5*60*60 + 30*60},
+		{defaultParser, "20180927T0500-0800", true, -8 * 60 * 60},
+		{defaultParser, "2018-09-27T05:00:00-00:00", true, 0},
+		{defaultParser, "2018-09-27T05:00:00[Europe/Paris]", false, 0},
+		{defaultParser, "2018-09-27T05:00:00+02:00[Europe/Paris]", true, 2 * 60 * 60},
+		{NewParser(WithOffsetResolver(cet)), "2018-09-27T05:00:00+01:00", true, 60 * 60},
+		{NewParser(WithProfile(XSD)), "-0001-01-01T00:00:00-05:00", true, -5 * 60 * 60},
+		{NewParser(WithProfile(XSD)), "-0001-01-01", false, 0},
+	} {
+		d, err := c.p.ParseDetailed(c.s)
+		if err != nil || d.HasOffset != c.hasOffset || d.Offset != c.offset {
+			t.Errorf(`ParseDetailed(%q) -> (%+v, %v) (should have HasOffset %v, Offset %d)`, c.s, d, err, c.hasOffset, c.offset)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format identifies one of the ISO-8601 representations that this package parses.
+//
+// The basic format has no separators ("20180927") and the extended format has them
+// ("2018-09-27").  A datetime is classed by its date portion; the time portion may be
+// written either way, with or without a fraction and an offset.
+type Format int
+
+const (
+	UnknownFormat            Format = iota
+	CalendarDateExtended            // 2018-09-27
+	CalendarDateBasic               // 20180927
+	YearMonthDate                   // 2018-09
+	YearDate                        // 2018
+	WeekDateExtended                // 2018-W39-4 or 2018-W39
+	WeekDateBasic                   // 2018W394 or 2018W39
+	OrdinalDateExtended             // 2018-270
+	OrdinalDateBasic                // 2018270
+	CalendarDateTimeExtended        // 2018-09-27T05:00:00
+	CalendarDateTimeBasic           // 20180927T050000
+	WeekDateTimeExtended            // 2018-W39-4T05:00:00
+	WeekDateTimeBasic               // 2018W394T050000
+	OrdinalDateTimeExtended         // 2018-270T05:00:00
+	OrdinalDateTimeBasic            // 2018270T050000
+	TimeOnly                        // 05:00:00, 0500, 05:00:00.123+05:30, and so on
+)
+
+var formatNames = [...]string{
+	UnknownFormat:            "UnknownFormat",
+	CalendarDateExtended:     "CalendarDateExtended",
+	CalendarDateBasic:        "CalendarDateBasic",
+	YearMonthDate:            "YearMonthDate",
+	YearDate:                 "YearDate",
+	WeekDateExtended:         "WeekDateExtended",
+	WeekDateBasic:            "WeekDateBasic",
+	OrdinalDateExtended:      "OrdinalDateExtended",
+	OrdinalDateBasic:         "OrdinalDateBasic",
+	CalendarDateTimeExtended: "CalendarDateTimeExtended",
+	CalendarDateTimeBasic:    "CalendarDateTimeBasic",
+	WeekDateTimeExtended:     "WeekDateTimeExtended",
+	WeekDateTimeBasic:        "WeekDateTimeBasic",
+	OrdinalDateTimeExtended:  "OrdinalDateTimeExtended",
+	OrdinalDateTimeBasic:     "OrdinalDateTimeBasic",
+	TimeOnly:                 "TimeOnly",
+}
+
+func (f Format) String() string {
+	if f >= 0 && int(f) < len(formatNames) {
+		return formatNames[f]
+	}
+	return "Format(" + strconv.Itoa(int(f)) + ")"
+}
+
+// ValueKind says whether a string holds a date, a time, or both, as reported by Classify,
+// or a duration or an interval, as reported by ParseAny.
+type ValueKind int
+
+const (
+	UnknownValue  ValueKind = iota
+	DateValue               // A date, including reduced-precision dates such as "2018-09"
+	TimeValue               // A time with no date
+	DateTimeValue           // A date and a time
+	DurationValue           // A duration, such as "P1DT2H"; only from ParseAny
+	IntervalValue           // An interval, such as "2018-09-27/P1D"; only from ParseAny
+)
+
+func (k ValueKind) String() string {
+	switch k {
+	case UnknownValue:
+		return "unknown"
+	case DateValue:
+		return "date"
+	case TimeValue:
+		return "time"
+	case DateTimeValue:
+		return "datetime"
+	case DurationValue:
+		return "duration"
+	case IntervalValue:
+		return "interval"
+	}
+	return "ValueKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Kind returns the kind of value that a string in format f holds.
+func (f Format) Kind() ValueKind {
+	switch {
+	case f == TimeOnly:
+		return TimeValue
+	case f >= CalendarDateExtended && f <= OrdinalDateBasic:
+		return DateValue
+	case f >= CalendarDateTimeExtended && f <= OrdinalDateTimeBasic:
+		return DateTimeValue
+	}
+	return UnknownValue
+}
+
+// Classify reports whether s is a date, a time, or a datetime, so that ingestion code can
+// route each value to a column of the right type.  It is shorthand for DetectFormat
+// followed by Format.Kind; see DetectFormat for the details.
+func Classify(s string) (ValueKind, error) {
+	format, err := DetectFormat(s)
+	return format.Kind(), err
+}
+
+// dateTimeFormats maps each date format to the format of a datetime with that date.
+// Reduced-precision dates can't have a time portion.
+var dateTimeFormats = map[Format]Format{
+	CalendarDateExtended: CalendarDateTimeExtended,
+	CalendarDateBasic:    CalendarDateTimeBasic,
+	WeekDateExtended:     WeekDateTimeExtended,
+	WeekDateBasic:        WeekDateTimeBasic,
+	OrdinalDateExtended:  OrdinalDateTimeExtended,
+	OrdinalDateBasic:     OrdinalDateTimeBasic,
+}
+
+// DetectFormat reports which representation s is written in, for tools that profile data
+// rather than parse it.  s must be a valid date, datetime, or time, as accepted by
+// ParseISODatetime or ParseISOTimeParts, and the components are checked against the
+// calendar, but no time.Time is constructed.  If s isn't valid, DetectFormat returns
+// UnknownFormat and the error that ParseISODatetime would.
+//
+// Where a string could be read either way, it is a date, as with ParseISODatetime:
+// "2018" is a year, not the time 20:18.
+func DetectFormat(s string) (Format, error) {
+	format, _, err := detectFormat(s)
+	return format, err
+}
+
+// detectFormat does the work for DetectFormat.  It also returns the index of the time
+// portion of s, or -1 if there is none.
+func detectFormat(s string) (format Format, timeStart int, err error) {
+	parts, err := parseISODatetime(s)
+	if err != nil {
+		if components, _, _, terr := parseISOTime(s); terr == nil {
+			if (TimeOfDay{components[0], components[1], components[2], components[3]}).IsValid() {
+				return TimeOnly, 0, nil
+			}
+		}
+		return UnknownFormat, -1, err
+	}
+	if err := checkRanges(parts.date[0], time.Month(parts.date[1]), parts.date[2], parts.time[0], parts.time[1], parts.time[2], parts.time[3], parts.tz); err != nil {
+		return UnknownFormat, -1, parts.locate(s, err)
+	}
+	if !parts.hasTime {
+		return dateFormat(s), -1, nil
+	}
+	return dateTimeFormats[dateFormat(s[:parts.timePos-1])], parts.timePos, nil
+}
+
+// dateFormat classifies date, which must be a valid date string.
+func dateFormat(date string) Format {
+	extended := len(date) > 4 && date[4] == dateSep
+	switch {
+	case len(date) == 4:
+		return YearDate
+	case strings.IndexByte(date, 'W') >= 0:
+		if extended {
+			return WeekDateExtended
+		}
+		return WeekDateBasic
+	case extended && len(date) == 7:
+		return YearMonthDate
+	case extended && len(date) == 8:
+		return OrdinalDateExtended
+	case extended:
+		return CalendarDateExtended
+	case len(date) == 7:
+		return OrdinalDateBasic
+	}
+	return CalendarDateBasic
+}
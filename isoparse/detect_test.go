@@ -0,0 +1,112 @@
+package isoparse
+
+import (
+	"errors"
+	"testing"
+)
+
+var detectFormats = map[string]Format{
+	"2018-09-27":                    CalendarDateExtended,
+	"20180927":                      CalendarDateBasic,
+	"2018-09":                       YearMonthDate,
+	"2018":                          YearDate,
+	"2018-W39-4":                    WeekDateExtended,
+	"2018-W39":                      WeekDateExtended,
+	"2018W394":                      WeekDateBasic,
+	"2018W39":                       WeekDateBasic,
+	"2018-270":                      OrdinalDateExtended,
+	"2018270":                       OrdinalDateBasic,
+	"2018-09-27T05:00:00":           CalendarDateTimeExtended,
+	"2018-09-27T05:00:00.123+05:30": CalendarDateTimeExtended,
+	"20180927T050000Z":              CalendarDateTimeBasic,
+	"2018-W39-4T05:00":              WeekDateTimeExtended,
+	"2018W394T05":                   WeekDateTimeBasic,
+	"2018-270T05:00:00Z":            OrdinalDateTimeExtended,
+	"2018270T050000-0800":           OrdinalDateTimeBasic,
+	"2018-09-27T24:00":              CalendarDateTimeExtended,
+	"05:00:00":                      TimeOnly,
+	"05:00:00.5Z":                   TimeOnly,
+	"050000+0530":                   TimeOnly,
+	"24:00":                         TimeOnly,
+}
+
+func TestDetectFormat(t *testing.T) {
+	for s, want := range detectFormats {
+		if got, err := DetectFormat(s); err != nil || got != want {
+			t.Errorf(`DetectFormat(%q) -> (%v, %v) (should be %v)`, s, got, err, want)
+		}
+	}
+}
+
+func TestDetectFormatAllFormats(t *testing.T) {
+	for datetime := range allFormats {
+		if got, err := DetectFormat(datetime); err != nil || got == UnknownFormat || got == TimeOnly {
+			t.Errorf(`DetectFormat(%q) -> (%v, %v) (should be a date or datetime format)`, datetime, got, err)
+		}
+	}
+}
+
+var detectFormatErrors = map[string]error{
+	"2018-02-30":          ErrInvalidDay,
+	"2018-13":             ErrInvalidMonth,
+	"2018-09-27T25:00":    ErrTimeRange,
+	"25:00":               ErrSyntax,
+	"2018-09-27T05:00:0x": ErrSyntax,
+	"not a timestamp":     ErrSyntax,
+}
+
+func TestDetectFormatInvalid(t *testing.T) {
+	for s, want := range detectFormatErrors {
+		if got, err := DetectFormat(s); got != UnknownFormat || !errors.Is(err, want) {
+			t.Errorf(`DetectFormat(%q) -> (%v, %v) (should be UnknownFormat and %v)`, s, got, err, want)
+		}
+	}
+}
+
+func TestFormatString(t *testing.T) {
+	if s := WeekDateBasic.String(); s != "WeekDateBasic" {
+		t.Errorf(`WeekDateBasic.String() -> %q (should be "WeekDateBasic")`, s)
+	}
+	if s := Format(100).String(); s != "Format(100)" {
+		t.Errorf(`Format(100).String() -> %q (should be "Format(100)")`, s)
+	}
+}
+
+var valueKinds = map[string]ValueKind{
+	"2018-09-27":          DateValue,
+	"2018-09":             DateValue,
+	"2018W39":             DateValue,
+	"2018-270":            DateValue,
+	"2018-09-27T05:00:00": DateTimeValue,
+	"2018W394T05Z":        DateTimeValue,
+	"05:00:00":            TimeValue,
+	"0500+01:00":          TimeValue,
+	"2018-02-30":          UnknownValue,
+	"05:00:00T2018":       UnknownValue,
+}
+
+func TestClassify(t *testing.T) {
+	for s, want := range valueKinds {
+		got, err := Classify(s)
+		if got != want || (err == nil) != (want != UnknownValue) {
+			t.Errorf(`Classify(%q) -> (%v, %v) (should be %v)`, s, got, err, want)
+		}
+	}
+}
+
+func TestFormatKind(t *testing.T) {
+	for f := UnknownFormat; f <= TimeOnly; f++ {
+		want := DateValue
+		switch {
+		case f == UnknownFormat:
+			want = UnknownValue
+		case f == TimeOnly:
+			want = TimeValue
+		case dateTimeFormats[dateFormatOf(f)] == f:
+			want = DateTimeValue
+		}
+		if got := f.Kind(); got != want {
+			t.Errorf(`%v.Kind() -> %v (should be %v)`, f, got, want)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"strconv"
+	"time"
+)
+
+// Resolving wall-clock readings to instants.
+//
+// time.Date documents that "in such cases [DST transitions], the choice of time zone,
+// and therefore the time, is not guaranteed."  Because civil values in this package
+// are routinely anchored into named locations, we pin down the behavior ourselves:
+//
+//   - A wall time that occurs twice (the repeated hour when clocks fall back) resolves
+//     to the earlier of the two instants.
+//   - A wall time that never occurs (the skipped hour when clocks spring forward) is
+//     shifted forward by the length of the gap.  In other words it is interpreted with
+//     the offset that was in effect just before the transition, so 02:30 on a day when
+//     clocks jump from 02:00 to 03:00 becomes 03:30.
+
+// wallCandidates finds the instants at which the wall clock in loc reads the given
+// components.  Hour 24 and other out-of-range components are normalized as in time.Date.
+//
+// It returns the earliest and latest such instants.  If the wall time is skipped
+// entirely, gap is true, latest is the forward-shifted instant described above, and
+// earliest is the instant reached by instead using the offset in effect after the
+// transition, which lands before the gap (01:30 rather than 03:30, in the example above).
+func wallCandidates(year int, month time.Month, day, hour, min, sec, nsec int, loc *time.Location) (earliest, latest time.Time, gap bool) {
+	// The wall reading as if it were UTC; subtracting an offset from it gives an instant.
+	wall := time.Date(year, month, day, hour, min, sec, nsec, time.UTC)
+
+	// Offsets in effect around the wall time.  A day on either side is enough to reach
+	// past any real-world transition, and time.Date's own pick covers the rest.
+	_, before := wall.Add(-24 * time.Hour).In(loc).Zone()
+	_, after := wall.Add(24 * time.Hour).In(loc).Zone()
+	_, own := time.Date(year, month, day, hour, min, sec, nsec, loc).Zone()
+
+	found := 0
+	for _, offset := range [...]int{before, own, after} {
+		t := wall.Add(-time.Duration(offset) * time.Second).In(loc)
+		if !sameWall(t, wall) {
+			continue
+		}
+		if found == 0 || t.Before(earliest) {
+			earliest = t
+		}
+		if found == 0 || t.After(latest) {
+			latest = t
+		}
+		found++
+	}
+	if found == 0 {
+		earliest = wall.Add(-time.Duration(after) * time.Second).In(loc)
+		latest = wall.Add(-time.Duration(before) * time.Second).In(loc)
+		return earliest, latest, true
+	}
+	return earliest, latest, false
+}
+
+// sameWall reports whether t (in its own location) has the wall clock reading of
+// wall (whose location is ignored).
+func sameWall(t, wall time.Time) bool {
+	y1, m1, d1 := t.Date()
+	y2, m2, d2 := wall.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2 && t.Hour() == wall.Hour() &&
+		t.Minute() == wall.Minute() && t.Second() == wall.Second() && t.Nanosecond() == wall.Nanosecond()
+}
+
+// WallStatus describes how a wall-clock reading maps onto instants in a location.
+type WallStatus int
+
+const (
+	WallUnique      WallStatus = iota // The reading occurs exactly once.
+	WallAmbiguous                     // The reading occurs twice, because clocks were set back.
+	WallNonexistent                   // The reading never occurs, because clocks were set forward.
+)
+
+func (s WallStatus) String() string {
+	switch s {
+	case WallUnique:
+		return "unique"
+	case WallAmbiguous:
+		return "ambiguous"
+	case WallNonexistent:
+		return "nonexistent"
+	}
+	return "WallStatus(" + strconv.Itoa(int(s)) + ")"
+}
+
+// SetLocStrict is like SetLoc, but reports whether the wall clock reading of t exists, and
+// is unique, in loc.  Where SetLoc silently returns whatever time.Date normalizes to,
+// SetLocStrict returns both candidates and leaves the choice to the caller:
+//
+//   - WallUnique: earliest and latest are the same instant.
+//   - WallAmbiguous: the reading falls in the hour repeated when clocks fell back, and
+//     earliest and latest are its first and second occurrences.
+//   - WallNonexistent: the reading falls in the hour skipped when clocks sprang forward.
+//     latest is the reading shifted forward by the gap (02:30 becomes 03:30), and earliest
+//     is the reading shifted back by it (02:30 becomes 01:30).
+//
+// DateTime.In agrees with earliest, except for nonexistent readings, where it agrees with
+// latest.  (SetLoc currently agrees with earliest throughout, but only because time.Date
+// happens to; the time package does not guarantee it.)  SetLocStrict panics if loc is nil.
+func SetLocStrict(t time.Time, loc *time.Location) (earliest, latest time.Time, status WallStatus) {
+	if loc == nil {
+		panic("isoparse: SetLocStrict called with nil location")
+	}
+	earliest, latest, gap := wallCandidates(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+	switch {
+	case gap:
+		status = WallNonexistent
+	case !earliest.Equal(latest):
+		status = WallAmbiguous
+	}
+	return earliest, latest, status
+}
+
+// DSTPolicy chooses how a Parser resolves wall-clock readings that are nonexistent or
+// ambiguous in the location configured with WithLocation, or named in an RFC 9557
+// annotation.  It has no effect on strings with a UTC offset, which name an instant exactly.
+type DSTPolicy int
+
+const (
+	// DSTShiftForward, the default, resolves an ambiguous reading to its first occurrence
+	// and shifts a nonexistent reading forward by the length of the gap, as DateTime.In
+	// does.  This is also the behavior of java.time and of JavaScript's Temporal.
+	DSTShiftForward DSTPolicy = iota
+
+	// DSTEarlier resolves to the earlier candidate: the first occurrence of an ambiguous
+	// reading, or a nonexistent reading shifted back by the length of the gap.
+	DSTEarlier
+
+	// DSTLater resolves to the later candidate: the second occurrence of an ambiguous
+	// reading, or a nonexistent reading shifted forward by the length of the gap.
+	DSTLater
+
+	// DSTReject makes nonexistent and ambiguous readings a *ParseError.
+	DSTReject
+)
+
+// WithDSTPolicy sets how a Parser resolves wall-clock readings that fall in a DST gap or
+// overlap.  The SetLocStrict documentation describes the candidates in each case.
+func WithDSTPolicy(policy DSTPolicy) Option {
+	return func(p *Parser) {
+		p.dstPolicy = policy
+	}
+}
+
+// resolveWall returns the instant at which the wall clock in loc reads wall (whose own
+// location is ignored), applying p's DST policy.  s is the string being parsed, for errors.
+func (p *Parser) resolveWall(s string, wall time.Time, loc *time.Location) (time.Time, error) {
+	earliest, latest, gap := wallCandidates(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), loc)
+	switch {
+	case earliest.Equal(latest):
+		return earliest, nil
+	case p.dstPolicy == DSTReject && gap:
+		return time.Time{}, &ParseError{s, "local time does not exist in " + loc.String(), -1, "", ErrNonexistentTime}
+	case p.dstPolicy == DSTReject:
+		return time.Time{}, &ParseError{s, "local time is ambiguous in " + loc.String(), -1, "", ErrAmbiguousTime}
+	case p.dstPolicy == DSTEarlier, p.dstPolicy == DSTShiftForward && !gap:
+		return earliest, nil
+	}
+	return latest, nil
+}
+
+// startOfDay returns the first instant of d in p's location: midnight, or where midnight is
+// skipped (as it is in some zones that change clocks at 00:00), the end of the gap.
+// The DST policy does not apply, since the first instant of a day is never ambiguous.
+func (p *Parser) startOfDay(d Date) time.Time {
+	return startOfDayIn(d, p.location())
+}
+
+// anchorDate returns the instant for d, the date alone in the string s, in loc: the start
+// of the day, or the time set with WithDateAnchor.
+func (p *Parser) anchorDate(s string, d Date, loc *time.Location) (time.Time, error) {
+	if p.dateAnchor == nil {
+		return startOfDayIn(d, loc), nil
+	}
+	a := p.dateAnchor
+	return p.resolveWall(s, time.Date(d.Year, d.Month, d.Day, a.Hour, a.Minute, a.Second, a.Nanosecond, time.UTC), loc)
+}
+
+// startOfDayIn is startOfDay for an arbitrary location.
+func startOfDayIn(d Date, loc *time.Location) time.Time {
+	earliest, latest, gap := wallCandidates(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+	if gap {
+		return latest
+	}
+	return earliest
+}
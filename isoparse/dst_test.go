@@ -0,0 +1,114 @@
+package isoparse
+
+import (
+	"testing"
+	"time"
+)
+
+// loadLocation loads a named zone, skipping the test if the system lacks zoneinfo.
+func loadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf(`time.LoadLocation(%q) -> %v; skipping`, name, err)
+	}
+	return loc
+}
+
+type wallCase struct {
+	dt       DateTime
+	earliest time.Time
+	latest   time.Time
+	gap      bool
+}
+
+// America/New_York: clocks spring forward 2018-03-11 02:00 EST -> 03:00 EDT,
+// and fall back 2018-11-04 02:00 EDT -> 01:00 EST.
+func newYorkWallCases() []wallCase {
+	est, edt := time.FixedZone("EST", -5*60*60), time.FixedZone("EDT", -4*60*60)
+	return []wallCase{
+		// Ordinary, unambiguous times.
+		{DateTime{Date{2018, 7, 4}, TimeOfDay{12, 0, 0, 0}}, time.Date(2018, 7, 4, 12, 0, 0, 0, edt), time.Date(2018, 7, 4, 12, 0, 0, 0, edt), false},
+		{DateTime{Date{2018, 1, 4}, TimeOfDay{12, 0, 0, 0}}, time.Date(2018, 1, 4, 12, 0, 0, 0, est), time.Date(2018, 1, 4, 12, 0, 0, 0, est), false},
+		// Nonexistent: shifted back or forward by the hour-long gap.
+		{DateTime{Date{2018, 3, 11}, TimeOfDay{2, 30, 0, 0}}, time.Date(2018, 3, 11, 1, 30, 0, 0, est), time.Date(2018, 3, 11, 3, 30, 0, 0, edt), true},
+		{DateTime{Date{2018, 3, 11}, TimeOfDay{2, 0, 0, 0}}, time.Date(2018, 3, 11, 1, 0, 0, 0, est), time.Date(2018, 3, 11, 3, 0, 0, 0, edt), true},
+		{DateTime{Date{2018, 3, 11}, TimeOfDay{3, 0, 0, 0}}, time.Date(2018, 3, 11, 3, 0, 0, 0, edt), time.Date(2018, 3, 11, 3, 0, 0, 0, edt), false},
+		// Ambiguous: occurs once in EDT and again in EST.
+		{DateTime{Date{2018, 11, 4}, TimeOfDay{1, 30, 0, 0}}, time.Date(2018, 11, 4, 1, 30, 0, 0, edt), time.Date(2018, 11, 4, 1, 30, 0, 0, est), false},
+		{DateTime{Date{2018, 11, 4}, TimeOfDay{2, 0, 0, 0}}, time.Date(2018, 11, 4, 2, 0, 0, 0, est), time.Date(2018, 11, 4, 2, 0, 0, 0, est), false},
+	}
+}
+
+func TestWallCandidates(t *testing.T) {
+	loc := loadLocation(t, "America/New_York")
+	for _, c := range newYorkWallCases() {
+		d, tod := c.dt.Date, c.dt.Time
+		earliest, latest, gap := wallCandidates(d.Year, d.Month, d.Day, tod.Hour, tod.Minute, tod.Second, tod.Nanosecond, loc)
+		if !earliest.Equal(c.earliest) || !latest.Equal(c.latest) || gap != c.gap {
+			t.Errorf(`wallCandidates(%v) -> (%v, %v, %v) (should be (%v, %v, %v))`, c.dt, earliest, latest, gap, c.earliest, c.latest, c.gap)
+		}
+		if earliest.Location() != loc || latest.Location() != loc {
+			t.Errorf(`wallCandidates(%v) returned instants not in %v`, c.dt, loc)
+		}
+	}
+}
+
+func TestSetLocStrict(t *testing.T) {
+	loc := loadLocation(t, "America/New_York")
+	for _, c := range newYorkWallCases() {
+		naive := c.dt.In(time.UTC)
+		earliest, latest, status := SetLocStrict(naive, loc)
+		want := WallUnique
+		if c.gap {
+			want = WallNonexistent
+		} else if !c.earliest.Equal(c.latest) {
+			want = WallAmbiguous
+		}
+		if !earliest.Equal(c.earliest) || !latest.Equal(c.latest) || status != want {
+			t.Errorf(`SetLocStrict(%v) -> (%v, %v, %v) (should be (%v, %v, %v))`, naive, earliest, latest, status, c.earliest, c.latest, want)
+		}
+	}
+}
+
+func TestDSTPolicy(t *testing.T) {
+	loc := loadLocation(t, "America/New_York")
+	policies := []DSTPolicy{DSTShiftForward, DSTEarlier, DSTLater, DSTReject}
+	for _, c := range newYorkWallCases() {
+		s := c.dt.String()
+		ambiguous := !c.gap && !c.earliest.Equal(c.latest)
+		for _, policy := range policies {
+			want, wantErr := c.earliest, false
+			switch {
+			case policy == DSTReject:
+				wantErr = c.gap || ambiguous
+			case policy == DSTLater, policy == DSTShiftForward && c.gap:
+				want = c.latest
+			}
+			got, err := NewParser(WithLocation(loc), WithDSTPolicy(policy)).Parse(s)
+			if wantErr {
+				if err == nil {
+					t.Errorf(`Parse(%q) with policy %d -> %v (should be an error)`, s, policy, got)
+				}
+			} else if err != nil || !got.Equal(want) {
+				t.Errorf(`Parse(%q) with policy %d -> %v, %v (should be %v)`, s, policy, got, err, want)
+			}
+		}
+	}
+	// The policy also applies to zones named in RFC 9557 annotations, but not to offsets.
+	if _, err := NewParser(WithDSTPolicy(DSTReject)).Parse("2018-11-04T01:30:00[America/New_York]"); err == nil {
+		t.Errorf(`Parse of an ambiguous annotated time with DSTReject returned nil error`)
+	}
+	if _, err := NewParser(WithLocation(loc), WithDSTPolicy(DSTReject)).Parse("2018-11-04T01:30:00-05:00"); err != nil {
+		t.Errorf(`Parse of an ambiguous time with an offset and DSTReject -> non-nil error (%v)`, err)
+	}
+}
+
+func TestStartOfDay(t *testing.T) {
+	// Clocks in Sao Paulo sprang forward from 00:00 to 01:00 on 2018-11-04.
+	loc := loadLocation(t, "America/Sao_Paulo")
+	got, err := NewParser(WithLocation(loc), WithDSTPolicy(DSTReject)).Parse("2018-11-04")
+	if want := time.Date(2018, 11, 4, 1, 0, 0, 0, loc); err != nil || !got.Equal(want) {
+		t.Errorf(`Parse("2018-11-04") in %v -> %v, %v (should be %v)`, loc, got, err, want)
+	}
+}
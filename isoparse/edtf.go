@@ -0,0 +1,533 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Extended Date/Time Format
+//
+// EDTF (https://www.loc.gov/standards/datetime/), since folded into ISO 8601-2, extends
+// ISO-8601 with notation for the imprecise dates found in archival and bibliographic
+// records: seasons, sets of possible dates, unspecified digits, and so on.  Most of these
+// name a span of days rather than an instant, so they don't fit in a time.Time, and the
+// functions here parse each into a type of its own.  ParseISODatetime and the other ISO
+// parsers are unaffected.
+//
+// EDTF comes in three levels of conformance: level 0 is plain ISO-8601, and levels 1 and 2
+// add successively more of the extended grammar.  The package-level functions here accept
+// everything up to level 2.  Consumers that want exactly one level can create a Parser
+// with WithEDTF and use its methods instead, which reject anything beyond that level with
+// an error wrapping ErrUnsupported.
+
+// edtfParser backs the package-level EDTF functions.
+var edtfParser = &Parser{edtfLevel: 2}
+
+// WithEDTF sets the EDTF conformance level, 0, 1, or 2, accepted by a Parser's EDTF
+// methods such as ParseYearSeason.  The default is 0, at which they accept only what plain
+// ISO-8601 allows.  A level outside that range is taken as the nearest one.  Parse and the
+// other ISO methods are unaffected.
+func WithEDTF(level int) Option {
+	return func(p *Parser) {
+		switch {
+		case level < 0:
+			level = 0
+		case level > 2:
+			level = 2
+		}
+		p.edtfLevel = level
+	}
+}
+
+// requireEDTF returns an error if p's EDTF level is below level, which feature of s, at
+// pos, needs.
+func (p *Parser) requireEDTF(s string, level, pos int, element, feature string) error {
+	if p.edtfLevel >= level {
+		return nil
+	}
+	return &ParseError{s, feature + " requires EDTF level " + strconv.Itoa(level), pos, element, ErrUnsupported}
+}
+
+// Season is an EDTF sub-year grouping, written in place of the month: "2001-21" is the
+// spring of 2001.  Codes 21 to 24 are the plain seasons; 25 to 41 are the finer groupings
+// of EDTF level 2.
+type Season int
+
+const (
+	Spring Season = 21 + iota
+	Summer
+	Autumn
+	Winter
+	SpringNorthern
+	SummerNorthern
+	AutumnNorthern
+	WinterNorthern
+	SpringSouthern
+	SummerSouthern
+	AutumnSouthern
+	WinterSouthern
+	Quarter1
+	Quarter2
+	Quarter3
+	Quarter4
+	Quadrimester1
+	Quadrimester2
+	Quadrimester3
+	Semester1
+	Semester2
+)
+
+// seasonMonths gives the first month and the number of months of each Season, from
+// Spring on.  The plain seasons are taken as the meteorological seasons of the northern
+// hemisphere, so winter runs from December into the next year.
+var seasonMonths = [...]struct {
+	first  time.Month
+	months int
+}{
+	{time.March, 3}, {time.June, 3}, {time.September, 3}, {time.December, 3},
+	{time.March, 3}, {time.June, 3}, {time.September, 3}, {time.December, 3},
+	{time.September, 3}, {time.December, 3}, {time.March, 3}, {time.June, 3},
+	{time.January, 3}, {time.April, 3}, {time.July, 3}, {time.October, 3},
+	{time.January, 4}, {time.May, 4}, {time.September, 4},
+	{time.January, 6}, {time.July, 6},
+}
+
+var seasonNames = [...]string{
+	"Spring", "Summer", "Autumn", "Winter",
+	"Spring (Northern Hemisphere)", "Summer (Northern Hemisphere)",
+	"Autumn (Northern Hemisphere)", "Winter (Northern Hemisphere)",
+	"Spring (Southern Hemisphere)", "Summer (Southern Hemisphere)",
+	"Autumn (Southern Hemisphere)", "Winter (Southern Hemisphere)",
+	"Quarter 1", "Quarter 2", "Quarter 3", "Quarter 4",
+	"Quadrimester 1", "Quadrimester 2", "Quadrimester 3",
+	"Semestral 1", "Semestral 2",
+}
+
+// IsValid reports whether s is one of the EDTF season codes, 21 to 41.
+func (s Season) IsValid() bool {
+	return s >= Spring && s <= Semester2
+}
+
+func (s Season) String() string {
+	if !s.IsValid() {
+		return "Season(" + strconv.Itoa(int(s)) + ")"
+	}
+	return seasonNames[s-Spring]
+}
+
+// YearSeason is a season of a given year, such as "2001-21".
+type YearSeason struct {
+	Year   int
+	Season Season
+}
+
+// ParseYearSeason parses an EDTF season, YYYY-SS, where SS is a season code from 21 to
+// 41.
+func ParseYearSeason(s string) (YearSeason, error) {
+	return edtfParser.ParseYearSeason(s)
+}
+
+// ParseYearSeason is like the package-level ParseYearSeason, but accepts only what p's
+// EDTF level allows: codes 21 to 24 at level 1, and all of them at level 2.
+func (p *Parser) ParseYearSeason(s string) (YearSeason, error) {
+	year, ok1 := scanXSDDigits(s, 0, 4)
+	season, ok2 := scanXSDDigits(s, 5, 2)
+	if !ok1 || !ok2 || len(s) != len("YYYY-SS") || s[4] != dateSep {
+		return YearSeason{}, &ParseError{s, "season must be in YYYY-SS format", 0, "season", ErrSyntax}
+	}
+	ys := YearSeason{year, Season(season)}
+	if !ys.Season.IsValid() {
+		return YearSeason{}, &ParseError{s, "season out of valid range", 5, "season", ErrInvalidMonth}
+	}
+	if err := p.requireEDTF(s, 1+btoi(ys.Season > Winter), 5, "season", "season "+s[5:]); err != nil {
+		return YearSeason{}, err
+	}
+	return ys, nil
+}
+
+// String returns the season in EDTF format, YYYY-SS.
+func (ys YearSeason) String() string {
+	return fmt.Sprintf("%04d-%02d", ys.Year, int(ys.Season))
+}
+
+// FirstDay returns the first day of the season.  The season must be valid.
+func (ys YearSeason) FirstDay() Date {
+	return Date{ys.Year, seasonMonths[ys.Season-Spring].first, 1}
+}
+
+// LastDay returns the last day of the season.  The season must be valid.
+func (ys YearSeason) LastDay() Date {
+	months := seasonMonths[ys.Season-Spring].months
+	return YearMonth{ys.Year, seasonMonths[ys.Season-Spring].first}.AddMonths(months - 1).LastDay()
+}
+
+// Interval returns the season as an Interval, from midnight at the start of its first
+// day to midnight at the end of its last, in loc.  The season must be valid.
+func (ys YearSeason) Interval(loc *time.Location) Interval {
+	return Interval{ys.FirstDay().In(loc), ys.LastDay().AddDays(1).In(loc)}
+}
+
+// DateSpan is a span of whole days, from First through Last.  A date of reduced precision
+// covers every day in it: "1961-12" is 1961-12-01 through 1961-12-31.
+type DateSpan struct {
+	First, Last Date
+	OpenStart   bool // The span has no first day: it is Last or any day before.  First is zero.
+	OpenEnd     bool // The span has no last day: it is First or any day after.  Last is zero.
+}
+
+// DateSet is an EDTF level 2 set of dates.  A set in square brackets,
+// "[1667,1668,1670..1672]", means one of its members, and a set in braces,
+// "{1960,1961-12}", means all of them.
+type DateSet struct {
+	AllOf   bool // Braces: all of the members, rather than one of them
+	Members []DateSpan
+}
+
+// ParseDateSet parses an EDTF set.  Each member is a date of year, month, or day
+// precision, or a range of them such as "1670..1672".  The first member may be open at
+// the start ("..1760-12-03", that date or any earlier one) and the last open at the end
+// ("1760-12.."), and a space may follow each comma.
+func ParseDateSet(s string) (DateSet, error) {
+	return edtfParser.ParseDateSet(s)
+}
+
+// ParseDateSet is like the package-level ParseDateSet, but requires EDTF level 2.
+func (p *Parser) ParseDateSet(s string) (DateSet, error) {
+	var set DateSet
+	if len(s) < 2 || !(s[0] == '[' && s[len(s)-1] == ']' || s[0] == '{' && s[len(s)-1] == '}') {
+		return set, &ParseError{s, "set must be enclosed in [] or {}", 0, "set", ErrSyntax}
+	}
+	if err := p.requireEDTF(s, 2, 0, "set", "set"); err != nil {
+		return set, err
+	}
+	set.AllOf = s[0] == '{'
+	end := len(s) - 1
+	for start := 1; start <= end; {
+		next := start + strings.IndexByte(s[start:end], ',')
+		if next < start {
+			next = end
+		}
+		member, err := parseDateSpan(s, start, next, start == 1, next == end)
+		if err != nil {
+			return DateSet{}, err
+		}
+		set.Members = append(set.Members, member)
+		start = next + 1
+		for start < end && s[start] == ' ' {
+			start++
+		}
+	}
+	return set, nil
+}
+
+// parseDateSpan parses s[start:end] as a member of a set, reporting errors in terms of s.
+func parseDateSpan(s string, start, end int, first, last bool) (DateSpan, error) {
+	var span DateSpan
+	dots := start + strings.Index(s[start:end], "..")
+	if dots < start {
+		var err error
+		span.First, span.Last, err = parseEDTFDate(s, start, end)
+		return span, err
+	}
+	span.OpenStart, span.OpenEnd = dots == start, dots+2 == end
+	switch {
+	case span.OpenStart && span.OpenEnd:
+		return span, &ParseError{s, "set member must have a date", start, "set", ErrSyntax}
+	case span.OpenStart && !first:
+		return span, &ParseError{s, "only the first set member may be open at the start", start, "set", ErrSyntax}
+	case span.OpenEnd && !last:
+		return span, &ParseError{s, "only the last set member may be open at the end", dots, "set", ErrSyntax}
+	}
+	if !span.OpenStart {
+		first, _, err := parseEDTFDate(s, start, dots)
+		if err != nil {
+			return span, err
+		}
+		span.First = first
+	}
+	if !span.OpenEnd {
+		_, last, err := parseEDTFDate(s, dots+2, end)
+		if err != nil {
+			return span, err
+		}
+		span.Last = last
+	}
+	if !span.OpenStart && !span.OpenEnd && span.Last.Before(span.First) {
+		return span, &ParseError{s, "range end precedes its start", dots, "set", ErrIntervalOrder}
+	}
+	return span, nil
+}
+
+// parseEDTFDate parses s[start:end] as a date of year, month, or day precision, YYYY,
+// YYYY-MM, or YYYY-MM-DD, and returns the first and last days that it covers.
+func parseEDTFDate(s string, start, end int) (first, last Date, err error) {
+	date := s[start:end]
+	year, ok := scanXSDDigits(date, 0, 4)
+	length := len(date)
+	if !ok || (length != 4 && length != 7 && length != 10) || (length > 4 && date[4] != dateSep) || (length > 7 && date[7] != dateSep) {
+		return first, last, &ParseError{s, "date must be in YYYY, YYYY-MM, or YYYY-MM-DD format", start, "date", ErrSyntax}
+	}
+	if length == 4 {
+		return Date{year, time.January, 1}, Date{year, time.December, 31}, nil
+	}
+	month, ok := scanXSDDigits(date, 5, 2)
+	if !ok {
+		return first, last, &ParseError{s, "invalid month", start + 5, "month", ErrSyntax}
+	}
+	ym := YearMonth{year, time.Month(month)}
+	if !ym.IsValid() {
+		return first, last, &ParseError{s, "month out of valid range", start + 5, "month", ErrInvalidMonth}
+	}
+	if length == 7 {
+		return ym.FirstDay(), ym.LastDay(), nil
+	}
+	day, ok := scanXSDDigits(date, 8, 2)
+	if !ok {
+		return first, last, &ParseError{s, "invalid day", start + 8, "day", ErrSyntax}
+	}
+	d := Date{year, ym.Month, day}
+	if !d.IsValid() {
+		return first, last, &ParseError{s, "day out of valid range", start + 8, "day", ErrInvalidDay}
+	}
+	return d, d, nil
+}
+
+// YearRange is a span of whole years, First through Last.
+type YearRange struct {
+	First, Last int
+}
+
+// ParseYearRange parses a year of reduced precision into the range of years it covers,
+// rather than picking one of them as ParseISODatetime would have to:
+//
+//	"199X"  1990 through 1999  (EDTF unspecified digits: a decade)
+//	"19XX"  1900 through 1999  (a century)
+//	"1XXX"  1000 through 1999
+//	"19"    1900 through 1999  (ISO 8601:2004 4.1.2.3, a century of reduced accuracy)
+func ParseYearRange(s string) (YearRange, error) {
+	return edtfParser.ParseYearRange(s)
+}
+
+// ParseYearRange is like the package-level ParseYearRange, but accepts the EDTF forms
+// with unspecified digits only at EDTF level 1 and above.  The ISO century, "19", is
+// always accepted.
+func (p *Parser) ParseYearRange(s string) (YearRange, error) {
+	digits := -1
+	switch len(s) {
+	case 2:
+		digits = 2
+	case 4:
+		digits = strings.IndexByte(s, 'X')
+	}
+	n, ok := 0, digits > 0
+	if ok {
+		n, ok = parseDigits(s[:digits])
+	}
+	if !ok || strings.Trim(s[digits:], "X") != "" {
+		return YearRange{}, &ParseError{s, "year range must be in YYYX, YYXX, YXXX, or YY format", 0, "year", ErrSyntax}
+	}
+	if digits < len(s) {
+		if err := p.requireEDTF(s, 1, digits, "year", "unspecified digit"); err != nil {
+			return YearRange{}, err
+		}
+	}
+	unit := 100
+	if len(s) == 4 {
+		unit = 1
+		for i := digits; i < 4; i++ {
+			unit *= 10
+		}
+	}
+	return YearRange{n * unit, n*unit + unit - 1}, nil
+}
+
+// Contains reports whether year is in r.
+func (r YearRange) Contains(year int) bool {
+	return year >= r.First && year <= r.Last
+}
+
+// String returns r as an EDTF interval of years, such as "1990/1999".
+func (r YearRange) String() string {
+	return fmt.Sprintf("%04d/%04d", r.First, r.Last)
+}
+
+// Interval returns r as an Interval, from midnight at the start of its first year to
+// midnight at the end of its last, in loc.
+func (r YearRange) Interval(loc *time.Location) Interval {
+	return Interval{time.Date(r.First, time.January, 1, 0, 0, 0, 0, loc), time.Date(r.Last+1, time.January, 1, 0, 0, 0, 0, loc)}
+}
+
+// maxLongYearExponent bounds the exponent of a LongYear, so that a short string can't
+// demand an enormous number.  10^1000 years is far beyond any date in science.
+const maxLongYearExponent = 1000
+
+// LongYear is an EDTF year too large for a Date or a time.Time, such as a geological or
+// astronomical date.
+type LongYear struct {
+	Year        *big.Int // Astronomical numbering, as with time.Time: "Y-170000002" is -170000002.
+	Significant int      // The number of significant digits, from an "S" suffix, or 0 if all are
+}
+
+// ParseLongYear parses an EDTF letter-prefixed year, "Y" followed by an optional minus
+// sign and a year of more than four digits ("Y170000002"), or the exponential form of
+// level 2, where the year is a number of digits times a power of ten ("Y-17E7" is
+// -170000000).  The exponent may be at most 1000.  Either form may end with a
+// significant-digits suffix, as in "Y171010000S3"; see LongYear.Range.
+func ParseLongYear(s string) (LongYear, error) {
+	return edtfParser.ParseLongYear(s)
+}
+
+// ParseLongYear is like the package-level ParseLongYear, but requires EDTF level 1, and
+// level 2 for an exponent or significant digits.
+func (p *Parser) ParseLongYear(s string) (LongYear, error) {
+	if len(s) == 0 || s[0] != 'Y' {
+		return LongYear{}, &ParseError{s, "long year must start with 'Y'", 0, "year", ErrSyntax}
+	}
+	pos := 1 + btoi(len(s) > 1 && s[1] == '-')
+	start := pos
+	for pos < len(s) && isDigit(s[pos]) {
+		pos++
+	}
+	digits := s[start:pos]
+	if digits == "" {
+		return LongYear{}, &ParseError{s, "long year must have digits", start, "year", ErrSyntax}
+	}
+	exponent := -1
+	if pos < len(s) && s[pos] == 'E' {
+		expStart := pos + 1
+		for pos = expStart; pos < len(s) && isDigit(s[pos]); pos++ {
+		}
+		if pos == expStart {
+			return LongYear{}, &ParseError{s, "exponent must have digits", expStart, "exponent", ErrSyntax}
+		}
+		// Trim leading zeros so that the length check below is a bound on the value.
+		expDigits := strings.TrimLeft(s[expStart:pos], "0")
+		if len(expDigits) > 4 {
+			return LongYear{}, &ParseError{s, "exponent too large", expStart, "exponent", ErrOverflow}
+		}
+		exponent, _ = parseDigits(expDigits)
+		if exponent > maxLongYearExponent {
+			return LongYear{}, &ParseError{s, "exponent too large", expStart, "exponent", ErrOverflow}
+		}
+	}
+	significant, pos, err := parseSignificant(s, pos)
+	if err != nil {
+		return LongYear{}, err
+	}
+	if pos < len(s) {
+		return LongYear{}, &ParseError{s, "unexpected trailing characters", pos, "year", ErrTrailingData}
+	}
+	if exponent < 0 && len(digits) <= 4 {
+		return LongYear{}, &ParseError{s, "long year must have more than four digits or an exponent", start, "year", ErrSyntax}
+	}
+
+	year, _ := new(big.Int).SetString(digits, 10)
+	if exponent > 0 {
+		year.Mul(year, pow10(exponent))
+	}
+	if significant > len(year.String()) {
+		return LongYear{}, &ParseError{s, "more significant digits than the year has", len(s) - 1, "significant-digits", ErrSyntax}
+	}
+	if err := p.requireEDTF(s, 1, 0, "year", "long year"); err != nil {
+		return LongYear{}, err
+	}
+	if exponent >= 0 || significant > 0 {
+		if err := p.requireEDTF(s, 2, start+len(digits), "year", "exponential year or significant digits"); err != nil {
+			return LongYear{}, err
+		}
+	}
+	if start > 1 {
+		year.Neg(year)
+	}
+	return LongYear{year, significant}, nil
+}
+
+// Range returns the range of years that y stands for, inclusive.  A year with
+// significant digits stands for any year that agrees with it in those digits:
+// "Y171010000S3" is some year from 171000000 through 171999999.  Otherwise the range is
+// y itself.
+func (y LongYear) Range() (first, last *big.Int) {
+	abs := new(big.Int).Abs(y.Year)
+	width := len(abs.String()) - y.Significant
+	if y.Significant == 0 || width <= 0 {
+		return new(big.Int).Set(y.Year), new(big.Int).Set(y.Year)
+	}
+	unit := pow10(width)
+	first = new(big.Int).Sub(abs, new(big.Int).Mod(abs, unit))
+	last = new(big.Int).Sub(new(big.Int).Add(first, unit), big.NewInt(1))
+	if y.Year.Sign() < 0 {
+		first, last = last.Neg(last), first.Neg(first)
+	}
+	return first, last
+}
+
+// pow10 returns 10 to the power n.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// parseSignificant parses an optional EDTF significant-digits suffix, "S" and a number, at
+// s[pos:].  It returns 0 if there is none.
+func parseSignificant(s string, pos int) (significant, newPos int, err error) {
+	if pos >= len(s) || s[pos] != 'S' {
+		return 0, pos, nil
+	}
+	start := pos + 1
+	for pos = start; pos < len(s) && isDigit(s[pos]) && pos-start < 4; pos++ {
+	}
+	significant, ok := parseDigits(s[start:pos])
+	if !ok || significant == 0 {
+		return 0, pos, &ParseError{s, "significant digits must be a positive number", start, "significant-digits", ErrSyntax}
+	}
+	return significant, pos, nil
+}
+
+// ParseSignificantYear parses an EDTF year with significant digits, such as "1950S2",
+// some year from 1900 through 1999.  It returns the year as written, 1950, and the range
+// of years it stands for.  A year without the suffix stands for itself.
+func ParseSignificantYear(s string) (year int, r YearRange, err error) {
+	return edtfParser.ParseSignificantYear(s)
+}
+
+// ParseSignificantYear is like the package-level ParseSignificantYear, but accepts a
+// significant-digits suffix only at EDTF level 2.
+func (p *Parser) ParseSignificantYear(s string) (year int, r YearRange, err error) {
+	year, ok := scanXSDDigits(s, 0, 4)
+	if !ok {
+		return 0, r, &ParseError{s, "year must be in YYYY or YYYYSn format", 0, "year", ErrSyntax}
+	}
+	significant, pos, err := parseSignificant(s, 4)
+	switch {
+	case err != nil:
+		return 0, r, err
+	case pos < len(s):
+		return 0, r, &ParseError{s, "unexpected trailing characters", pos, "year", ErrTrailingData}
+	case significant > 4:
+		return 0, r, &ParseError{s, "more significant digits than the year has", 5, "significant-digits", ErrSyntax}
+	case significant > 0:
+		if err := p.requireEDTF(s, 2, 4, "significant-digits", "significant digits"); err != nil {
+			return 0, r, err
+		}
+	}
+	unit := 1
+	for i := significant; significant > 0 && i < 4; i++ {
+		unit *= 10
+	}
+	first := year - year%unit
+	return year, YearRange{first, first + unit - 1}, nil
+}
+
+// String returns the year in EDTF letter-prefixed form, such as "Y-170000000" or
+// "Y171010000S3".
+func (y LongYear) String() string {
+	if y.Significant > 0 {
+		return "Y" + y.Year.String() + "S" + strconv.Itoa(y.Significant)
+	}
+	return "Y" + y.Year.String()
+}
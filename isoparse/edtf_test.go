@@ -0,0 +1,296 @@
+package isoparse
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseYearSeason(t *testing.T) {
+	for _, c := range []struct {
+		s           string
+		want        YearSeason
+		first, last Date
+	}{
+		{"2001-21", YearSeason{2001, Spring}, Date{2001, time.March, 1}, Date{2001, time.May, 31}},
+		{"2001-24", YearSeason{2001, Winter}, Date{2001, time.December, 1}, Date{2002, time.February, 28}},
+		{"2003-32", YearSeason{2003, WinterSouthern}, Date{2003, time.June, 1}, Date{2003, time.August, 31}},
+		{"2004-30", YearSeason{2004, SummerSouthern}, Date{2004, time.December, 1}, Date{2005, time.February, 28}},
+		{"2019-34", YearSeason{2019, Quarter2}, Date{2019, time.April, 1}, Date{2019, time.June, 30}},
+		{"2019-39", YearSeason{2019, Quadrimester3}, Date{2019, time.September, 1}, Date{2019, time.December, 31}},
+		{"2020-41", YearSeason{2020, Semester2}, Date{2020, time.July, 1}, Date{2020, time.December, 31}},
+	} {
+		got, err := ParseYearSeason(c.s)
+		if err != nil || got != c.want {
+			t.Errorf(`ParseYearSeason(%q) -> (%v, %v) (should be %v)`, c.s, got, err, c.want)
+			continue
+		}
+		if got.FirstDay() != c.first || got.LastDay() != c.last {
+			t.Errorf(`ParseYearSeason(%q) -> %v to %v (should be %v to %v)`, c.s, got.FirstDay(), got.LastDay(), c.first, c.last)
+		}
+		if got.String() != c.s {
+			t.Errorf(`ParseYearSeason(%q).String() -> %q`, c.s, got.String())
+		}
+	}
+
+	for s, want := range map[string]error{
+		"2001-20":  ErrInvalidMonth,
+		"2001-42":  ErrInvalidMonth,
+		"2001-2":   ErrSyntax,
+		"200121":   ErrSyntax,
+		"2001-21Z": ErrSyntax,
+		"2001/21":  ErrSyntax,
+	} {
+		if _, err := ParseYearSeason(s); !errors.Is(err, want) {
+			t.Errorf(`ParseYearSeason(%q) -> %v (should wrap %v)`, s, err, want)
+		}
+	}
+}
+
+func TestYearSeasonInterval(t *testing.T) {
+	iv := YearSeason{2001, Winter}.Interval(time.UTC)
+	want := Interval{time.Date(2001, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2002, 3, 1, 0, 0, 0, 0, time.UTC)}
+	if !iv.Equal(want) {
+		t.Errorf(`YearSeason{2001, Winter}.Interval -> %v (should be %v)`, iv, want)
+	}
+}
+
+func TestSeasonString(t *testing.T) {
+	for season, want := range map[Season]string{
+		Spring:         "Spring",
+		AutumnSouthern: "Autumn (Southern Hemisphere)",
+		Semester1:      "Semestral 1",
+		Season(42):     "Season(42)",
+	} {
+		if got := season.String(); got != want {
+			t.Errorf(`Season(%d).String() -> %q (should be %q)`, int(season), got, want)
+		}
+	}
+}
+
+func TestParseDateSet(t *testing.T) {
+	year := func(y int) DateSpan {
+		return DateSpan{First: Date{y, time.January, 1}, Last: Date{y, time.December, 31}}
+	}
+	for _, c := range []struct {
+		s    string
+		want DateSet
+	}{
+		{"[1667,1668,1670..1672]", DateSet{false, []DateSpan{
+			year(1667), year(1668), {First: Date{1670, time.January, 1}, Last: Date{1672, time.December, 31}},
+		}}},
+		{"{1960, 1961-12}", DateSet{true, []DateSpan{
+			year(1960), {First: Date{1961, time.December, 1}, Last: Date{1961, time.December, 31}},
+		}}},
+		{"[..1760-12-03]", DateSet{false, []DateSpan{
+			{Last: Date{1760, time.December, 3}, OpenStart: true},
+		}}},
+		{"[1760-01,1760-02,1760-12..]", DateSet{false, []DateSpan{
+			{First: Date{1760, time.January, 1}, Last: Date{1760, time.January, 31}},
+			{First: Date{1760, time.February, 1}, Last: Date{1760, time.February, 29}},
+			{First: Date{1760, time.December, 1}, OpenEnd: true},
+		}}},
+		{"{1667-02-28..1667-03}", DateSet{true, []DateSpan{
+			{First: Date{1667, time.February, 28}, Last: Date{1667, time.March, 31}},
+		}}},
+	} {
+		got, err := ParseDateSet(c.s)
+		if err != nil || !reflect.DeepEqual(got, c.want) {
+			t.Errorf(`ParseDateSet(%q) -> (%v, %v) (should be %v)`, c.s, got, err, c.want)
+		}
+	}
+
+	for s, want := range map[string]error{
+		"1667,1668":         ErrSyntax,
+		"[1667,1668}":       ErrSyntax,
+		"[]":                ErrSyntax,
+		"[1667,,1668]":      ErrSyntax,
+		"[1667,..1668]":     ErrSyntax,
+		"[1667..,1668]":     ErrSyntax,
+		"[..]":              ErrSyntax,
+		"[1672..1670]":      ErrIntervalOrder,
+		"{1960,1961-13}":    ErrInvalidMonth,
+		"{1960,1961-02-29}": ErrInvalidDay,
+		"{1960,196}":        ErrSyntax,
+	} {
+		if _, err := ParseDateSet(s); !errors.Is(err, want) {
+			t.Errorf(`ParseDateSet(%q) -> %v (should wrap %v)`, s, err, want)
+		}
+	}
+}
+
+func TestParseYearRange(t *testing.T) {
+	for s, want := range map[string]YearRange{
+		"199X": {1990, 1999},
+		"19XX": {1900, 1999},
+		"1XXX": {1000, 1999},
+		"000X": {0, 9},
+		"19":   {1900, 1999},
+		"00":   {0, 99},
+	} {
+		if got, err := ParseYearRange(s); err != nil || got != want {
+			t.Errorf(`ParseYearRange(%q) -> (%v, %v) (should be %v)`, s, got, err, want)
+		}
+	}
+
+	for _, s := range []string{"", "1999", "1", "199", "19X", "19X9", "X999", "199x", "1a9X", "19XXX", "-19X", "XXXX"} {
+		if got, err := ParseYearRange(s); !errors.Is(err, ErrSyntax) {
+			t.Errorf(`ParseYearRange(%q) -> (%v, %v) (should wrap %v)`, s, got, err, ErrSyntax)
+		}
+	}
+}
+
+func TestYearRangeInterval(t *testing.T) {
+	r := YearRange{1990, 1999}
+	iv := r.Interval(time.UTC)
+	want := Interval{time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)}
+	if !iv.Equal(want) || !r.Contains(1995) || r.Contains(2000) || r.String() != "1990/1999" {
+		t.Errorf(`YearRange %v -> interval %v (should be %v)`, r, iv, want)
+	}
+}
+
+func TestParseLongYear(t *testing.T) {
+	for s, want := range map[string]string{
+		"Y170000002":  "170000002",
+		"Y-170000002": "-170000002",
+		"Y-17E7":      "-170000000",
+		"Y17101E4":    "171010000",
+		"Y2E0":        "2",
+		"Y1E20":       "100000000000000000000",
+		"Y00001E0003": "1000",
+	} {
+		got, err := ParseLongYear(s)
+		if err != nil || got.Year.String() != want {
+			t.Errorf(`ParseLongYear(%q) -> (%v, %v) (should be %v)`, s, got, err, want)
+		}
+	}
+
+	for s, want := range map[string]error{
+		"":              ErrSyntax,
+		"170000002":     ErrSyntax,
+		"Y":             ErrSyntax,
+		"Y-":            ErrSyntax,
+		"Y2018":         ErrSyntax,
+		"Y17E":          ErrSyntax,
+		"Y17E-7":        ErrSyntax,
+		"Y170000002x":   ErrTrailingData,
+		"Y1E1001":       ErrOverflow,
+		"Y1E99999999":   ErrOverflow,
+		"Y170000002S":   ErrSyntax,
+		"Y170000002S0":  ErrSyntax,
+		"Y170000002S10": ErrSyntax,
+		"Y17E7S3x":      ErrTrailingData,
+	} {
+		if got, err := ParseLongYear(s); !errors.Is(err, want) {
+			t.Errorf(`ParseLongYear(%q) -> (%v, %v) (should wrap %v)`, s, got, err, want)
+		}
+	}
+}
+
+func TestLongYearRange(t *testing.T) {
+	for s, want := range map[string][2]string{
+		"Y171010000S3": {"171000000", "171999999"},
+		"Y3388E2S3":    {"338000", "338999"},
+		"Y-17E7S1":     {"-199999999", "-100000000"},
+		"Y-17E7":       {"-170000000", "-170000000"},
+		"Y171010000S9": {"171010000", "171010000"},
+	} {
+		y, err := ParseLongYear(s)
+		if err != nil {
+			t.Errorf(`ParseLongYear(%q) -> %v`, s, err)
+			continue
+		}
+		if y.String() != s && !strings.Contains(s, "E") {
+			t.Errorf(`ParseLongYear(%q).String() -> %q`, s, y.String())
+		}
+		if first, last := y.Range(); first.String() != want[0] || last.String() != want[1] {
+			t.Errorf(`ParseLongYear(%q).Range() -> (%v, %v) (should be (%v, %v))`, s, first, last, want[0], want[1])
+		}
+	}
+}
+
+func TestParseSignificantYear(t *testing.T) {
+	for s, want := range map[string]YearRange{
+		"1950S2": {1900, 1999},
+		"1950S1": {1000, 1999},
+		"1950S3": {1950, 1959},
+		"1950S4": {1950, 1950},
+		"1950":   {1950, 1950},
+	} {
+		if year, got, err := ParseSignificantYear(s); err != nil || year != 1950 || got != want {
+			t.Errorf(`ParseSignificantYear(%q) -> (%v, %v, %v) (should be (1950, %v))`, s, year, got, err, want)
+		}
+	}
+
+	for s, want := range map[string]error{
+		"195S2":   ErrSyntax,
+		"1950S":   ErrSyntax,
+		"1950S0":  ErrSyntax,
+		"1950S5":  ErrSyntax,
+		"1950S2x": ErrTrailingData,
+		"1950-01": ErrTrailingData,
+	} {
+		if _, _, err := ParseSignificantYear(s); !errors.Is(err, want) {
+			t.Errorf(`ParseSignificantYear(%q) -> %v (should wrap %v)`, s, err, want)
+		}
+	}
+}
+
+func TestWithEDTF(t *testing.T) {
+	// The lowest level at which each string is accepted.
+	levels := map[string]int{
+		"2001-21":      1,
+		"2001-24":      1,
+		"2001-25":      2,
+		"2001-41":      2,
+		"[1667,1668]":  2,
+		"{1960}":       2,
+		"19":           0,
+		"199X":         1,
+		"19XX":         1,
+		"Y170000002":   1,
+		"Y-17E7":       2,
+		"Y17101E4S3":   2,
+		"Y170000002S3": 2,
+		"1950":         0,
+		"1950S2":       2,
+	}
+	parse := func(p *Parser, s string) error {
+		var err error
+		switch {
+		case strings.HasPrefix(s, "Y"):
+			_, err = p.ParseLongYear(s)
+		case s[0] == '[' || s[0] == '{':
+			_, err = p.ParseDateSet(s)
+		case len(s) == len("2001-21"):
+			_, err = p.ParseYearSeason(s)
+		case strings.Contains(s, "S") || s == "1950":
+			_, _, err = p.ParseSignificantYear(s)
+		default:
+			_, err = p.ParseYearRange(s)
+		}
+		return err
+	}
+	for level := -1; level <= 3; level++ {
+		p := NewParser(WithEDTF(level))
+		for s, min := range levels {
+			err := parse(p, s)
+			// Levels out of range are taken as the nearest one.
+			if accepted := level >= min || min == 0; accepted && err != nil {
+				t.Errorf(`WithEDTF(%d): %q -> %v (should be accepted)`, level, s, err)
+			} else if !accepted && !errors.Is(err, ErrUnsupported) {
+				t.Errorf(`WithEDTF(%d): %q -> %v (should wrap %v)`, level, s, err, ErrUnsupported)
+			}
+		}
+	}
+	for s := range levels {
+		if err := parse(NewParser(), s); (err == nil) != (levels[s] == 0) {
+			t.Errorf(`NewParser(): %q -> %v (should be accepted only at level 0)`, s, err)
+		}
+		if err := parse(edtfParser, s); err != nil {
+			t.Errorf(`package-level function: %q -> %v`, s, err)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"encoding"
+	"encoding/json"
+)
+
+// Text encoding
+//
+// Each value type implements encoding.TextMarshaler and encoding.TextUnmarshaler, which
+// is enough for it to work with encoding/json, encoding/xml, map keys, and most
+// configuration loaders.  Marshaling produces the type's String form; unmarshaling accepts
+// anything the corresponding Parser method accepts, with default options.
+
+var (
+	_ encoding.TextMarshaler   = Date{}
+	_ encoding.TextUnmarshaler = (*Date)(nil)
+	_ encoding.TextMarshaler   = YearMonth{}
+	_ encoding.TextUnmarshaler = (*YearMonth)(nil)
+	_ encoding.TextMarshaler   = TimeOfDay{}
+	_ encoding.TextUnmarshaler = (*TimeOfDay)(nil)
+	_ encoding.TextMarshaler   = DateTime{}
+	_ encoding.TextUnmarshaler = (*DateTime)(nil)
+	_ encoding.TextMarshaler   = Period{}
+	_ encoding.TextUnmarshaler = (*Period)(nil)
+	_ encoding.TextMarshaler   = Interval{}
+	_ encoding.TextUnmarshaler = (*Interval)(nil)
+)
+
+// MarshalText implements encoding.TextMarshaler.  The result is in YYYY-MM-DD format.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.  It accepts any ISO-8601 date
+// representation, as with Parser.ParseDate.
+func (d *Date) UnmarshalText(data []byte) error {
+	parsed, err := defaultParser.ParseDate(string(data))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.  The result is in YYYY-MM format.
+func (ym YearMonth) MarshalText() ([]byte, error) {
+	return []byte(ym.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.  It accepts only the YYYY-MM
+// format, since that is the only ISO-8601 representation of a calendar month.
+// (YYYYMM is disallowed by the standard.)
+func (ym *YearMonth) UnmarshalText(data []byte) error {
+	s := string(data)
+	if len(s) != 7 || s[4] != dateSep {
+		return &ParseError{s, "year and month must be in YYYY-MM format", -1, "", ErrSyntax}
+	}
+	d, err := defaultParser.ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*ym = d.YearMonth()
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.  The result is in HH:MM:SS[.fff] format.
+func (t TimeOfDay) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.  It accepts any ISO-8601 time
+// representation without a UTC offset; a string with an offset is an error, since a
+// TimeOfDay has no location in which to keep it.
+func (t *TimeOfDay) UnmarshalText(data []byte) error {
+	s := string(data)
+	parts, err := defaultParser.ParseTime(s)
+	if err != nil {
+		return err
+	}
+	if parts.HasOffset {
+		return &ParseError{s, "TimeOfDay cannot hold a UTC offset", -1, "offset", ErrUnexpectedOffset}
+	}
+	*t = parts.TimeOfDay
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.  The result is in
+// YYYY-MM-DDTHH:MM:SS[.fff] format.
+func (dt DateTime) MarshalText() ([]byte, error) {
+	return []byte(dt.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.  It accepts the same inputs
+// as Parser.ParseDateTime.
+func (dt *DateTime) UnmarshalText(data []byte) error {
+	parsed, err := defaultParser.ParseDateTime(string(data))
+	if err != nil {
+		return err
+	}
+	*dt = parsed
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.  The result is in ISO-8601 duration
+// format, such as P1Y2M10DT2H30M.
+func (p Period) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.  It accepts the same inputs as
+// ParseISODuration.
+func (p *Period) UnmarshalText(data []byte) error {
+	parsed, err := defaultParser.ParseDuration(string(data))
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.  The result is in <start>/<end>
+// format, with each instant formatted per time.RFC3339Nano.
+func (iv Interval) MarshalText() ([]byte, error) {
+	// Defer to time.Time for its range checking.
+	start, err := iv.Start.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	end, err := iv.End.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return append(append(start, '/'), end...), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.  It accepts the same inputs as
+// ParseISOInterval.  Instants with no UTC offset are taken to be in time.Local.
+func (iv *Interval) UnmarshalText(data []byte) error {
+	parsed, err := defaultParser.ParseInterval(string(data))
+	if err != nil {
+		return err
+	}
+	*iv = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, so that an API validating timestamp fields can
+// return the failure as it is.  The result is an object of the form
+//
+//	{"input": "2013-02-29", "pos": 8, "element": "day", "message": "day out of valid range", "kind": "range"}
+//
+// where "pos" is -1 and "element" is empty if unknown, and "kind" is the String of e.Kind.
+func (e *ParseError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Input   string `json:"input"`
+		Pos     int    `json:"pos"`
+		Element string `json:"element"`
+		Message string `json:"message"`
+		Kind    string `json:"kind"`
+	}{e.Datetime, e.Pos, e.Element, e.Message, e.Kind().String()})
+}
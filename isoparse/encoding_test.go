@@ -0,0 +1,115 @@
+package isoparse
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+type civilRecord struct {
+	Date     Date      `json:"date" xml:"date"`
+	Month    YearMonth `json:"month" xml:"month,attr"`
+	Time     TimeOfDay `json:"time" xml:"time"`
+	DateTime DateTime  `json:"datetime" xml:"datetime"`
+	Period   Period    `json:"period" xml:"period"`
+	Interval Interval  `json:"interval" xml:"interval"`
+}
+
+var sampleCivilRecord = civilRecord{
+	Date:     Date{2018, time.September, 27},
+	Month:    YearMonth{2018, time.September},
+	Time:     TimeOfDay{11, 52, 59, 500000000},
+	DateTime: DateTime{Date{2018, time.September, 27}, TimeOfDay{24, 0, 0, 0}},
+	Period:   Period{Years: 1, Days: 2, Hours: 3, Nanoseconds: 1000},
+	Interval: Interval{time.Date(2018, 9, 27, 0, 0, 0, 0, time.UTC), time.Date(2018, 9, 28, 0, 0, 0, 0, time.UTC)},
+}
+
+const sampleCivilJSON = `{"date":"2018-09-27","month":"2018-09","time":"11:52:59.5","datetime":"2018-09-27T24:00:00",` +
+	`"period":"P1Y2DT3H0.000001S","interval":"2018-09-27T00:00:00Z/2018-09-28T00:00:00Z"}`
+
+// Alternative ISO forms that should all unmarshal to sampleCivilRecord.
+const sampleCivilJSONAlt = `{"date":"2018-W39-4","month":"2018-09","time":"115259,5","datetime":"20180927T24",` +
+	`"period":"P1Y2DT3H0.000001S","interval":"2018-09-27T00:00:00Z/P1D"}`
+
+var invalidCivilJSON = []string{
+	`{"date":"2018-02-30"}`,
+	`{"month":"201809"}`,
+	`{"month":"2018-09-01"}`,
+	`{"time":"11:52:59Z"}`,
+	`{"time":"25:00"}`,
+	`{"datetime":"2018-09-27T11:52:59+01:00"}`,
+	`{"period":"1D"}`,
+	`{"interval":"2018-09-28/2018-09-27"}`,
+}
+
+func TestCivilJSON(t *testing.T) {
+	data, err := json.Marshal(sampleCivilRecord)
+	if err != nil {
+		t.Fatalf(`json.Marshal(%v) -> non-nil error (%v)`, sampleCivilRecord, err)
+	}
+	if string(data) != sampleCivilJSON {
+		t.Errorf(`json.Marshal(%v) -> %s (should be %s)`, sampleCivilRecord, data, sampleCivilJSON)
+	}
+	for _, s := range []string{sampleCivilJSON, sampleCivilJSONAlt} {
+		var got civilRecord
+		if err := json.Unmarshal([]byte(s), &got); err != nil {
+			t.Errorf(`json.Unmarshal(%s) -> non-nil error (%v)`, s, err)
+		} else if got.Date != sampleCivilRecord.Date || got.Month != sampleCivilRecord.Month ||
+			got.Time != sampleCivilRecord.Time || got.DateTime != sampleCivilRecord.DateTime ||
+			got.Period != sampleCivilRecord.Period || !got.Interval.Equal(sampleCivilRecord.Interval) {
+			t.Errorf(`json.Unmarshal(%s) -> %v (should be %v)`, s, got, sampleCivilRecord)
+		}
+	}
+	for _, s := range invalidCivilJSON {
+		var got civilRecord
+		if err := json.Unmarshal([]byte(s), &got); err == nil {
+			t.Errorf(`json.Unmarshal(%s) -> %v returned nil error (invalid value should error)`, s, got)
+		}
+	}
+}
+
+func TestCivilXML(t *testing.T) {
+	data, err := xml.Marshal(sampleCivilRecord)
+	if err != nil {
+		t.Fatalf(`xml.Marshal(%v) -> non-nil error (%v)`, sampleCivilRecord, err)
+	}
+	var got civilRecord
+	if err := xml.Unmarshal(data, &got); err != nil {
+		t.Errorf(`xml.Unmarshal(%s) -> non-nil error (%v)`, data, err)
+	} else if got.Date != sampleCivilRecord.Date || got.Month != sampleCivilRecord.Month || got.Period != sampleCivilRecord.Period {
+		t.Errorf(`xml.Unmarshal(%s) -> %v (should be %v)`, data, got, sampleCivilRecord)
+	}
+}
+
+func TestCivilMapKeys(t *testing.T) {
+	counts := map[Date]int{{2018, time.September, 27}: 1, {2018, time.September, 28}: 2}
+	data, err := json.Marshal(counts)
+	if err != nil {
+		t.Fatalf(`json.Marshal(%v) -> non-nil error (%v)`, counts, err)
+	}
+	var got map[Date]int
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf(`json.Unmarshal(%s) -> non-nil error (%v)`, data, err)
+	}
+	for d, n := range counts {
+		if got[d] != n {
+			t.Errorf(`json.Unmarshal(%s)[%v] -> %d (should be %d)`, data, d, got[d], n)
+		}
+	}
+}
+
+func TestParseErrorMarshalJSON(t *testing.T) {
+	_, err := ParseISODate("2013-02-29")
+	b, jsonErr := json.Marshal(map[string]error{"error": err})
+	want := `{"error":{"input":"2013-02-29","pos":8,"element":"day","message":"day out of valid range","kind":"range"}}`
+	if jsonErr != nil || string(b) != want {
+		t.Errorf(`json.Marshal -> %s, %v (should be %s)`, b, jsonErr, want)
+	}
+
+	b, jsonErr = json.Marshal(&ParseError{"x", "", -1, "", nil})
+	want = `{"input":"x","pos":-1,"element":"","message":"","kind":"syntax"}`
+	if jsonErr != nil || string(b) != want {
+		t.Errorf(`json.Marshal -> %s, %v (should be %s)`, b, jsonErr, want)
+	}
+}
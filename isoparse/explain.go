@@ -0,0 +1,199 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Explanation is the result of Explain: a report of how a datetime string was read, for
+// showing users why their input parsed the way it did.
+type Explanation struct {
+	Input     string
+	Time      time.Time // The result, as Parse returns it
+	Format    Format    // The grammar branch the string matched
+	Precision Precision
+
+	// Components lists the components of the result in order, from the year down to the
+	// second, followed by the fraction if one was written.  Those the string left out are
+	// marked as defaulted, with their values taken from Time.
+	Components []Component
+
+	// DateSeparator and TimeSeparator are "-" and ":" for the extended format and "" for
+	// the basic format.  DateTimeSeparator is the character between the date and the
+	// time, usually "T", and DecimalSign is "." or ",".  Each is "" if that portion of the
+	// string is absent.
+	DateSeparator     string
+	TimeSeparator     string
+	DateTimeSeparator string
+	DecimalSign       string
+
+	// OffsetText is the UTC offset as written, such as "Z" or "+05:30", or "" if there
+	// was none; Offset is its value in seconds east of UTC.
+	OffsetText string
+	Offset     int
+
+	// Zone is the time zone named by an RFC 9557 annotation, or "" if there was none.
+	Zone string
+
+	// AppliedOffset is the UTC offset of Time, in seconds east of UTC: the written offset,
+	// or the one that Time's location gave the naive reading.
+	AppliedOffset int
+}
+
+// Component is one component of a parsed datetime, as reported by Explain.
+type Component struct {
+	Name  string // "year", "month", "week", "weekday", "day", "hour", "minute", "second", or "fraction"
+	Value int    // For "fraction", in nanoseconds
+
+	// Text is the component as written, and Pos its index in the input.  A component
+	// that the string left out has Text "" and Pos -1.
+	Text string
+	Pos  int
+}
+
+// Defaulted reports whether the component was left out of the string and filled in.
+func (c Component) Defaulted() bool {
+	return c.Pos < 0
+}
+
+// Explain parses datetime as ParseISODatetime does and reports how it was read: which
+// grammar branch it matched, which components were written and which defaulted, the
+// separators it used, and the UTC offset that was applied.  If datetime isn't valid,
+// Explain returns the error that ParseISODatetime would.
+//
+// Strings that only an extension accepts, such as quarters or a profile's own syntax,
+// are reported with UnknownFormat and no Components.
+func Explain(datetime string) (Explanation, error) {
+	return defaultParser.Explain(datetime)
+}
+
+// Explain is like the package-level Explain, but parses with p, so that the defaulted
+// components reflect p's options, such as WithDefault and WithDateAnchor.
+func (p *Parser) Explain(datetime string) (Explanation, error) {
+	t, err := p.Parse(datetime)
+	if err != nil {
+		return Explanation{}, err
+	}
+	e := Explanation{Input: datetime, Time: t}
+	_, e.AppliedOffset = t.Zone()
+	rest, zone, _ := splitIXDTF(datetime)
+	e.Zone = zone
+	format, timeStart, err := detectFormat(rest)
+	if err != nil {
+		return e, nil
+	}
+	e.Format = format
+	e.Precision, _ = PrecisionOf(rest)
+	parts, _ := parseISODatetime(rest)
+	var date, clock []Component
+	for _, tok := range tokenize(rest, parts) {
+		switch tok.Kind {
+		case DateSeparatorToken:
+			e.DateSeparator = tok.Text
+		case DateTimeSeparatorToken:
+			e.DateTimeSeparator = tok.Text
+		case TimeSeparatorToken:
+			e.TimeSeparator = tok.Text
+		case DecimalSignToken:
+			e.DecimalSign = tok.Text
+		case OffsetToken:
+			e.OffsetText = tok.Text
+			_, e.Offset = writtenOffset(rest)
+		case FractionToken:
+			clock = append(clock, Component{tok.Kind.String(), fractionNanos(tok.Text), tok.Text, tok.Pos})
+		case YearToken, MonthToken, WeekToken, WeekdayToken, DayToken:
+			value, _ := parseDigits(tok.Text)
+			date = append(date, Component{tok.Kind.String(), value, tok.Text, tok.Pos})
+		case HourToken, MinuteToken, SecondToken:
+			value, _ := parseDigits(tok.Text)
+			clock = append(clock, Component{tok.Kind.String(), value, tok.Text, tok.Pos})
+		}
+	}
+	e.Components = append(date, defaultedDate(date, t)...)
+	precision := HourPrecision - 1
+	if timeStart >= 0 {
+		precision = timePrecision(rest[timeStart:])
+	}
+	// A time with a fraction has a second too, so nothing follows the fraction.
+	e.Components = append(e.Components, clock...)
+	e.Components = append(e.Components, defaultedTime(precision, t)...)
+	return e, nil
+}
+
+// defaultedDate returns the date components that a string left out, given the ones it
+// wrote, with their values from t.
+func defaultedDate(written []Component, t time.Time) []Component {
+	has := make(map[string]bool, len(written))
+	for _, c := range written {
+		has[c.Name] = true
+	}
+	switch {
+	case has["week"]:
+		if !has["weekday"] {
+			return []Component{defaulted("weekday", isoWeekday(t))}
+		}
+	case !has["day"] && !has["month"]:
+		return []Component{defaulted("month", int(t.Month())), defaulted("day", t.Day())}
+	case !has["day"]:
+		return []Component{defaulted("day", t.Day())}
+	}
+	return nil
+}
+
+// defaulted returns the component name, left out of the string, with the given value.
+func defaulted(name string, value int) Component {
+	return Component{name, value, "", -1}
+}
+
+// defaultedTime returns the time components finer than precision, which a string left
+// out, with their values from t.  A string with no time portion has precision just
+// coarser than HourPrecision.
+func defaultedTime(precision Precision, t time.Time) []Component {
+	var components []Component
+	hour, min, sec := t.Clock()
+	if precision < HourPrecision {
+		components = append(components, defaulted("hour", hour))
+	}
+	if precision < MinutePrecision {
+		components = append(components, defaulted("minute", min))
+	}
+	if precision < SecondPrecision {
+		components = append(components, defaulted("second", sec))
+	}
+	return components
+}
+
+// String returns the explanation as a multi-line report, one component to a line.
+func (e Explanation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%q is %v, with %v precision\n", e.Input, e.Format, e.Precision)
+	for _, c := range e.Components {
+		if c.Defaulted() {
+			fmt.Fprintf(&b, "  %-8s %d (defaulted)\n", c.Name, c.Value)
+		} else {
+			fmt.Fprintf(&b, "  %-8s %d (%q at %d)\n", c.Name, c.Value, c.Text, c.Pos)
+		}
+	}
+	for _, sep := range [...]struct{ name, text string }{
+		{"date separator", e.DateSeparator},
+		{"time separator", e.TimeSeparator},
+		{"date/time separator", e.DateTimeSeparator},
+		{"decimal sign", e.DecimalSign},
+	} {
+		if sep.text != "" {
+			fmt.Fprintf(&b, "  %s %q\n", sep.name, sep.text)
+		}
+	}
+	if e.OffsetText != "" {
+		fmt.Fprintf(&b, "  offset %q (%s)\n", e.OffsetText, formatOffset(e.Offset, ":"))
+	}
+	if e.Zone != "" {
+		fmt.Fprintf(&b, "  zone %s\n", e.Zone)
+	}
+	fmt.Fprintf(&b, "  applied offset %s, giving %v", formatOffset(e.AppliedOffset, ":"), e.Time.Format(time.RFC3339Nano))
+	return b.String()
+}
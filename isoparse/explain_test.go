@@ -0,0 +1,121 @@
+package isoparse
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var explanations = []struct {
+	s          string
+	format     Format
+	precision  Precision
+	components []Component
+	dateSep    string
+	timeSep    string
+	decimal    string
+	offsetText string
+	offset     int
+}{
+	{"2018-W39-4T05:30:15,25+05:30", WeekDateTimeExtended, SecondPrecision + 2, []Component{
+		{"year", 2018, "2018", 0},
+		{"week", 39, "39", 6},
+		{"weekday", 4, "4", 9},
+		{"hour", 5, "05", 11},
+		{"minute", 30, "30", 14},
+		{"second", 15, "15", 17},
+		{"fraction", 250000000, "25", 20},
+	}, "-", ":", ",", "+05:30", 5*60*60 + 30*60},
+	{"20180927T05Z", CalendarDateTimeBasic, HourPrecision, []Component{
+		{"year", 2018, "2018", 0},
+		{"month", 9, "09", 4},
+		{"day", 27, "27", 6},
+		{"hour", 5, "05", 9},
+		{"minute", 0, "", -1},
+		{"second", 0, "", -1},
+	}, "", "", "", "Z", 0},
+	{"2018-09", YearMonthDate, MonthPrecision, []Component{
+		{"year", 2018, "2018", 0},
+		{"month", 9, "09", 5},
+		{"day", 1, "", -1},
+		{"hour", 0, "", -1},
+		{"minute", 0, "", -1},
+		{"second", 0, "", -1},
+	}, "-", "", "", "", 0},
+	{"2018270T0500-0800", OrdinalDateTimeBasic, MinutePrecision, []Component{
+		{"year", 2018, "2018", 0},
+		{"day", 270, "270", 4},
+		{"hour", 5, "05", 8},
+		{"minute", 0, "00", 10},
+		{"second", 0, "", -1},
+	}, "", "", "", "-0800", -8 * 60 * 60},
+	{"2018W39", WeekDateBasic, WeekPrecision, []Component{
+		{"year", 2018, "2018", 0},
+		{"week", 39, "39", 5},
+		{"weekday", 1, "", -1},
+		{"hour", 0, "", -1},
+		{"minute", 0, "", -1},
+		{"second", 0, "", -1},
+	}, "", "", "", "", 0},
+}
+
+func TestExplain(t *testing.T) {
+	p := NewParser(WithLocation(time.UTC))
+	for _, c := range explanations {
+		e, err := p.Explain(c.s)
+		if err != nil {
+			t.Errorf(`Explain(%q) -> %v`, c.s, err)
+			continue
+		}
+		if want, _ := p.Parse(c.s); !e.Time.Equal(want) {
+			t.Errorf(`Explain(%q).Time -> %v (should be %v)`, c.s, e.Time, want)
+		}
+		if e.Format != c.format || e.Precision != c.precision {
+			t.Errorf(`Explain(%q) -> %v, %v (should be %v, %v)`, c.s, e.Format, e.Precision, c.format, c.precision)
+		}
+		if !reflect.DeepEqual(e.Components, c.components) {
+			t.Errorf(`Explain(%q).Components -> %v (should be %v)`, c.s, e.Components, c.components)
+		}
+		if e.DateSeparator != c.dateSep || e.TimeSeparator != c.timeSep || e.DecimalSign != c.decimal {
+			t.Errorf(`Explain(%q) separators -> %q, %q, %q (should be %q, %q, %q)`, c.s, e.DateSeparator, e.TimeSeparator, e.DecimalSign, c.dateSep, c.timeSep, c.decimal)
+		}
+		if e.OffsetText != c.offsetText || e.Offset != c.offset {
+			t.Errorf(`Explain(%q) offset -> %q, %v (should be %q, %v)`, c.s, e.OffsetText, e.Offset, c.offsetText, c.offset)
+		}
+	}
+}
+
+// Defaulted components come from the result, so they follow the Parser's options, and the
+// applied offset is the location's when none is written.
+func TestExplainOptions(t *testing.T) {
+	loc := loadLocation(t, "Europe/Paris")
+	p := NewParser(WithLocation(loc), WithDateAnchor(TimeOfDay{Hour: 12}))
+	e, err := p.Explain("2018-09-27")
+	if err != nil {
+		t.Fatalf(`Explain("2018-09-27") -> %v`, err)
+	}
+	if hour := e.Components[3]; hour.Name != "hour" || hour.Value != 12 || !hour.Defaulted() {
+		t.Errorf(`Explain("2018-09-27") hour -> %+v (should be 12, defaulted)`, hour)
+	}
+	if e.AppliedOffset != 2*60*60 || e.DateTimeSeparator != "" {
+		t.Errorf(`Explain("2018-09-27") -> applied offset %v, date/time separator %q`, e.AppliedOffset, e.DateTimeSeparator)
+	}
+
+	e, err = Explain("2018-09-27T05:00:00Z[Europe/Paris]")
+	if err != nil || e.Zone != "Europe/Paris" || e.AppliedOffset != 2*60*60 || e.OffsetText != "Z" {
+		t.Errorf(`Explain("2018-09-27T05:00:00Z[Europe/Paris]") -> (%+v, %v)`, e, err)
+	}
+	if s := e.String(); !strings.Contains(s, "zone Europe/Paris") || !strings.Contains(s, `second   0 ("00" at 17)`) {
+		t.Errorf(`Explain("2018-09-27T05:00:00Z[Europe/Paris]").String() -> %s`, s)
+	}
+
+	e, err = NewParser(WithQuarters()).Explain("2018-Q3")
+	if err != nil || e.Format != UnknownFormat || e.Components != nil {
+		t.Errorf(`Explain("2018-Q3") with quarters -> (%+v, %v) (should have no components)`, e, err)
+	}
+	if _, err := Explain("2018-02-30"); !errors.Is(err, ErrInvalidDay) {
+		t.Errorf(`Explain("2018-02-30") -> %v (should wrap %v)`, err, ErrInvalidDay)
+	}
+}
@@ -0,0 +1,43 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import "time"
+
+// WithFallback adds a parse function that Parse tries when a string isn't ISO-8601, such
+// as a project's legacy format, so that application code has a single entry point:
+//
+//	p := isoparse.NewParser(
+//		isoparse.WithFallback(func(s string) (time.Time, error) {
+//			return time.Parse("02/01/2006 15:04", s)
+//		}),
+//	)
+//
+// The precedence is fixed: ISO-8601 first, as configured by the other options, then each
+// fallback in the order it was added, and the first to succeed gives the result.  If all
+// of them fail, Parse returns the ISO-8601 error, not a fallback's, since that is the
+// format the caller asked for.  Fallbacks apply to Parse and the functions built on it,
+// such as ParseInterval and ParseAll, and their results are cached like any other with
+// WithCache.  A nil function is ignored.
+func WithFallback(parse func(s string) (time.Time, error)) Option {
+	return func(p *Parser) {
+		if parse != nil {
+			p.fallbacks = append(p.fallbacks, parse)
+		}
+	}
+}
+
+// parseWithFallbacks is parse, followed by p's fallbacks in order if it fails.
+func (p *Parser) parseWithFallbacks(datetime string) (time.Time, error) {
+	t, err := p.parse(datetime)
+	if err == nil {
+		return t, nil
+	}
+	for _, parse := range p.fallbacks {
+		if ft, ferr := parse(datetime); ferr == nil {
+			return ft, nil
+		}
+	}
+	return t, err
+}
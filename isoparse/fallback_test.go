@@ -0,0 +1,48 @@
+package isoparse
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithFallback(t *testing.T) {
+	var calls []string
+	legacy := func(layout string) func(string) (time.Time, error) {
+		return func(s string) (time.Time, error) {
+			calls = append(calls, layout)
+			return time.Parse(layout, s)
+		}
+	}
+	p := NewParser(
+		WithLocation(time.UTC),
+		WithFallback(legacy("02/01/2006 15:04")),
+		WithFallback(nil),
+		WithFallback(legacy("02/01/2006")),
+	)
+	for _, c := range []struct {
+		s     string
+		want  time.Time
+		calls int
+	}{
+		{"2018-09-27T05:00", time.Date(2018, 9, 27, 5, 0, 0, 0, time.UTC), 0},
+		{"27/09/2018 05:00", time.Date(2018, 9, 27, 5, 0, 0, 0, time.UTC), 1},
+		{"27/09/2018", time.Date(2018, 9, 27, 0, 0, 0, 0, time.UTC), 2},
+	} {
+		calls = nil
+		if got, err := p.Parse(c.s); err != nil || !got.Equal(c.want) || len(calls) != c.calls {
+			t.Errorf(`Parse(%q) with fallbacks -> (%v, %v) after %v (should be %v)`, c.s, got, err, calls, c.want)
+		}
+	}
+
+	// When everything fails, the error is the ISO-8601 one.
+	if _, err := p.Parse("2018-02-30"); !errors.Is(err, ErrInvalidDay) {
+		t.Errorf(`Parse("2018-02-30") with fallbacks -> %v (should wrap %v)`, err, ErrInvalidDay)
+	}
+	if ts, err := p.ParseAll([]string{"2018-09-27", "28/09/2018"}); err != nil || len(ts) != 2 || !ts[1].Equal(time.Date(2018, 9, 28, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf(`ParseAll with fallbacks -> (%v, %v)`, ts, err)
+	}
+	if _, err := ParseISODatetime("27/09/2018"); err == nil {
+		t.Errorf(`ParseISODatetime("27/09/2018") returned nil error`)
+	}
+}
@@ -0,0 +1,94 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"strings"
+	"time"
+)
+
+// A Match is a datetime found in text by FindAll.
+type Match struct {
+	Start, End int // The datetime is text[Start:End].
+	Time       time.Time
+}
+
+// FindAll returns every ISO-8601 datetime in text, such as a log line, an HTML page, or an
+// email, in order, as parsed by ParseISODatetime.  It takes the longest datetime at each
+// position, as ParsePrefix does, so the trailing period of a sentence isn't mistaken for
+// the start of a fraction.
+//
+// Only strings that are clearly datetimes are matched: dates and times in either format,
+// and extended calendar and week dates on their own, such as "2018-09-27" and "2018-W39".
+// Bare numbers such as "2018" or "20180927" are passed over, since prose is full of them.
+// A match must also stand alone, neither preceded nor followed by a letter or digit.
+func FindAll(text string) []Match {
+	return defaultParser.FindAll(text)
+}
+
+// FindAll is like the package-level FindAll, but parses with p.
+func (p *Parser) FindAll(text string) []Match {
+	var matches []Match
+	for m, ok := p.find(text, 0, len(text)); ok; m, ok = p.find(text, m.End, len(text)) {
+		p.observe(text[m.Start:m.End], nil)
+		matches = append(matches, m)
+	}
+	return matches
+}
+
+// find returns the first datetime in text, as FindAll would find it, that starts at or
+// after from and before limit.
+func (p *Parser) find(text string, from, limit int) (Match, bool) {
+	for i := from; i < limit && i+len("YYYY") <= len(text); i++ {
+		if (i > 0 && isWordByte(text[i-1])) || !isDigit(text[i]) {
+			continue
+		}
+		t, n := p.longestPrefix(text[i:])
+		if n == 0 || (i+n < len(text) && isWordByte(text[i+n])) || !findable(text[i:i+n]) {
+			continue
+		}
+		return Match{i, i + n, t}, true
+	}
+	return Match{}, false
+}
+
+// findable reports whether s, a valid datetime, is distinctive enough for FindAll.
+func findable(s string) bool {
+	rest, _, _ := splitIXDTF(s)
+	format, _ := DetectFormat(rest)
+	switch format {
+	case CalendarDateExtended, WeekDateExtended:
+		return true
+	}
+	return format.Kind() == DateTimeValue
+}
+
+// isWordByte reports whether c is an ASCII letter or digit.
+func isWordByte(c byte) bool {
+	return isDigit(c) || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// MatchLen returns the length of the longest ISO-8601 datetime, as accepted by
+// ParseISODatetime, that starts at s[pos], or 0 if there is none.  It is for other
+// scanners, such as the lexer of a query language or configuration format, that want to
+// accept datetime literals: the lexer asks MatchLen at the start of a token and takes that
+// many bytes as the literal.
+//
+// MatchLen doesn't allocate.  Unlike ParsePrefix, it stops short of any RFC 9557
+// annotation, whose time zone could only be checked by loading it.  It also matches bare
+// numbers such as "2018", which are valid dates; a lexer that wants FindAll's stricter
+// rules can check the literal with DetectFormat.
+func MatchLen(s string, pos int) int {
+	s = s[pos:]
+	end := prefixCandidate(s)
+	if i := strings.IndexByte(s[:end], '['); i >= 0 {
+		end = i
+	}
+	for n := end; n >= len("YYYY"); n-- {
+		if ValidDatetime(s[:n]) {
+			return n
+		}
+	}
+	return 0
+}
@@ -0,0 +1,72 @@
+package isoparse
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFindAll(t *testing.T) {
+	text := "Deployed 2024-01-02T03:04:05Z (build 20240102) and rolled back at " +
+		"2024-01-02 03:30:00+01:00; the next window is 2024-W02, from 2024-01-08.\n" +
+		"<time datetime=\"2024-01-09T10:00:00+09:00[Asia/Tokyo]\">later</time> " +
+		"Ignored: 2018 apples, v2024-01-02, 2024-01-02x, 1234567, 2024-13-01T00:00Z."
+	want := []string{
+		"2024-01-02T03:04:05Z",
+		"2024-01-02 03:30:00+01:00",
+		"2024-W02",
+		"2024-01-08",
+		"2024-01-09T10:00:00+09:00[Asia/Tokyo]",
+	}
+	p := NewParser(WithLocation(time.UTC))
+	matches := p.FindAll(text)
+	var got []string
+	for _, m := range matches {
+		got = append(got, text[m.Start:m.End])
+		if parsed, err := p.Parse(text[m.Start:m.End]); err != nil || !parsed.Equal(m.Time) {
+			t.Errorf(`FindAll match %q -> %v (should be %v)`, text[m.Start:m.End], m.Time, parsed)
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf(`FindAll(%q) -> %q (should be %q)`, text, got, want)
+	}
+}
+
+func TestFindAllEmpty(t *testing.T) {
+	for _, text := range []string{"", "no dates here", "2018", "1111111111111111111111111111111111111111111111111111111111111111111111"} {
+		if got := FindAll(text); got != nil {
+			t.Errorf(`FindAll(%q) -> %v (should be nil)`, text, got)
+		}
+	}
+}
+
+func TestMatchLen(t *testing.T) {
+	for _, c := range []struct {
+		s         string
+		pos, want int
+	}{
+		{"2018-09-27T05:00:00Z", 0, 20},
+		{"since:2018-09-27 and more", 6, 10},
+		{"at 2018-09-27 05:00:00.5+01:00, then", 3, 27},
+		{"2018-09-27T05:00:00+01:00[Europe/London]", 0, 25},
+		{"2018-09-27T05:00.", 0, 16},
+		{"2018-02-30", 0, 7},
+		{"20180927T0500Z)", 0, 14},
+		{"2018 apples", 0, 4},
+		{"v2018", 0, 0},
+		{"201", 0, 0},
+		{"x", 1, 0},
+	} {
+		if got := MatchLen(c.s, c.pos); got != c.want {
+			t.Errorf(`MatchLen(%q, %d) -> %d (should be %d)`, c.s, c.pos, got, c.want)
+		}
+	}
+}
+
+func TestMatchLenAllocs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping allocation counts in short mode")
+	}
+	s := "created > 2018-09-27T05:00:00.123+05:30 and status = 'done'"
+	assertMaxAllocs(t, "MatchLen", 0, func() { MatchLen(s, 10) })
+}
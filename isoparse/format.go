@@ -0,0 +1,164 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"fmt"
+	"time"
+)
+
+// Formatting
+//
+// Go's time.Time.Format covers most ISO-8601 representations given the right layout, but
+// the layouts are easy to get subtly wrong, and week and ordinal dates have no layout at all.
+// FormatISO names the common representations instead.
+
+// Layouts used by FormatISO.  Fractional seconds are only written if nonzero.
+const (
+	layoutDatetime      = "2006-01-02T15:04:05.999999999Z07:00"
+	layoutDatetimeBasic = "20060102T150405.999999999Z0700"
+	layoutDate          = "2006-01-02"
+	layoutDateBasic     = "20060102"
+	layoutMonth         = "2006-01"
+	layoutTime          = "15:04:05.999999999Z07:00"
+	layoutICalUTC       = "20060102T150405Z"
+	layoutICalFloating  = "20060102T150405"
+)
+
+// FormatISO formats t in one of the following ISO-8601 representations, selected by style:
+//
+//	"datetime"  2018-09-27T11:52:59.5-05:00  (the default for an empty style; also valid RFC 3339)
+//	"utc"       2018-09-27T16:52:59.5Z       (t converted to UTC first)
+//	"basic"     20180927T115259.5-0500
+//	"date"      2018-09-27
+//	"datebasic" 20180927
+//	"month"     2018-09
+//	"week"      2018-W39-4
+//	"ordinal"   2018-270
+//	"time"      11:52:59.5-05:00
+//
+// and in the iCalendar (RFC 5545) forms that the ICalendar profile accepts, which have no
+// fractional seconds, so that t is truncated to the second:
+//
+//	"icaldate"      20180927          (a DATE)
+//	"icalutc"       20180927T165259Z  (a DATE-TIME in UTC, t converted to UTC first)
+//	"icalfloating"  20180927T115259   (a floating DATE-TIME, or one for a TZID parameter)
+//
+// A floating DATE-TIME is t's wall-clock reading.  To write a local time with a TZID,
+// name t.Location() in the parameter and format the value as "icalfloating".
+//
+// Otherwise fractional seconds are written only when nonzero, with trailing zeros removed.
+// A time in the UnknownOffset location is written with "-00:00" (or "-0000") in place of "Z".
+// An unknown style is an error.
+func FormatISO(t time.Time, style string) (string, error) {
+	switch style {
+	case "", "datetime":
+		return formatUnknownOffset(t, layoutDatetime, "-00:00"), nil
+	case "utc":
+		return t.UTC().Format(layoutDatetime), nil
+	case "basic":
+		return formatUnknownOffset(t, layoutDatetimeBasic, "-0000"), nil
+	case "date":
+		return t.Format(layoutDate), nil
+	case "datebasic":
+		return t.Format(layoutDateBasic), nil
+	case "month":
+		return t.Format(layoutMonth), nil
+	case "week":
+		cal := isoCalendar(t)
+		return fmt.Sprintf("%04d-W%02d-%d", cal[0], cal[1], cal[2]), nil
+	case "ordinal":
+		return fmt.Sprintf("%04d-%03d", t.Year(), t.YearDay()), nil
+	case "time":
+		return formatUnknownOffset(t, layoutTime, "-00:00"), nil
+	case "icaldate":
+		return t.Format(layoutDateBasic), nil
+	case "icalutc":
+		return t.UTC().Format(layoutICalUTC), nil
+	case "icalfloating":
+		return t.Format(layoutICalFloating), nil
+	}
+	return "", fmt.Errorf("isoparse: unknown format style %q", style)
+}
+
+// formatUnknownOffset formats t with layout, which must end in a "Z07" style zone, writing
+// zero instead of "Z" if t is in the UnknownOffset location.
+func formatUnknownOffset(t time.Time, layout, zero string) string {
+	s := t.Format(layout)
+	if t.Location() == UnknownOffset {
+		s = s[:len(s)-1] + zero
+	}
+	return s
+}
+
+// Canonicalize parses an ISO-8601 datetime in any form accepted by ParseISODatetime and
+// rewrites it in the "datetime" style of FormatISO, e.g. "1985W155T1015+0400" becomes
+// "1985-04-12T10:15:00+04:00".  Strings with no UTC offset stay that way, rather than
+// acquiring the offset of time.Local.  RFC 9557 annotations are checked as
+// ParseISODatetime checks them and kept as written, so
+// "20220708T001407+0200[Europe/Paris]" becomes "2022-07-08T00:14:07+02:00[Europe/Paris]".
+func Canonicalize(datetime string) (string, error) {
+	rest, zone, err := splitIXDTF(datetime)
+	if err != nil {
+		return "", err
+	}
+	parts, err := parseISODatetime(rest)
+	if err != nil {
+		err.(*ParseError).Datetime = datetime
+		return "", err
+	}
+	// Resolve with UTC standing in for "no offset", then leave the Z off again.
+	t, err := utcParser.resolveDatetime(datetime, rest, zone, parts)
+	if err != nil {
+		return "", err
+	}
+	layout := layoutDatetime
+	if !parts.hasOffset {
+		layout = "2006-01-02T15:04:05.999999999"
+	}
+	return t.Format(layout) + datetime[len(rest):], nil
+}
+
+// FormatOffset formats a UTC offset, given in seconds east of UTC, in one of the following
+// styles, as the counterpart of ParseISOOffset:
+//
+//	"extended"  +05:30  (a zero offset is +00:00)
+//	"basic"     +0530
+//	"z"         +05:30, or Z for a zero offset  (the default for an empty style)
+//
+// An offset with nonzero seconds, which only occurs in historical local mean time, is
+// written with them as well (+00:19:32, or +001932 in the basic style).
+// To format the offset of a time, or of a location at some instant, use time.Time.Zone:
+//
+//	_, offset := t.In(loc).Zone()
+//	s, _ := isoparse.FormatOffset(offset, "extended")
+//
+// An unknown style is an error.
+func FormatOffset(secondsEast int, style string) (string, error) {
+	switch style {
+	case "", "z":
+		if secondsEast == 0 {
+			return "Z", nil
+		}
+		return formatOffset(secondsEast, ":"), nil
+	case "extended":
+		return formatOffset(secondsEast, ":"), nil
+	case "basic":
+		return formatOffset(secondsEast, ""), nil
+	}
+	return "", fmt.Errorf("isoparse: unknown offset style %q", style)
+}
+
+// formatOffset writes ±hh<sep>mm[<sep>ss].
+func formatOffset(secondsEast int, sep string) string {
+	sign := '+'
+	if secondsEast < 0 {
+		sign, secondsEast = '-', -secondsEast
+	}
+	s := fmt.Sprintf("%c%02d%s%02d", sign, secondsEast/3600, sep, secondsEast/60%60)
+	if secondsEast%60 != 0 {
+		s += fmt.Sprintf("%s%02d", sep, secondsEast%60)
+	}
+	return s
+}
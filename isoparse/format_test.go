@@ -0,0 +1,114 @@
+package isoparse
+
+import (
+	"testing"
+	"time"
+)
+
+var formatTime = time.Date(2018, 9, 27, 11, 52, 59, 5e8, offsetZone(-5*60*60))
+
+var formatStyles = map[string]string{
+	"":          "2018-09-27T11:52:59.5-05:00",
+	"datetime":  "2018-09-27T11:52:59.5-05:00",
+	"utc":       "2018-09-27T16:52:59.5Z",
+	"basic":     "20180927T115259.5-0500",
+	"date":      "2018-09-27",
+	"datebasic": "20180927",
+	"month":     "2018-09",
+	"week":      "2018-W39-4",
+	"ordinal":   "2018-270",
+	"time":      "11:52:59.5-05:00",
+
+	"icaldate":     "20180927",
+	"icalutc":      "20180927T165259Z",
+	"icalfloating": "20180927T115259",
+}
+
+var canonicalForms = map[string]string{
+	"1985W155T1015+0400":        "1985-04-12T10:15:00+04:00",
+	"19850412T101530Z":          "1985-04-12T10:15:30Z",
+	"1985-102T10:15:30.25-05":   "1985-04-12T10:15:30.25-05:00",
+	"1985-04-12 10:15":          "1985-04-12T10:15:00",
+	"1985":                      "1985-01-01T00:00:00",
+	"2014-04-10T24:00:00+00:00": "2014-04-11T00:00:00Z",
+
+	"2022-07-08T00:14:07+02:00[Europe/Paris]":       "2022-07-08T00:14:07+02:00[Europe/Paris]",
+	"20220708T001407.5[Europe/Paris][u-ca=iso8601]": "2022-07-08T00:14:07.5[Europe/Paris][u-ca=iso8601]",
+	"2022-07-08T00:14:07Z[!u-ca=iso8601]":           "2022-07-08T00:14:07Z[!u-ca=iso8601]",
+}
+
+func TestFormatISO(t *testing.T) {
+	for style, want := range formatStyles {
+		if got, err := FormatISO(formatTime, style); err != nil {
+			t.Errorf(`FormatISO(%v, %q) -> non-nil error (%v)`, formatTime, style, err)
+		} else if got != want {
+			t.Errorf(`FormatISO(%v, %q) -> %q (should be %q)`, formatTime, style, got, want)
+		}
+	}
+	// Every style except "utc" should parse back to the same wall clock (or the same date).
+	for style, s := range formatStyles {
+		if style == "time" || style == "month" || style == "utc" || style == "icalutc" {
+			continue
+		}
+		if tm, err := ParseISODatetime(s); err != nil {
+			t.Errorf(`ParseISODatetime(%q) -> non-nil error (%v) for FormatISO(%q) output`, s, err, style)
+		} else if DateOf(tm) != DateOf(formatTime) {
+			t.Errorf(`ParseISODatetime(%q) -> %v (should fall on %v)`, s, tm, DateOf(formatTime))
+		}
+	}
+	if s, err := FormatISO(formatTime, "rfc822"); err == nil {
+		t.Errorf(`FormatISO(%v, "rfc822") -> %q returned nil error (unknown style should error)`, formatTime, s)
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	for s, want := range canonicalForms {
+		if got, err := Canonicalize(s); err != nil {
+			t.Errorf(`Canonicalize(%q) -> non-nil error (%v)`, s, err)
+		} else if got != want {
+			t.Errorf(`Canonicalize(%q) -> %q (should be %q)`, s, got, want)
+		}
+	}
+	for _, s := range []string{"2014-04-10T25:00", "2022-07-08T00:14:07+01:00[Europe/Paris]", "2022-07-08T00:14:07Z[!u-ca=hebrew]"} {
+		if got, err := Canonicalize(s); err == nil {
+			t.Errorf(`Canonicalize(%q) -> %q returned nil error`, s, got)
+		}
+	}
+}
+
+var formattedOffsets = []struct {
+	secondsEast int
+	style       string
+	want        string
+}{
+	{0, "", "Z"},
+	{0, "z", "Z"},
+	{0, "extended", "+00:00"},
+	{0, "basic", "+0000"},
+	{5*60*60 + 30*60, "", "+05:30"},
+	{5*60*60 + 30*60, "basic", "+0530"},
+	{-(3*60*60 + 30*60), "extended", "-03:30"},
+	{-8 * 60 * 60, "basic", "-0800"},
+	{19*60 + 32, "extended", "+00:19:32"},
+	{-(19*60 + 32), "basic", "-001932"},
+}
+
+func TestFormatOffset(t *testing.T) {
+	for _, c := range formattedOffsets {
+		got, err := FormatOffset(c.secondsEast, c.style)
+		if err != nil || got != c.want {
+			t.Errorf(`FormatOffset(%d, %q) -> %q, %v (should be %q)`, c.secondsEast, c.style, got, err, c.want)
+		}
+		if c.secondsEast%60 != 0 {
+			continue
+		}
+		// Round trip through ParseISOOffset.
+		loc, err := ParseISOOffset(got)
+		if _, offset := time.Date(2018, 9, 27, 0, 0, 0, 0, loc).Zone(); err != nil || offset != c.secondsEast {
+			t.Errorf(`ParseISOOffset(%q) -> offset %d, %v (should be %d)`, got, offset, err, c.secondsEast)
+		}
+	}
+	if _, err := FormatOffset(0, "short"); err == nil {
+		t.Errorf(`FormatOffset(0, "short") returned nil error`)
+	}
+}
@@ -0,0 +1,136 @@
+package isoparse
+
+import (
+	"testing"
+)
+
+// Inputs that used to panic by slicing past the end of the string.
+var truncatedInputs = []string{
+	"2018-",
+	"2018-W",
+	"2018W1",
+	"2018-W01-",
+	"2018-09-27T1",
+	"2018-09-27T12:3",
+	"2018-09-27T123",
+	"2018-09-27TT1230",
+}
+
+func TestTruncatedInputs(t *testing.T) {
+	for _, s := range truncatedInputs {
+		if _, err := ParseISODatetime(s); err == nil {
+			t.Errorf(`ParseISODatetime(%q) returned nil error`, s)
+		}
+	}
+}
+
+// The fuzz targets check that no input panics, and that successful parses are
+// self-consistent.  Run one with, e.g.:
+//
+//	go test -run '^$' -fuzz FuzzParseISODatetime
+
+func FuzzParseISODatetime(f *testing.F) {
+	for s := range allFormats {
+		f.Add(s)
+	}
+	for _, s := range invalidDatetimes {
+		f.Add(s)
+	}
+	for s := range uncommonDates {
+		f.Add(s)
+	}
+	for _, s := range truncatedInputs {
+		f.Add(s)
+	}
+	f.Add("")
+	f.Add("2018-09-27T05:00:00.123456789+05:30")
+	f.Fuzz(func(t *testing.T, s string) {
+		got, err := ParseISODatetime(s)
+		if err != nil {
+			return
+		}
+		if y := got.Year(); y < minYear-1 || y > maxYear+1 {
+			t.Errorf(`ParseISODatetime(%q) -> %v (year out of range)`, s, got)
+		}
+	})
+}
+
+func FuzzParseISOTime(f *testing.F) {
+	for s := range timesWithComponents {
+		f.Add(s)
+	}
+	for _, s := range invalidTimes {
+		f.Add(s)
+	}
+	for s := range tzStrings {
+		f.Add("12:30" + s)
+	}
+	f.Add("")
+	f.Add("T1230")
+	f.Fuzz(func(t *testing.T, s string) {
+		parts, err := ParseISOTimeParts(s)
+		if err != nil {
+			return
+		}
+		if !parts.IsValid() {
+			t.Errorf(`ParseISOTimeParts(%q) -> %v (not a valid time of day)`, s, parts.TimeOfDay)
+		}
+		if parts.Loc == nil {
+			t.Errorf(`ParseISOTimeParts(%q) -> nil location`, s)
+		}
+	})
+}
+
+func FuzzParseISODuration(f *testing.F) {
+	for s := range isoDurations {
+		f.Add(s)
+	}
+	for _, s := range invalidDurations {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		p, err := ParseISODuration(s)
+		if err != nil {
+			return
+		}
+		// The canonical form must parse back to an equal period.
+		again, err := ParseISODuration(p.String())
+		if err != nil || !again.Equal(p) {
+			t.Errorf(`ParseISODuration(%q) -> %v, which reparses as %v, %v`, s, p, again, err)
+		}
+	})
+}
+
+func FuzzTokenize(f *testing.F) {
+	for s := range tokenizations {
+		f.Add(s)
+	}
+	for s := range allFormats {
+		f.Add(s)
+	}
+	for _, s := range truncatedInputs {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		if tokens, err := Tokenize(s); err == nil {
+			checkTokensCover(t, s, tokens)
+		}
+	})
+}
+
+func FuzzLayoutOf(f *testing.F) {
+	for s := range layouts {
+		f.Add(s)
+	}
+	for s := range layoutErrors {
+		f.Add(s)
+	}
+	for s := range allFormats {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		if layout, err := LayoutOf(s); err == nil && layout == "" {
+			t.Errorf(`LayoutOf(%q) -> "" with nil error`, s)
+		}
+	})
+}
@@ -0,0 +1,162 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+// Package gen generates random, valid ISO-8601 strings together with the time.Time that
+// a conforming parser should return for each, for use in property-based tests of code
+// built on the isoparse package.
+//
+// Typical use:
+//
+//	g := gen.New(seed, gen.Options{Kind: gen.Week, Time: true, Offset: true})
+//	for i := 0; i < 1000; i++ {
+//		s, want := g.Next()
+//		got, err := isoparse.ParseISODatetime(s)
+//		if err != nil || !got.Equal(want) {
+//			t.Errorf("ParseISODatetime(%q) -> %v, %v (should be %v)", s, got, err, want)
+//		}
+//	}
+//
+// Compare results with time.Time.Equal: the expected times carry unnamed fixed zones.
+//
+// This package deliberately does not import isoparse, so that it can be used to test
+// isoparse itself without an import cycle.
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// Kind selects the form of the date portion.
+type Kind int
+
+const (
+	Calendar Kind = iota // YYYY-MM-DD
+	Week                 // YYYY-Www-D
+	Ordinal              // YYYY-DDD
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Calendar:
+		return "Calendar"
+	case Week:
+		return "Week"
+	case Ordinal:
+		return "Ordinal"
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// Options controls the strings a Generator produces.
+type Options struct {
+	Kind Kind
+
+	// Basic selects the basic format, with no "-" or ":" separators.
+	Basic bool
+
+	// Time appends a time of day, hh:mm:ss, after a "T".
+	Time bool
+
+	// Fraction adds 1 to 9 digits of fractional seconds, with either "." or ","
+	// as the decimal sign.  It implies Time.
+	Fraction bool
+
+	// Offset adds a UTC offset: "Z", ±hh, or ±hh:mm, in whole quarter hours up to 14 hours.
+	// It implies Time.
+	Offset bool
+
+	// Location is the location expected for strings without an offset.
+	// The default, nil, means time.Local, as with isoparse.ParseISODatetime.
+	Location *time.Location
+}
+
+// A Generator produces random ISO-8601 strings.  It is not safe for concurrent use.
+type Generator struct {
+	rng  *rand.Rand
+	opts Options
+}
+
+// New returns a Generator seeded with seed, so that a failing case can be reproduced.
+func New(seed int64, opts Options) *Generator {
+	if opts.Fraction || opts.Offset {
+		opts.Time = true
+	}
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+	return &Generator{rand.New(rand.NewSource(seed)), opts}
+}
+
+var (
+	minDate = time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC)
+	maxDate = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+	maxDays = int(maxDate.Sub(minDate).Hours() / 24)
+)
+
+// Next returns a random string and the time it represents.
+func (g *Generator) Next() (s string, want time.Time) {
+	date := minDate.AddDate(0, 0, g.rng.Intn(maxDays+1))
+	dateSep, timeSep := "-", ":"
+	if g.opts.Basic {
+		dateSep, timeSep = "", ""
+	}
+
+	var b strings.Builder
+	switch g.opts.Kind {
+	case Week:
+		year, week := date.ISOWeek()
+		weekday := (int(date.Weekday())+6)%7 + 1
+		fmt.Fprintf(&b, "%04d%sW%02d%s%d", year, dateSep, week, dateSep, weekday)
+	case Ordinal:
+		fmt.Fprintf(&b, "%04d%s%03d", date.Year(), dateSep, date.YearDay())
+	default:
+		fmt.Fprintf(&b, "%04d%s%02d%s%02d", date.Year(), dateSep, int(date.Month()), dateSep, date.Day())
+	}
+	if !g.opts.Time {
+		return b.String(), time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, g.opts.Location)
+	}
+
+	hour, min, sec, nsec := g.rng.Intn(24), g.rng.Intn(60), g.rng.Intn(60), 0
+	fmt.Fprintf(&b, "T%02d%s%02d%s%02d", hour, timeSep, min, timeSep, sec)
+	if g.opts.Fraction {
+		digits := 1 + g.rng.Intn(9)
+		frac := g.rng.Intn(pow10(digits))
+		nsec = frac * pow10(9-digits)
+		fmt.Fprintf(&b, "%c%0*d", ".,"[g.rng.Intn(2)], digits, frac)
+	}
+	loc := g.opts.Location
+	if g.opts.Offset {
+		loc = g.offset(&b, timeSep)
+	}
+	return b.String(), time.Date(date.Year(), date.Month(), date.Day(), hour, min, sec, nsec, loc)
+}
+
+// offset writes a random UTC offset to b and returns its location.
+func (g *Generator) offset(b *strings.Builder, timeSep string) *time.Location {
+	quarters := g.rng.Intn(14*4 + 1)
+	hours, mins := quarters/4, quarters%4*15
+	sign, mult := "+", 1
+	if g.rng.Intn(2) == 0 {
+		sign, mult = "-", -1
+	}
+	switch {
+	case quarters == 0 && g.rng.Intn(2) == 0:
+		b.WriteString("Z")
+	case mins == 0 && g.rng.Intn(2) == 0:
+		fmt.Fprintf(b, "%s%02d", sign, hours)
+	default:
+		fmt.Fprintf(b, "%s%02d%s%02d", sign, hours, timeSep, mins)
+	}
+	return time.FixedZone("", mult*(hours*60+mins)*60)
+}
+
+func pow10(n int) int {
+	p := 1
+	for ; n > 0; n-- {
+		p *= 10
+	}
+	return p
+}
@@ -0,0 +1,63 @@
+package gen
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+const iterations = 2000
+
+// Extended calendar strings with a full time and offset are also RFC 3339, which the time
+// package can check independently.
+func TestCalendarRFC3339(t *testing.T) {
+	g := New(1, Options{Kind: Calendar, Offset: true})
+	for i := 0; i < iterations; i++ {
+		s, want := g.Next()
+		if strings.HasSuffix(s, "Z") || len(s) == len("2006-01-02T15:04:05-07:00") {
+			got, err := time.Parse(time.RFC3339, s)
+			if err != nil || !got.Equal(want) {
+				t.Errorf(`time.Parse(RFC3339, %q) -> %v, %v (should be %v)`, s, got, err, want)
+			}
+		}
+	}
+}
+
+func TestWeekAndOrdinal(t *testing.T) {
+	week := New(2, Options{Kind: Week, Basic: true})
+	ordinal := New(3, Options{Kind: Ordinal, Location: time.UTC})
+	for i := 0; i < iterations; i++ {
+		s, want := week.Next()
+		year, wk := want.ISOWeek()
+		if weekday := (int(want.Weekday())+6)%7 + 1; s != fmt.Sprintf("%04dW%02d%d", year, wk, weekday) {
+			t.Errorf(`week date %q does not match %v`, s, want)
+		}
+		s, want = ordinal.Next()
+		if s != fmt.Sprintf("%04d-%03d", want.Year(), want.YearDay()) || want.Location() != time.UTC {
+			t.Errorf(`ordinal date %q does not match %v`, s, want)
+		}
+	}
+}
+
+func TestFraction(t *testing.T) {
+	g := New(4, Options{Fraction: true, Location: time.UTC})
+	for i := 0; i < iterations; i++ {
+		s, want := g.Next()
+		got, err := time.Parse("2006-01-02T15:04:05.999999999", strings.Replace(s, ",", ".", 1))
+		if err != nil || !got.Equal(want) {
+			t.Errorf(`fraction %q -> %v, %v (should be %v)`, s, got, err, want)
+		}
+	}
+}
+
+func TestReproducible(t *testing.T) {
+	a, b := New(5, Options{Offset: true}), New(5, Options{Offset: true})
+	for i := 0; i < 100; i++ {
+		sa, _ := a.Next()
+		sb, _ := b.Next()
+		if sa != sb {
+			t.Fatalf(`generators with the same seed diverged at %d: %q != %q`, i, sa, sb)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"encoding"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// GraphQL scalars
+//
+// Date, DateTime, Period, and Timestamp implement gqlgen's graphql.Marshaler and
+// graphql.Unmarshaler interfaces, so they can be bound to custom scalars in gqlgen.yml
+// without importing gqlgen here:
+//
+//	models:
+//	  Date:
+//	    model: github.com/bsolomon1124/isoparse/isoparse.Date
+//	  Duration:
+//	    model: github.com/bsolomon1124/isoparse/isoparse.Period
+//
+// Input goes through the same lenient parsing as UnmarshalText, so clients may send any
+// ISO-8601 form this package accepts rather than only RFC 3339.  Output is the canonical
+// String form, as a GraphQL string literal.
+
+// Timestamp is a time.Time that marshals to and from GraphQL as an ISO-8601 string.
+// It exists because gqlgen's built-in Time scalar accepts only RFC 3339.
+// Input without a UTC offset is read in time.Local, as with ParseISODatetime;
+// output is RFC 3339 with nanoseconds, as with time.Time.MarshalText.
+type Timestamp struct {
+	time.Time
+}
+
+// MarshalGQL implements graphql.Marshaler.
+func (ts Timestamp) MarshalGQL(w io.Writer) {
+	io.WriteString(w, strconv.Quote(ts.Format(time.RFC3339Nano)))
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+func (ts *Timestamp) UnmarshalGQL(v interface{}) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("isoparse: timestamp must be a string, not %T", v)
+	}
+	t, err := ParseISODatetime(s)
+	if err != nil {
+		return err
+	}
+	ts.Time = t
+	return nil
+}
+
+// MarshalGQL implements graphql.Marshaler.
+func (d Date) MarshalGQL(w io.Writer) { marshalGQLString(w, d) }
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+func (d *Date) UnmarshalGQL(v interface{}) error { return unmarshalGQLText(v, d) }
+
+// MarshalGQL implements graphql.Marshaler.
+func (dt DateTime) MarshalGQL(w io.Writer) { marshalGQLString(w, dt) }
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+func (dt *DateTime) UnmarshalGQL(v interface{}) error { return unmarshalGQLText(v, dt) }
+
+// MarshalGQL implements graphql.Marshaler.
+func (p Period) MarshalGQL(w io.Writer) { marshalGQLString(w, p) }
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+func (p *Period) UnmarshalGQL(v interface{}) error { return unmarshalGQLText(v, p) }
+
+// marshalGQLString writes s's String form as a GraphQL string literal.
+func marshalGQLString(w io.Writer, s fmt.Stringer) {
+	io.WriteString(w, strconv.Quote(s.String()))
+}
+
+// unmarshalGQLText passes a GraphQL string input value to u.
+func unmarshalGQLText(v interface{}, u encoding.TextUnmarshaler) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("isoparse: %T must be a string, not %T", u, v)
+	}
+	return u.UnmarshalText([]byte(s))
+}
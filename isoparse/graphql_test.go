@@ -0,0 +1,62 @@
+package isoparse
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type gqlScalar interface {
+	MarshalGQL(w io.Writer)
+}
+
+type gqlUnmarshaler interface {
+	UnmarshalGQL(v interface{}) error
+}
+
+// GraphQL input values, and the value and literal output each should round trip to.
+var gqlScalars = []struct {
+	input string
+	into  gqlUnmarshaler
+	want  gqlScalar
+	out   string
+}{
+	{"2018-W39-4", new(Date), &Date{2018, time.September, 27}, `"2018-09-27"`},
+	{"20180927T1230", new(DateTime), &DateTime{Date{2018, time.September, 27}, TimeOfDay{12, 30, 0, 0}}, `"2018-09-27T12:30:00"`},
+	{"P1DT12H", new(Period), &Period{Days: 1, Hours: 12}, `"P1DT12H"`},
+	{"2018-09-27T05:00+05", new(Timestamp), &Timestamp{time.Date(2018, 9, 27, 0, 0, 0, 0, time.UTC)}, `"2018-09-27T05:00:00+05:00"`},
+}
+
+func TestGQLScalars(t *testing.T) {
+	for _, c := range gqlScalars {
+		if err := c.into.UnmarshalGQL(c.input); err != nil {
+			t.Errorf(`%T.UnmarshalGQL(%q) -> non-nil error (%v)`, c.into, c.input, err)
+			continue
+		}
+		if ts, ok := c.into.(*Timestamp); ok {
+			if !ts.Equal(c.want.(*Timestamp).Time) {
+				t.Errorf(`%T.UnmarshalGQL(%q) -> %v (should be %v)`, c.into, c.input, ts, c.want)
+			}
+		} else if !reflect.DeepEqual(c.into, c.want) {
+			t.Errorf(`%T.UnmarshalGQL(%q) -> %v (should be %v)`, c.into, c.input, c.into, c.want)
+		}
+		var buf bytes.Buffer
+		c.into.(gqlScalar).MarshalGQL(&buf)
+		if buf.String() != c.out {
+			t.Errorf(`%T.MarshalGQL -> %s (should be %s)`, c.into, buf.String(), c.out)
+		}
+	}
+}
+
+func TestGQLNonString(t *testing.T) {
+	for _, into := range []gqlUnmarshaler{new(Date), new(DateTime), new(Period), new(Timestamp)} {
+		if err := into.UnmarshalGQL(int64(2018)); err == nil {
+			t.Errorf(`%T.UnmarshalGQL(2018) returned nil error`, into)
+		}
+		if err := into.UnmarshalGQL("not a date"); err == nil {
+			t.Errorf(`%T.UnmarshalGQL("not a date") returned nil error`, into)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import "strings"
+
+// formatShapes lists the accepted shapes for each class of element, as used by
+// ParseError.AcceptedFormats.
+var formatShapes = map[string][]string{
+	"date": {
+		"YYYY", "YYYY-MM", "YYYY-MM-DD", "YYYYMMDD", "YYYY-Www", "YYYYWww",
+		"YYYY-Www-D", "YYYYWwwD", "YYYY-DDD", "YYYYDDD",
+	},
+	"datetime": {"<date>T<time>", "YYYY-MM-DDThh:mm:ss±hh:mm", "YYYYMMDDThhmmss±hhmm"},
+	"time": {
+		"hh", "hh:mm", "hhmm", "hh:mm:ss", "hhmmss", "hh:mm:ss.sss", "hhmmss.sss",
+		"any of which may be followed by an offset",
+	},
+	"offset":     {"Z", "±hh", "±hh:mm", "±hhmm"},
+	"duration":   {"PnYnMnDTnHnMnS", "PnW", "PTnH", "PTn.nS"},
+	"interval":   {"<start>/<end>", "<start>/<duration>", "<duration>/<end>"},
+	"annotation": {"[Area/City]", "[±hh:mm]", "[key=value]", "[!key=value]"},
+}
+
+// elementClasses maps each ParseError.Element to its key in formatShapes.
+var elementClasses = map[string]string{
+	"year":           "date",
+	"month":          "date",
+	"day":            "date",
+	"week":           "date",
+	"weekday":        "date",
+	"ordinal-day":    "date",
+	"date-separator": "date",
+	"time-separator": "datetime",
+	"hour":           "time",
+	"minute":         "time",
+	"second":         "time",
+	"fraction":       "time",
+	"offset":         "offset",
+	"offset-hours":   "offset",
+	"offset-minutes": "offset",
+	"duration":       "duration",
+	"interval":       "interval",
+	"annotation":     "annotation",
+	"zone":           "annotation",
+}
+
+// AcceptedFormats returns the shapes of string that this package accepts for the kind of
+// element that e is about: for example, the date formats for an error in the month.
+// It returns nil if e has no Element.
+func (e *ParseError) AcceptedFormats() []string {
+	return formatShapes[elementClasses[e.Element]]
+}
+
+// WithFormatHints makes a Parser append the accepted formats to the message of each
+// syntax error or invalid UTC offset, as listed by ParseError.AcceptedFormats.  This lets
+// the consumers of an API see for themselves what "2014/12/03" should have looked like.
+func WithFormatHints() Option {
+	return func(p *Parser) {
+		p.formatHints = true
+	}
+}
+
+// hintFormats applies WithFormatHints to err.
+func (p *Parser) hintFormats(err error) error {
+	e, ok := err.(*ParseError)
+	if !p.formatHints || !ok || (e.Err != ErrSyntax && e.Err != ErrTrailingData && e.Err != ErrInvalidOffset) {
+		return err
+	}
+	if formats := e.AcceptedFormats(); formats != nil {
+		e.Message += "; accepted formats: " + strings.Join(formats, ", ")
+	}
+	return e
+}
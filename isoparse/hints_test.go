@@ -0,0 +1,39 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWithFormatHints(t *testing.T) {
+	p := NewParser(WithFormatHints())
+	cases := map[string]string{
+		"2014/12/03":         "invalid ordinal day; accepted formats: YYYY, YYYY-MM, YYYY-MM-DD,",
+		"2014-12-03T12.30":   `in time "12.30"; accepted formats: hh, hh:mm, hhmm,`,
+		"2014-12-03T12:30+5": `in time "12:30+5"; accepted formats: Z, ±hh, ±hh:mm, ±hhmm`,
+	}
+	for s, want := range cases {
+		_, err := p.Parse(s)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf(`Parse(%q) -> %v (should contain %q)`, s, err, want)
+		}
+	}
+	if _, err := p.ParseDuration("P1Y2X"); err == nil || !strings.Contains(err.Error(), "accepted formats: PnYnMnDTnHnMnS") {
+		t.Errorf(`ParseDuration("P1Y2X") -> %v (should list duration formats)`, err)
+	}
+
+	// Range errors and parsers without the option get no hints.
+	if _, err := p.Parse("2014-02-30"); err == nil || strings.Contains(err.Error(), "accepted formats") {
+		t.Errorf(`Parse("2014-02-30") -> %v (should have no hint)`, err)
+	}
+	_, err := ParseISODatetime("2014/12/03")
+	if err == nil || strings.Contains(err.Error(), "accepted formats") {
+		t.Errorf(`ParseISODatetime("2014/12/03") -> %v (should have no hint)`, err)
+	}
+	if formats := err.(*ParseError).AcceptedFormats(); len(formats) != 10 || formats[2] != "YYYY-MM-DD" {
+		t.Errorf(`AcceptedFormats() -> %v`, formats)
+	}
+}
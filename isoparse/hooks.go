@@ -0,0 +1,54 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+// Hooks are functions that a Parser calls after each parse, so that a service can count
+// failures and the formats it sees, say as Prometheus counters, without wrapping every
+// call site.  Either may be nil.  They are called on the parsing goroutine, so they must
+// be safe for concurrent use if the Parser is shared, and should be quick.
+type Hooks struct {
+	// OnSuccess is called with the format of each string that parses.  Strings that only
+	// an extension accepts, such as quarters or a profile's own syntax, are
+	// UnknownFormat.
+	OnSuccess func(format Format)
+
+	// OnError is called with the error for each string that doesn't.
+	OnError func(err error)
+}
+
+// WithHooks sets the hooks that a Parser calls after each call to Parse, ParseDate,
+// ParseTime, and ParseDateTime, including those made by the functions built on them: for
+// an interval, once for each end that is a datetime rather than a duration.  A result
+// from the cache set with WithCache counts as a parse.
+//
+// Functions that search text, such as FindAll, ParsePrefix, and SplitLeadingTimestamp,
+// try many candidates along the way, and report only what they return: each datetime
+// found, and for ParsePrefix, the error if there is none.
+func WithHooks(h Hooks) Option {
+	return func(p *Parser) {
+		p.hooks = nil
+		if h.OnSuccess != nil || h.OnError != nil {
+			p.hooks = &h
+		}
+	}
+}
+
+// observe calls p's hook, if it has one, for the result of parsing s.
+func (p *Parser) observe(s string, err error) {
+	if p.hooks != nil {
+		p.hooks.observe(s, err)
+	}
+}
+
+// observe calls the hook for the result of parsing s.
+func (h *Hooks) observe(s string, err error) {
+	switch {
+	case err != nil && h.OnError != nil:
+		h.OnError(err)
+	case err == nil && h.OnSuccess != nil:
+		rest, _, _ := splitIXDTF(s)
+		format, _ := DetectFormat(rest)
+		h.OnSuccess(format)
+	}
+}
@@ -0,0 +1,91 @@
+package isoparse
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// hookCounts records the calls to a Parser's hooks.
+type hookCounts struct {
+	mu      sync.Mutex
+	formats map[Format]int
+	errors  []error
+}
+
+func (c *hookCounts) hooks() Hooks {
+	c.formats = make(map[Format]int)
+	return Hooks{
+		OnSuccess: func(format Format) {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			c.formats[format]++
+		},
+		OnError: func(err error) {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			c.errors = append(c.errors, err)
+		},
+	}
+}
+
+func TestWithHooks(t *testing.T) {
+	var c hookCounts
+	p := NewParser(WithLocation(time.UTC), WithCache(4), WithHooks(c.hooks()))
+	p.Parse("2018-09-27T05:00:00Z")
+	p.Parse("2018-09-27T05:00:00Z") // From the cache
+	p.Parse("2018-W39-4[Europe/Paris]")
+	p.ParseDate("2018-270")
+	p.ParseTime("05:00")
+	p.ParseDateTime("20180927T0500")
+	p.ParseInterval("2018-09-27/P1D")
+	p.Parse("2018-02-30")
+	p.ParseTime("25:00")
+
+	want := map[Format]int{
+		CalendarDateTimeExtended: 2,
+		WeekDateExtended:         1,
+		OrdinalDateExtended:      1,
+		TimeOnly:                 1,
+		CalendarDateTimeBasic:    1,
+		CalendarDateExtended:     1,
+	}
+	if !reflect.DeepEqual(c.formats, want) {
+		t.Errorf(`OnSuccess formats -> %v (should be %v)`, c.formats, want)
+	}
+	if len(c.errors) != 2 || !errors.Is(c.errors[0], ErrInvalidDay) || !errors.Is(c.errors[1], ErrTimeRange) {
+		t.Errorf(`OnError errors -> %v (should be an invalid day and a time out of range)`, c.errors)
+	}
+}
+
+func TestWithHooksPartial(t *testing.T) {
+	var failures int
+	p := NewParser(WithHooks(Hooks{OnError: func(error) { failures++ }}))
+	p.Parse("2018-09-27")
+	p.Parse("2018-09-27T")
+	if failures != 1 {
+		t.Errorf(`OnError called %d times (should be 1)`, failures)
+	}
+	// Hooks with neither function are the same as none.
+	if p := NewParser(WithHooks(Hooks{})); p.hooks != nil {
+		t.Errorf(`WithHooks(Hooks{}) set hooks`)
+	}
+}
+
+func TestWithHooksSearch(t *testing.T) {
+	var c hookCounts
+	p := NewParser(WithHooks(c.hooks()))
+	p.FindAll("at 2024-01-02T03:04:05Z, then 1234 and 2024-13-01")
+	p.ParsePrefix("2024-01-02T03:04:05Z: started")
+	p.SplitLeadingTimestamp("[2024-01-02 03:04:05] started")
+	p.SplitLeadingTimestamp("started")
+	if want := map[Format]int{CalendarDateTimeExtended: 3}; !reflect.DeepEqual(c.formats, want) || len(c.errors) != 0 {
+		t.Errorf(`hooks -> %v, %v (should be %v and no errors)`, c.formats, c.errors, want)
+	}
+	_, _, err := p.ParsePrefix("12:00 started")
+	if len(c.errors) != 1 || c.errors[0] != err {
+		t.Errorf(`OnError errors -> %v (should be %v alone)`, c.errors, err)
+	}
+}
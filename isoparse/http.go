@@ -0,0 +1,166 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// A RequestError describes a value in an HTTP request that could not be parsed.
+// Err is the underlying error, usually a *ParseError.
+type RequestError struct {
+	Source string // "query parameter", "header", or "form value"
+	Name   string // parameter or header name
+	Index  int    // position of the bad value among the values for Name, from 0
+	Count  int    // number of values for Name
+	Err    error
+}
+
+func (e *RequestError) Error() string {
+	if e.Count > 1 {
+		return fmt.Sprintf("isoparse: %s %q (value %d of %d): %v", e.Source, e.Name, e.Index+1, e.Count, e.Err)
+	}
+	return fmt.Sprintf("isoparse: %s %q: %v", e.Source, e.Name, e.Err)
+}
+
+func (e *RequestError) Unwrap() error { return e.Err }
+
+// RequestValues extracts ISO-8601 values from one part of an HTTP request: its query string,
+// its headers, or its form.  Use QueryValues, HeaderValues, or FormValues to get one.
+//
+// Each getter reports ok = false, with a nil error, if the value is absent or empty,
+// so that optional parameters need no extra lookup.  The singular getters use the first
+// value when a name is repeated; the plural getters parse every value.
+type RequestValues struct {
+	parser *Parser
+	source string
+	get    func(name string) []string
+}
+
+// QueryValues returns the RequestValues for r's URL query parameters.
+func QueryValues(r *http.Request) *RequestValues {
+	return defaultParser.QueryValues(r)
+}
+
+// QueryValues is like the package-level QueryValues, but parses with p.
+func (p *Parser) QueryValues(r *http.Request) *RequestValues {
+	q := r.URL.Query()
+	return &RequestValues{parser: p, source: "query parameter", get: func(name string) []string { return q[name] }}
+}
+
+// HeaderValues returns the RequestValues for r's headers.  Names are canonicalized as by
+// http.Header.Values.
+func HeaderValues(r *http.Request) *RequestValues {
+	return defaultParser.HeaderValues(r)
+}
+
+// HeaderValues is like the package-level HeaderValues, but parses with p.
+func (p *Parser) HeaderValues(r *http.Request) *RequestValues {
+	return &RequestValues{parser: p, source: "header", get: r.Header.Values}
+}
+
+// FormValues returns the RequestValues for r's form, calling r.ParseMultipartForm
+// (or r.ParseForm for other content types) if it has not been parsed already.
+// As with r.Form, POST and PUT body values take precedence over query parameters.
+func FormValues(r *http.Request) (*RequestValues, error) {
+	return defaultParser.FormValues(r)
+}
+
+// FormValues is like the package-level FormValues, but parses with p.
+func (p *Parser) FormValues(r *http.Request) (*RequestValues, error) {
+	if r.Form == nil {
+		err := r.ParseMultipartForm(32 << 20)
+		if err != nil && err != http.ErrNotMultipart {
+			return nil, err
+		}
+	}
+	form := r.Form
+	return &RequestValues{parser: p, source: "form value", get: func(name string) []string { return form[name] }}, nil
+}
+
+// each calls parse on every non-empty value for name, wrapping any error in a *RequestError.
+func (v *RequestValues) each(name string, all bool, parse func(string) error) (ok bool, err error) {
+	values := v.get(name)
+	for i, s := range values {
+		if s == "" {
+			continue
+		}
+		if err := parse(s); err != nil {
+			return false, &RequestError{v.source, name, i, len(values), err}
+		}
+		ok = true
+		if !all {
+			break
+		}
+	}
+	return ok, nil
+}
+
+// Datetime parses the first value for name as with Parser.Parse.
+func (v *RequestValues) Datetime(name string) (t time.Time, ok bool, err error) {
+	ok, err = v.each(name, false, func(s string) (err error) {
+		t, err = v.parser.Parse(s)
+		return err
+	})
+	return t, ok, err
+}
+
+// Datetimes parses every value for name as with Parser.Parse.
+func (v *RequestValues) Datetimes(name string) (ts []time.Time, err error) {
+	_, err = v.each(name, true, func(s string) error {
+		t, err := v.parser.Parse(s)
+		ts = append(ts, t)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+// Date parses the first value for name as with Parser.ParseDate.
+func (v *RequestValues) Date(name string) (d Date, ok bool, err error) {
+	ok, err = v.each(name, false, func(s string) (err error) {
+		d, err = v.parser.ParseDate(s)
+		return err
+	})
+	return d, ok, err
+}
+
+// Dates parses every value for name as with Parser.ParseDate.
+func (v *RequestValues) Dates(name string) (ds []Date, err error) {
+	_, err = v.each(name, true, func(s string) error {
+		d, err := v.parser.ParseDate(s)
+		ds = append(ds, d)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
+// Duration parses the first value for name as with Parser.ParseDuration.
+func (v *RequestValues) Duration(name string) (p Period, ok bool, err error) {
+	ok, err = v.each(name, false, func(s string) (err error) {
+		p, err = v.parser.ParseDuration(s)
+		return err
+	})
+	return p, ok, err
+}
+
+// Durations parses every value for name as with Parser.ParseDuration.
+func (v *RequestValues) Durations(name string) (ps []Period, err error) {
+	_, err = v.each(name, true, func(s string) error {
+		p, err := v.parser.ParseDuration(s)
+		ps = append(ps, p)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ps, nil
+}
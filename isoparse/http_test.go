@@ -0,0 +1,76 @@
+package isoparse
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQueryValues(t *testing.T) {
+	r := httptest.NewRequest("GET", "/events?since=2018-09-27T05:00Z&day=2018-W39-4&window=PT1H30M&empty=&bad=2018-13-01&day=2018-09-28", nil)
+	v := NewParser(WithLocation(time.UTC)).QueryValues(r)
+
+	since, ok, err := v.Datetime("since")
+	if want := time.Date(2018, 9, 27, 5, 0, 0, 0, time.UTC); err != nil || !ok || !since.Equal(want) {
+		t.Errorf(`Datetime("since") -> %v, %v, %v (should be %v, true, nil)`, since, ok, err, want)
+	}
+	days, err := v.Dates("day")
+	if want := []Date{{2018, time.September, 27}, {2018, time.September, 28}}; err != nil || len(days) != 2 || days[0] != want[0] || days[1] != want[1] {
+		t.Errorf(`Dates("day") -> %v, %v (should be %v, nil)`, days, err, want)
+	}
+	window, ok, err := v.Duration("window")
+	if want := (Period{Hours: 1, Minutes: 30}); err != nil || !ok || window != want {
+		t.Errorf(`Duration("window") -> %v, %v, %v (should be %v, true, nil)`, window, ok, err, want)
+	}
+	for _, name := range []string{"empty", "missing"} {
+		if _, ok, err := v.Datetime(name); ok || err != nil {
+			t.Errorf(`Datetime(%q) -> %v, %v (should be false, nil)`, name, ok, err)
+		}
+	}
+
+	_, _, err = v.Date("bad")
+	var reqErr *RequestError
+	if !errors.As(err, &reqErr) || reqErr.Name != "bad" || reqErr.Source != "query parameter" {
+		t.Fatalf(`Date("bad") -> %v (should be a *RequestError for query parameter "bad")`, err)
+	}
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Errorf(`Date("bad") -> %v (should wrap a *ParseError)`, err)
+	}
+}
+
+func TestRequestErrorPosition(t *testing.T) {
+	r := httptest.NewRequest("GET", "/?at=2018-09-27&at=nope", nil)
+	_, err := QueryValues(r).Dates("at")
+	if err == nil || !strings.Contains(err.Error(), `"at" (value 2 of 2)`) {
+		t.Errorf(`Dates("at") -> %v (should report value 2 of 2)`, err)
+	}
+}
+
+func TestHeaderValues(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("X-Not-Before", "2018-09-27T05:00:00+05:00")
+	got, ok, err := HeaderValues(r).Datetime("x-not-before")
+	if want := time.Date(2018, 9, 27, 0, 0, 0, 0, time.UTC); err != nil || !ok || !got.Equal(want) {
+		t.Errorf(`Datetime("x-not-before") -> %v, %v, %v (should be %v, true, nil)`, got, ok, err, want)
+	}
+}
+
+func TestFormValues(t *testing.T) {
+	r := httptest.NewRequest("POST", "/?due=2018-01-01", strings.NewReader("due=2018-09-27"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	v, err := FormValues(r)
+	if err != nil {
+		t.Fatalf(`FormValues -> non-nil error (%v)`, err)
+	}
+	// Body values come first, as with http.Request.FormValue.
+	got, ok, err := v.Date("due")
+	if want := (Date{2018, time.September, 27}); err != nil || !ok || got != want {
+		t.Errorf(`Date("due") -> %v, %v, %v (should be %v, true, nil)`, got, ok, err, want)
+	}
+	if _, err := v.Dates("due"); err != nil {
+		t.Errorf(`Dates("due") -> non-nil error (%v)`, err)
+	}
+}
@@ -0,0 +1,129 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"strings"
+	"time"
+)
+
+// Interval represents an ISO-8601 time interval (ISO 8601:2004 4.4): the span of time
+// between two instants, Start and End.
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// ParseISOInterval parses an ISO-8601 time interval (ISO 8601:2004 4.4.4) in any of the
+// three forms that denote a pair of instants:
+//
+//	<start>/<end>       2007-03-01T13:00:00Z/2008-05-11T15:30:00Z
+//	<start>/<duration>  2007-03-01T13:00:00Z/P1Y2M10DT2H30M
+//	<duration>/<end>    P1Y2M10DT2H30M/2008-05-11T15:30:00Z
+//
+// A double hyphen ("--") is accepted in place of the solidus, as the standard allows.
+// Each instant is parsed as with ParseISODatetime; durations are applied with Period.AddTo.
+// An interval whose end precedes its start is an error.
+//
+// It is a thin wrapper around Parser.ParseInterval for a Parser with default options.
+func ParseISOInterval(intervalString string) (Interval, error) {
+	return defaultParser.ParseInterval(intervalString)
+}
+
+// ParseInterval parses an ISO-8601 time interval.  See ParseISOInterval.
+// Instants with no UTC offset get the location configured with WithLocation.
+func (p *Parser) ParseInterval(intervalString string) (Interval, error) {
+	sep, width := strings.IndexByte(intervalString, '/'), 1
+	if sep < 0 {
+		sep, width = strings.Index(intervalString, "--"), 2
+	}
+	if sep < 0 {
+		return Interval{}, p.hintFormats(&ParseError{intervalString, "interval must contain a '/' or '--' separator", -1, "interval", ErrSyntax})
+	}
+	first, second := intervalString[:sep], intervalString[sep+width:]
+	firstIsPeriod, secondIsPeriod := strings.HasPrefix(first, "P"), strings.HasPrefix(second, "P")
+
+	var iv Interval
+	switch {
+	case firstIsPeriod && secondIsPeriod:
+		return Interval{}, p.hintFormats(&ParseError{intervalString, "interval cannot consist of two durations", sep, "interval", ErrSyntax})
+	case firstIsPeriod:
+		period, err := p.ParseDuration(first)
+		if err != nil {
+			return Interval{}, err
+		}
+		if iv.End, err = p.Parse(second); err != nil {
+			return Interval{}, err
+		}
+		period.Negative = true
+		iv.Start = period.AddTo(iv.End)
+	case secondIsPeriod:
+		period, err := p.ParseDuration(second)
+		if err != nil {
+			return Interval{}, err
+		}
+		if iv.Start, err = p.Parse(first); err != nil {
+			return Interval{}, err
+		}
+		iv.End = period.AddTo(iv.Start)
+	default:
+		var err error
+		if iv.Start, err = p.Parse(first); err != nil {
+			return Interval{}, err
+		}
+		if iv.End, err = p.Parse(second); err != nil {
+			return Interval{}, err
+		}
+	}
+	if iv.End.Before(iv.Start) {
+		return Interval{}, &ParseError{intervalString, "interval end precedes its start", -1, "interval", ErrIntervalOrder}
+	}
+	return iv, nil
+}
+
+// Duration returns the length of the interval.
+func (iv Interval) Duration() time.Duration {
+	return iv.End.Sub(iv.Start)
+}
+
+// Contains reports whether t falls within the interval.  Start is inclusive and
+// End is exclusive.
+func (iv Interval) Contains(t time.Time) bool {
+	return !t.Before(iv.Start) && t.Before(iv.End)
+}
+
+// String returns the interval in ISO-8601 <start>/<end> format.
+func (iv Interval) String() string {
+	return iv.Start.Format(time.RFC3339Nano) + "/" + iv.End.Format(time.RFC3339Nano)
+}
+
+// cmpTime is a three-way comparison of two instants.
+func cmpTime(a, b time.Time) int {
+	if a.Before(b) {
+		return -1
+	}
+	if a.After(b) {
+		return 1
+	}
+	return 0
+}
+
+// Compare compares iv and other, returning -1, 0, or +1.  Intervals are ordered
+// by their start instants, with ties broken by the end instants.  As with
+// time.Time.Equal, the locations attached to the instants are not considered.
+func (iv Interval) Compare(other Interval) int {
+	if c := cmpTime(iv.Start, other.Start); c != 0 {
+		return c
+	}
+	return cmpTime(iv.End, other.End)
+}
+
+// Before reports whether iv orders before other.  See Compare.
+func (iv Interval) Before(other Interval) bool { return iv.Compare(other) < 0 }
+
+// After reports whether iv orders after other.  See Compare.
+func (iv Interval) After(other Interval) bool { return iv.Compare(other) > 0 }
+
+// Equal reports whether iv and other start and end at the same instants.
+func (iv Interval) Equal(other Interval) bool { return iv.Compare(other) == 0 }
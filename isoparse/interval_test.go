@@ -0,0 +1,75 @@
+package isoparse
+
+import (
+	"testing"
+	"time"
+)
+
+var (
+	ivJan = Interval{time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2018, 2, 1, 0, 0, 0, 0, time.UTC)}
+	ivFeb = Interval{time.Date(2018, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC)}
+	// Same start as ivJan, but a later end.
+	ivJanFeb = Interval{time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC)}
+	// Same instants as ivJan, in a different location.
+	ivJanOffset = Interval{ivJan.Start.In(offsetZone(-5 * 60 * 60)), ivJan.End.In(offsetZone(3 * 60 * 60))}
+)
+
+func TestIntervalCompare(t *testing.T) {
+	for _, pair := range [][2]Interval{{ivJan, ivFeb}, {ivJan, ivJanFeb}, {ivJanFeb, ivFeb}} {
+		a, b := pair[0], pair[1]
+		if a.Compare(b) != -1 || b.Compare(a) != 1 || !a.Before(b) || !b.After(a) || a.Equal(b) {
+			t.Errorf(`Interval comparison of %v and %v is inconsistent`, a, b)
+		}
+	}
+	if !ivJan.Equal(ivJanOffset) {
+		t.Errorf(`Interval %v should equal %v`, ivJan, ivJanOffset)
+	}
+}
+
+func TestIntervalContains(t *testing.T) {
+	if !ivJan.Contains(ivJan.Start) {
+		t.Errorf(`%v.Contains(%v) returned false for start instant`, ivJan, ivJan.Start)
+	}
+	if ivJan.Contains(ivJan.End) {
+		t.Errorf(`%v.Contains(%v) returned true for end instant`, ivJan, ivJan.End)
+	}
+	if d := ivJan.Duration(); d != 31*24*time.Hour {
+		t.Errorf(`%v.Duration() -> %v (should be %v)`, ivJan, d, 31*24*time.Hour)
+	}
+}
+
+var isoIntervals = map[string]Interval{
+	"2007-03-01T13:00:00Z/2008-05-11T15:30:00Z":      {time.Date(2007, 3, 1, 13, 0, 0, 0, time.UTC), time.Date(2008, 5, 11, 15, 30, 0, 0, time.UTC)},
+	"2007-03-01T13:00:00Z--2008-05-11T15:30:00Z":     {time.Date(2007, 3, 1, 13, 0, 0, 0, time.UTC), time.Date(2008, 5, 11, 15, 30, 0, 0, time.UTC)},
+	"2007-03-01T13:00:00Z/P1Y2M10DT2H30M":            {time.Date(2007, 3, 1, 13, 0, 0, 0, time.UTC), time.Date(2008, 5, 11, 15, 30, 0, 0, time.UTC)},
+	"P1Y2M10DT2H30M/2008-05-11T15:30:00Z":            {time.Date(2007, 3, 1, 13, 0, 0, 0, time.UTC), time.Date(2008, 5, 11, 15, 30, 0, 0, time.UTC)},
+	"2018-01-01T00:00:00+05:00/2018-01-01T00:00:00Z": {time.Date(2017, 12, 31, 19, 0, 0, 0, time.UTC), time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)},
+	"2018-01-01/2018-01-01":                          {time.Date(2018, 1, 1, 0, 0, 0, 0, time.Local), time.Date(2018, 1, 1, 0, 0, 0, 0, time.Local)},
+	"2018-01-31/P1M":                                 {time.Date(2018, 1, 31, 0, 0, 0, 0, time.Local), time.Date(2018, 2, 28, 0, 0, 0, 0, time.Local)},
+}
+
+var invalidIntervals = []string{
+	"2007-03-01T13:00:00Z", // No separator
+	"P1D/P2D",              // Two durations
+	"2008-05-11T15:30:00Z/2007-03-01T13:00:00Z",      // End before start
+	"2018-01-01T00:00:00-05:00/2018-01-01T00:00:00Z", // End before start, accounting for offsets
+	"2007-03-01T13:00:00Z/",                          // Missing end
+	"/2007-03-01T13:00:00Z",                          // Missing start
+	"2007-03-01T13:00:00Z/P1X",                       // Bad duration
+	"2007-13-01/2008-01-01",                          // Bad start
+}
+
+func TestParseISOInterval(t *testing.T) {
+	for s, want := range isoIntervals {
+		if got, err := ParseISOInterval(s); err != nil {
+			t.Errorf(`ParseISOInterval(%q) -> non-nil error (%v) for valid interval`, s, err)
+		} else if !got.Equal(want) {
+			t.Errorf(`ParseISOInterval(%q) -> %v (should be %v)`, s, got, want)
+		}
+	}
+	for _, s := range invalidIntervals {
+		if got, err := ParseISOInterval(s); err == nil {
+			t.Errorf(`ParseISOInterval(%q) -> %v returned nil error (invalid interval should error)`, s, got)
+		}
+	}
+}
@@ -19,8 +19,18 @@
 // 		function can also parse just a date in isolation, but if the user knows that input strings
 // 		contain only dates with no time components, it will be faster to use ParseISODate.
 // -	ParseISODate: parses a date string with no time component.
-// -	ParseISOTime: parses a time string with no date component.  This does not return a
-// 		time.Time instance, but rather the hour/minute/second/nsec components and the location.
+// -	ParseISOTimeParts: parses a time string with no date component.  This does not return a
+// 		time.Time instance, but rather a TimeParts holding the hour/minute/second/nsec components,
+// 		the location, and whether an offset was present.  (ParseISOTime is the older,
+// 		deprecated form of this that returns the components as a [4]int.)
+//
+// Parsers And Value Types
+//
+// The functions above are thin wrappers around a Parser with default options.  NewParser
+// accepts Option values (such as WithLocation) for when those defaults don't fit.
+//
+// Alongside time.Time, the package has "civil" value types that carry no location at all:
+// Date, YearMonth, TimeOfDay, and DateTime, plus Period (an ISO-8601 duration) and Interval.
 //
 // A Note On Time Zone Handling
 //
@@ -46,8 +56,9 @@
 // 		change attributes such as t.Hour() in the resulting timestamp itself.
 //
 // Note also that input strings that do contain a recognizable UTC offset will be given a
-// loc that is the result of time.FixedZone, with the generic name of "UTC" and a specified
-// seconds-east offset from UTC.  There is no attempt to determine an IANA time zone by name because,
+// loc that is the result of time.FixedZone, named after the offset itself ("UTC+05:30",
+// "UTC-08:00") with the matching seconds-east offset from UTC.  A zero offset ("Z", "+00:00")
+// gives time.UTC.  There is no attempt to determine an IANA time zone by name because,
 // for instance, an offset of -05:00 is still ambiguous based on whether daylight savings time
 // is in effect or not.
 //
@@ -58,10 +69,9 @@
 //			...
 //		}
 //
-// The time.Time results from isoparse's parsing functions will have a loc that looks like
-// time.FixedZone("UTC", secondsEast) and will be printed as:
+// The time.Time results from isoparse's parsing functions will be printed as:
 //
-//		YYYY-MM-DD HH:MM:SS.sssssssss -HHMM UTC
+//		YYYY-MM-DD HH:MM:SS.sssssssss +0530 UTC+05:30
 //
 // If you want more control over the actual resulting format, use time.Time.Format.
 //
@@ -81,8 +91,8 @@
 // 		between date and time, rather than just "T".
 // -	The standard allows years less than 0 and greater than 9999.
 // 		This package only permits years greater than 0 and less than 10,000.
-// -	This package does not support parsing time intervals or recurring time intervals
-// 		as defined in sections 4.4 and 4.5 of the standard, respectively.
+// -	Time intervals (section 4.4) are supported only by the separate ParseISOInterval
+// 		and ParseISODuration functions.  Recurring time intervals (section 4.5) are not supported.
 // -	The standard technically allows "19" to represent the date 1900-01-01, or "23" to
 // 		represent the time 23:00:00, as "representation[s] with reduced accuracy."
 // 		This package does not allow these formats.  (Although YYYY-MM and YYYY are valid here.)
@@ -103,9 +113,10 @@
 package isoparse
 
 import (
+	"errors"
 	"fmt"
-	"regexp"
 	"strconv"
+	"sync"
 	"time"
 )
 
@@ -131,11 +142,6 @@ const (
 	maxISODay  = 7
 )
 
-// Period, or comma, followed by 1 or more digits.
-// This is used to grab the optional fraction portion of the time.
-// It is the only regexp used in this package.
-var fractionRegex = regexp.MustCompile(`[.,](?P<digits>[0-9]+)`)
-
 // Days in month.  -1 is a placeholder because calendars are more intuitively 1-indexed.
 var dim = [13]int{-1, 31, 28, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
 
@@ -161,50 +167,99 @@ var dbm = [13]int{
 // These follow closely with both Python's datetime and Go's time modules.
 // We use the time.Month type (just an int) wherever possible for consistency.
 
+// rangeError is a failed range check from strictDate.  It carries the components rather
+// than a formatted datetime, since its callers replace it with a ParseError for their own
+// input (see locateElement); the string is built only if Error is called on it directly.
+type rangeError struct {
+	components [7]int
+	loc        *time.Location
+	message    string
+	element    string
+	err        error
+}
+
+func (e *rangeError) Error() string {
+	c := e.components
+	datetime := fmt.Sprintf("%02d-%02d-%02dT%02d:%02d:%02d.%09d%v", c[0], c[1], c[2], c[3], c[4], c[5], c[6], e.loc)
+	return "cannot parse " + datetime + ": " + e.message + " (" + e.element + ")"
+}
+
+func (e *rangeError) Unwrap() error { return e.err }
+
 // Go's time.Date "normalizes" values, overflowing into the next smallest unit.
 // (Providing month=1, day=32 normalizes to month=2, day=1.)
 // This package is more strict: if the input string doesn't itself form a valid date, don't attempt to reconform it.
 // Each unit must be strictly in its independently defined range.
 func strictDate(year int, month time.Month, day, hour, min, sec, nsec int, loc *time.Location) (time.Time, error) {
+	if err := checkRanges(year, month, day, hour, min, sec, nsec, loc); err != nil {
+		return time.Time{}, err
+	}
+
+	// We need to be careful with the fact that time.UTC != nil, but the zero value for
+	// *time.Location will be represented as UTC
+	if loc == nil {
+		loc = time.Local
+	}
+
+	// We can't validate the hours/minutes on loc here because there are unexported
+	// fields of Location.  That checking is performed in parseTimezone
+	return time.Date(year, month, day, hour, min, sec, nsec, loc), nil
+}
+
+// checkRanges does the range checks for strictDate, for callers that only need to
+// validate the components.  loc appears only in the error message.
+func checkRanges(year int, month time.Month, day, hour, min, sec, nsec int, loc *time.Location) error {
 	if year < minYear || year > maxYear {
-		datetime := fmt.Sprintf("%02d-%02d-%02dT%02d:%02d:%02d.%09d%v", year, month, day, hour, min, sec, nsec, loc)
-		return time.Time{}, &ParseError{datetime, "year out of valid range"}
+		return &rangeError{[7]int{year, int(month), day, hour, min, sec, nsec}, loc, "year out of valid range", "year", ErrYearRange}
 	}
 	if month < minMonth || month > maxMonth {
-		datetime := fmt.Sprintf("%02d-%02d-%02dT%02d:%02d:%02d.%09d%v", year, month, day, hour, min, sec, nsec, loc)
-		return time.Time{}, &ParseError{datetime, "month out of valid range"}
+		return &rangeError{[7]int{year, int(month), day, hour, min, sec, nsec}, loc, "month out of valid range", "month", ErrInvalidMonth}
 	}
-	if day > daysInMonth(year, month) {
-		datetime := fmt.Sprintf("%02d-%02d-%02dT%02d:%02d:%02d.%09d%v", year, month, day, hour, min, sec, nsec, loc)
-		return time.Time{}, &ParseError{datetime, "day out of valid range"}
+	if day < 1 || day > daysInMonth(year, month) {
+		return &rangeError{[7]int{year, int(month), day, hour, min, sec, nsec}, loc, "day out of valid range", "day", ErrInvalidDay}
 	}
 	if hour < minHour || hour > maxHour {
 		// We do *not* handle the 24:00 -> midnight aspect here.  Hour may be 24.
-		datetime := fmt.Sprintf("%02d-%02d-%02dT%02d:%02d:%02d.%09d%v", year, month, day, hour, min, sec, nsec, loc)
-		return time.Time{}, &ParseError{datetime, "hour out of valid range"}
+		return &rangeError{[7]int{year, int(month), day, hour, min, sec, nsec}, loc, "hour out of valid range", "hour", ErrTimeRange}
 	}
 	if min < minMin || min > maxMin {
-		datetime := fmt.Sprintf("%02d-%02d-%02dT%02d:%02d:%02d.%09d%v", year, month, day, hour, min, sec, nsec, loc)
-		return time.Time{}, &ParseError{datetime, "minute out of valid range"}
+		return &rangeError{[7]int{year, int(month), day, hour, min, sec, nsec}, loc, "minute out of valid range", "minute", ErrTimeRange}
 	}
 	if sec < minSec || sec > maxSec {
-		datetime := fmt.Sprintf("%02d-%02d-%02dT%02d:%02d:%02d.%09d%v", year, month, day, hour, min, sec, nsec, loc)
-		return time.Time{}, &ParseError{datetime, "second out of valid range"}
+		return &rangeError{[7]int{year, int(month), day, hour, min, sec, nsec}, loc, "second out of valid range", "second", ErrTimeRange}
 	}
 	if nsec < minNsec || nsec > maxNsec {
-		datetime := fmt.Sprintf("%02d-%02d-%02dT%02d:%02d:%02d.%09d%v", year, month, day, hour, min, sec, nsec, loc)
-		return time.Time{}, &ParseError{datetime, "nanosecond out of valid range"}
+		return &rangeError{[7]int{year, int(month), day, hour, min, sec, nsec}, loc, "nanosecond out of valid range", "fraction", ErrTimeRange}
 	}
+	return nil
+}
 
-	// We need to be careful with the fact that time.UTC != nil, but the zero value for
-	// *time.Location will be represented as UTC
-	if loc == nil {
-		loc = time.Local
+// parseDigits returns the value of s, which must be non-empty and consist only of ASCII
+// digits.  Unlike strconv.Atoi, it rejects signs, and it doesn't allocate on failure.
+// s must be short enough not to overflow an int; callers pass at most 9 digits.
+func parseDigits(s string) (n int, ok bool) {
+	if s == "" {
+		return 0, false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return 0, false
+		}
+		n = n*10 + int(s[i]-'0')
 	}
+	return n, true
+}
 
-	// We can't validate the hours/minutes on loc here because there are unexported
-	// fields of Location.  That checking is performed in parseTimezone
-	return time.Date(year, month, day, hour, min, sec, nsec, loc), nil
+// fractionNanos returns the nanoseconds represented by the digits of a decimal fraction
+// (those after the decimal sign).  Digits beyond nanosecond precision are truncated, not
+// rounded.  The digits must already have been checked.
+func fractionNanos(digits string) int {
+	n, scale := 0, 1000000000
+	for i := 0; i < len(digits) && scale > 1; i++ {
+		scale /= 10
+		n += int(digits[i]-'0') * scale
+	}
+	return n
 }
 
 // Bool to int
@@ -215,13 +270,6 @@ func btoi(b bool) int {
 	return 0
 }
 
-func min(i, j int) int {
-	if i < j {
-		return i
-	}
-	return j
-}
-
 // isLeapYear tests whether a given year is a leap year.
 // A leap year is a year whose year number is divisible by four an integral number of times.
 // However, a centennial year is not a leap year unless its year number is divisible
@@ -269,10 +317,9 @@ func ymdToOrd(year int, month time.Month, day int) int {
 }
 
 // isoWeekday returns the day of the week, where Monday == 1 ... Sunday == 7.
+// time.Weekday is used rather than ymdToOrd, which goes wrong for years before 1.
 func isoWeekday(date time.Time) int {
-	year, month, day := date.Date()
-	ordinal := ymdToOrd(year, month, day)
-	isoweekday := ordinal % 7
+	isoweekday := int(date.Weekday())
 	if isoweekday == 0 {
 		isoweekday = 7
 	}
@@ -298,10 +345,10 @@ func isoCalendar(date time.Time) [3]int {
 func calcWeekdate(year, week, day int) (time.Time, error) {
 	if week < minISOWeek || week > maxISOWeek {
 		dateString := fmt.Sprintf("%04d-%02d-%02d", year, week, day)
-		return time.Time{}, &ParseError{dateString, "invalid ISO week"}
+		return time.Time{}, &ParseError{dateString, "invalid ISO week", -1, "week", ErrInvalidWeek}
 	} else if day < minISODay || day > maxISODay {
 		dateString := fmt.Sprintf("%04d-%02d-%02d", year, week, day)
-		return time.Time{}, &ParseError{dateString, "invalid ISO day"}
+		return time.Time{}, &ParseError{dateString, "invalid ISO day", -1, "weekday", ErrInvalidDay}
 	}
 	jan4 := time.Date(year, 1, 4, 0, 0, 0, 0, time.Local)
 	week1 := jan4.AddDate(0, 0, -1*(isoWeekday(jan4)-1))
@@ -309,19 +356,102 @@ func calcWeekdate(year, week, day int) (time.Time, error) {
 	return week1.AddDate(0, 0, weekOffset), nil
 }
 
+// Sentinel errors for the categories of ParseError, so that callers can branch with
+// errors.Is rather than matching on Message.
+var (
+	ErrSyntax           = errors.New("malformed ISO-8601 string")
+	ErrYearRange        = errors.New("year out of valid range")
+	ErrInvalidMonth     = errors.New("invalid month")
+	ErrInvalidDay       = errors.New("invalid day")
+	ErrInvalidWeek      = errors.New("invalid ISO week")
+	ErrTimeRange        = errors.New("time component out of valid range")
+	ErrInvalidOffset    = errors.New("invalid UTC offset")
+	ErrMissingOffset    = errors.New("UTC offset required")
+	ErrUnexpectedOffset = errors.New("UTC offset not allowed")
+	ErrOffsetMismatch   = errors.New("UTC offset inconsistent with location")
+	ErrTrailingData     = errors.New("unparsed trailing data")
+	ErrNonexistentTime  = errors.New("local time does not exist")
+	ErrAmbiguousTime    = errors.New("local time is ambiguous")
+	ErrUnknownZone      = errors.New("unknown time zone")
+	ErrUnsupported      = errors.New("unsupported ISO-8601 feature")
+	ErrOverflow         = errors.New("value too large")
+	ErrIntervalOrder    = errors.New("interval end precedes its start")
+)
+
 // ParseError describes any problem parsing a datetime, date, or time string.
-// It is the sole error exported by this package.
+// It is the error type returned by every parsing function in this package.
 // (It also exists with similar structure in Go's time package.)
+//
+// Pos and Element locate the problem within Datetime, which matters when strings are
+// machine-generated and long.  Element is one of "year", "month", "day", "week", "weekday",
+// "ordinal-day", "date-separator", "time-separator", "hour", "minute", "second", "fraction",
+// "offset", "offset-hours", "offset-minutes", "duration", "interval", "annotation", or "zone".
 type ParseError struct {
 	Datetime string // This should always be passed
 	Message  string // Treat as optional unless the reason is specific
+	Pos      int    // Byte offset in Datetime at which the problem was found, or -1 if unknown
+	Element  string // The element being parsed when the problem was found, or "" if unknown
+	Err      error  // The category of the problem: one of the Err sentinels
 }
 
 func (e *ParseError) Error() string {
-	if e.Message == "" {
-		return "cannot parse " + e.Datetime
+	s := "cannot parse " + e.Datetime
+	if e.Message != "" {
+		s += ": " + e.Message
+	}
+	switch {
+	case e.Element != "" && e.Pos >= 0:
+		s += fmt.Sprintf(" (%s at byte %d)", e.Element, e.Pos)
+	case e.Element != "":
+		s += " (" + e.Element + ")"
+	case e.Pos >= 0:
+		s += fmt.Sprintf(" (at byte %d)", e.Pos)
+	}
+	return s
+}
+
+// Unwrap returns the sentinel error for e's category, so that errors.Is(err, ErrYearRange)
+// and the like work on a ParseError.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorKind is a coarse classification of a ParseError, for API layers that map parse
+// failures onto status codes: a syntax error is a malformed request, a range error is a
+// well-formed string naming something that doesn't exist, and an unsupported error is a
+// valid string that this package can't handle.
+type ErrorKind int
+
+const (
+	ErrorKindSyntax      ErrorKind = iota // The string is not well-formed.
+	ErrorKindRange                        // A component is out of range, or the instant doesn't exist.
+	ErrorKindUnsupported                  // The string uses a feature or time zone this package lacks.
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorKindSyntax:
+		return "syntax"
+	case ErrorKindRange:
+		return "range"
+	case ErrorKindUnsupported:
+		return "unsupported"
 	}
-	return "cannot parse " + e.Datetime + ": " + e.Message
+	return "ErrorKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Kind classifies e by its Err.  A ParseError without one of the Err sentinels is a
+// syntax error.
+func (e *ParseError) Kind() ErrorKind {
+	switch e.Err {
+	case ErrYearRange, ErrInvalidMonth, ErrInvalidDay, ErrInvalidWeek, ErrTimeRange,
+		ErrInvalidOffset, ErrOffsetMismatch, ErrNonexistentTime, ErrAmbiguousTime,
+		ErrOverflow, ErrIntervalOrder:
+		return ErrorKindRange
+	case ErrUnsupported, ErrUnknownZone:
+		return ErrorKindUnsupported
+	}
+	return ErrorKindSyntax
 }
 
 // parseIsoDateCommon parses common-format ISO-8601 date strings (no time portion).
@@ -338,10 +468,13 @@ func parseISODateCommon(dateString string) (components [3]int, pos int, err erro
 	length := len(dateString)
 	if length < 4 {
 		// The shortest string we should possibly have is YYYY.
-		return components, pos, &ParseError{dateString, "date string too short"}
+		return components, pos, &ParseError{dateString, "date string too short", length, "year", ErrSyntax}
 	}
 	components = [3]int{1, 1, 1}
-	components[0], _ = strconv.Atoi(dateString[:4])
+	var ok bool
+	if components[0], ok = parseDigits(dateString[:4]); !ok {
+		return components, pos, &ParseError{dateString, "year must be four digits", 0, "year", ErrSyntax}
+	}
 	pos = 4
 	if pos >= length {
 		// We received just YYYY, which is valid and becomes YYYY-01-01.
@@ -354,17 +487,17 @@ func parseISODateCommon(dateString string) (components [3]int, pos int, err erro
 
 	// At this point we are left with one of the following: MM-DD, MMDD, MM
 	if length-pos < 2 {
-		return components, pos, &ParseError{dateString, "invalid month"}
+		return components, pos, &ParseError{dateString, "invalid month", pos, "month", ErrSyntax}
 	}
 
 	// Note that this *may* incorrectly pick up on a portion of YYYYDDD as the month.
 	// But will then raise later on.
-	components[1], err = strconv.Atoi(dateString[pos : pos+2])
-	// This is one place where we definitely need to check the error.
+	components[1], ok = parseDigits(dateString[pos : pos+2])
+	// This is one place where we definitely need to check the digits.
 	// It is what allows us to catch "2004W537" and defer it to parseISODateUncommon.
 	pos += 2
-	if err != nil {
-		return components, pos, &ParseError{dateString, "invalid month"}
+	if !ok {
+		return components, pos, &ParseError{dateString, "invalid month", pos - 2, "month", ErrSyntax}
 	}
 	if pos >= length {
 		if hasSep {
@@ -373,29 +506,29 @@ func parseISODateCommon(dateString string) (components [3]int, pos int, err erro
 		} else {
 			// We have something like 177607, which is invalid
 			// (Designed to avoid confusion with truncated representation YYMMDD still often used)
-			return components, pos, &ParseError{dateString, "invalid format"}
+			return components, pos, &ParseError{dateString, "invalid format", pos, "day", ErrSyntax}
 		}
 	}
 
 	if hasSep {
 		if dateString[pos] != dateSep {
 			// Separator must be consistent.
-			return components, pos, &ParseError{dateString, "invalid separator"}
+			return components, pos, &ParseError{dateString, "invalid separator", pos, "date-separator", ErrSyntax}
 		}
 		pos += 1
 	}
 
 	// Day
 	if length-pos < 2 {
-		return components, pos, &ParseError{dateString, "invalid common day"}
+		return components, pos, &ParseError{dateString, "invalid common day", pos, "day", ErrSyntax}
 	}
-	components[2], err = strconv.Atoi(dateString[pos : pos+2])
-	if err != nil {
+	components[2], ok = parseDigits(dateString[pos : pos+2])
+	if !ok {
 		// Again, check the success of the conversion to make sure things like YYYYDDD fail here.
 		// (And get picked up by parseISODateUncommon.)  We have may otherwise parsed the
 		// month as the first two DD characters, and without this check 1985102 gets detected
 		// as 1985-10-0.
-		return components, pos, &ParseError{dateString, "invalid day"}
+		return components, pos, &ParseError{dateString, "invalid day", pos, "day", ErrSyntax}
 	}
 	return components, pos + 2, nil
 }
@@ -415,54 +548,80 @@ func parseISODateUncommon(dateString string) (components [3]int, pos int, err er
 	// The tradeoff is that parseISODateCommon is a fastpath that should handle most cases.
 	length := len(dateString)
 	if length < 4 {
-		return components, pos, &ParseError{dateString, "date string too short"}
+		return components, pos, &ParseError{dateString, "date string too short", length, "year", ErrSyntax}
 	}
 	var t time.Time
-	year, _ := strconv.Atoi(dateString[:4])
+	year, ok := parseDigits(dateString[:4])
+	if !ok {
+		return components, pos, &ParseError{dateString, "year must be four digits", 0, "year", ErrSyntax}
+	}
 	pos = 4
 	hasSep := dateString[pos] == dateSep
 	pos += btoi(hasSep)
+	if pos >= length {
+		return components, pos, &ParseError{dateString, "invalid format", pos, "week", ErrSyntax}
+	}
 
 	// We have now moved past YYYY or YYYY-
 	if dateString[pos] == 'W' {
 		// Choose from Www, Www-D, or WwwD
 		pos += 1
-		weekNum, _ := strconv.Atoi(dateString[pos : pos+2])
+		if length-pos < 2 {
+			return components, pos, &ParseError{dateString, "invalid week number", pos, "week", ErrSyntax}
+		}
+		weekNum, ok := parseDigits(dateString[pos : pos+2])
+		if !ok {
+			return components, pos, &ParseError{dateString, "invalid week number", pos, "week", ErrSyntax}
+		}
 		pos += 2
 		dayNum := 1
 		if length > pos {
 			if (dateString[pos] == dateSep) != hasSep {
 				// Prevent things like YYYY-MMDD (either use sep, or don't)
-				return components, pos, &ParseError{dateString, "inconsistent separator"}
+				return components, pos, &ParseError{dateString, "inconsistent separator", pos, "date-separator", ErrSyntax}
 			}
 			if hasSep {
 				pos += 1
 			}
-			dayNum, _ = strconv.Atoi(dateString[pos : pos+1])
+			if pos >= length {
+				return components, pos, &ParseError{dateString, "invalid week day", pos, "weekday", ErrSyntax}
+			}
+			if dayNum, ok = parseDigits(dateString[pos : pos+1]); !ok {
+				return components, pos, &ParseError{dateString, "invalid week day", pos, "weekday", ErrSyntax}
+			}
 			pos += 1
 		}
 		t, err = calcWeekdate(year, weekNum, dayNum)
 		if err != nil {
-			return components, pos, err
+			// Report the error against dateString rather than calcWeekdate's reconstruction.
+			e := err.(*ParseError)
+			e.Datetime, e.Pos = dateString, 5+btoi(hasSep)
+			if e.Element == "weekday" {
+				e.Pos = pos - 1
+			}
+			return components, pos, e
 		}
 	} else {
 		// Ordinal dates, YYYYDDD or YYYY-DDD (already at DDD)
 		if length-pos < 3 {
-			return components, pos, &ParseError{dateString, "invalid ordinal day"}
+			return components, pos, &ParseError{dateString, "invalid ordinal day", pos, "ordinal-day", ErrSyntax}
 		}
 		if length-pos == 4 {
 			// First prevent things like YYYY-MMDD (either use sep, or don't)
 			if hasSep && dateString[length-3] != dateSep {
-				return components, pos, &ParseError{dateString, "inconsistent separator"}
+				return components, pos, &ParseError{dateString, "inconsistent separator", length - 3, "date-separator", ErrSyntax}
 			} else if !hasSep && dateString[length-3] == dateSep {
 				// Vice-versa
-				return components, pos, &ParseError{dateString, "inconsistent separator"}
+				return components, pos, &ParseError{dateString, "inconsistent separator", length - 3, "date-separator", ErrSyntax}
 			}
 		}
-		ordinalDay, _ := strconv.Atoi(dateString[pos : pos+3])
+		ordinalDay, ok := parseDigits(dateString[pos : pos+3])
+		if !ok {
+			return components, pos, &ParseError{dateString, "invalid ordinal day", pos, "ordinal-day", ErrSyntax}
+		}
 		pos += 3
 		if ordinalDay < 1 || ordinalDay > (365+btoi(isLeapYear(year))) {
-			return components, pos, &ParseError{dateString, "invalid ordinal day for given year"}
+			return components, pos, &ParseError{dateString, "invalid ordinal day for given year", pos - 3, "ordinal-day", ErrInvalidDay}
 		}
 		t = time.Date(year, 1, 1, 0, 0, 0, 0, time.Local).AddDate(0, 0, ordinalDay-1)
 	}
@@ -491,30 +650,58 @@ func parseISODate(dateString string) (components [3]int, pos int, err error) {
 }
 
 // ParseISODate parses an ISO-8601 date string with no time component and returns components.
+// The result is midnight at the start of the date, in time.Local.
+//
+// It is a thin wrapper around Parser.ParseDate for a Parser with default options.
 func ParseISODate(dateString string) (time.Time, error) {
-	components, pos, err := parseISODate(dateString)
+	d, err := defaultParser.ParseDate(dateString)
 	if err != nil {
 		return time.Time{}, err
 	}
-	if pos < len(dateString) {
-		// This final check needs to remain separate.
-		// I.e. this logic is not followed in ParseISODatetime
-		return time.Time{}, &ParseError{dateString, "string contains unknown iso components"}
+	return defaultParser.startOfDay(d), nil
+}
+
+// offsetLen returns the length of the offset, Z, ±hh:mm, ±hhmm, or ±hh, at the start of
+// tzString, if it isn't followed by anything that could make it a longer one, or 0.
+func offsetLen(tzString string) int {
+	if tzString == "" {
+		return 0
+	}
+	if tzString[0] == 'Z' {
+		return 1
+	}
+	if tzString[0] != '+' && tzString[0] != '-' {
+		return 0
 	}
-	return strictDate(components[0], time.Month(components[1]), components[2], 0, 0, 0, 0, time.Local)
+	for _, shape := range [...]string{"dd:dd", "dddd", "dd"} {
+		if hasShape(tzString, 1, shape) {
+			n := 1 + len(shape)
+			if n < len(tzString) && (isDigit(tzString[n]) || tzString[n] == timeSep) {
+				return 0
+			}
+			return n
+		}
+	}
+	return 0
 }
 
 // parseTimezone parses an ISO-8601 timezone string, from Z, ±HH:MM, ±HHMM, or ±HH.
 // It allows Unicode minus-sign or minus-hyphen as the leading sign, in addition to plus-sign.
 func parseTimezone(tzString string) (tz *time.Location, err error) {
-	if tzString[0] == 'Z' {
+	if tzString == "Z" {
 		// var UTC *Location = &utcLoc
 		return time.UTC, nil
 	}
 
 	length := len(tzString)
-	if _, ok := map[int]bool{3: true, 5: true, 6: true}[length]; !ok {
-		return time.Local, &ParseError{tzString, "time zone offset string must be 1, 3, 5 or 6 characters"}
+	if n := offsetLen(tzString); n > 0 && n < length {
+		// A complete offset, followed by something that can't be part of one.
+		return time.Local, &ParseError{tzString, "unexpected characters after the offset", n, "", ErrTrailingData}
+	}
+	switch length {
+	case 3, 5, 6:
+	default:
+		return time.Local, &ParseError{tzString, "time zone offset string must be 1, 3, 5 or 6 characters", 0, "offset", ErrInvalidOffset}
 	}
 
 	// Except for Z, leading sign is required.
@@ -526,18 +713,26 @@ func parseTimezone(tzString string) (tz *time.Location, err error) {
 		// ("hyphen" and "minus" are both mapped onto "hyphen-minus.")
 		mult = -1
 	} else {
-		return tz, &ParseError{tzString, "unrecognized timezone sign"}
+		return tz, &ParseError{tzString, "unrecognized timezone sign", 0, "offset", ErrInvalidOffset}
 	}
 
 	// Hour and minute
-	hours, _ := strconv.Atoi(tzString[1:3])
+	hours, ok := parseDigits(tzString[1:3])
+	if !ok {
+		return time.Local, &ParseError{tzString, "offset hours must be two digits", 1, "offset-hours", ErrInvalidOffset}
+	}
 	var minutes int
 	if length != 3 {
 		// We are down to ±HH:MM and ±HHMM
-		if tzString[3] == ':' {
-			minutes, _ = strconv.Atoi(tzString[4:])
-		} else {
-			minutes, _ = strconv.Atoi(tzString[3:])
+		minuteString := tzString[3:]
+		if length == 6 {
+			if tzString[3] != ':' {
+				return time.Local, &ParseError{tzString, "invalid offset separator", 3, "offset", ErrInvalidOffset}
+			}
+			minuteString = tzString[4:]
+		}
+		if minutes, ok = parseDigits(minuteString); !ok {
+			return time.Local, &ParseError{tzString, "offset minutes must be two digits", length - 2, "offset-minutes", ErrInvalidOffset}
 		}
 	}
 
@@ -545,36 +740,118 @@ func parseTimezone(tzString string) (tz *time.Location, err error) {
 		return time.UTC, nil
 	}
 
-	if hours < minHour || hours > maxHour || minutes < minMin || minutes > maxMin {
-		return time.Local, &ParseError{tzString, "offset component out of valid range"}
+	if hours < minHour || hours > maxHour {
+		return time.Local, &ParseError{tzString, "offset component out of valid range", 1, "offset-hours", ErrInvalidOffset}
+	}
+	if minutes < minMin || minutes > maxMin {
+		return time.Local, &ParseError{tzString, "offset component out of valid range", length - 2, "offset-minutes", ErrInvalidOffset}
 	}
 
 	// We need seconds east of UTC as float64.
 	secondsEast := int(mult * 60 * (hours*60 + minutes))
 
 	// We cannot explicitly name the time zone (or determine DST)
-	// just based solely on its offset.  This seems to be the next best thing,
-	// although it is not ideal because it returns a time.Location where the caller
-	// cannot change `.name` (unexported field) from what is given here.
-	return time.FixedZone("UTC", secondsEast), nil
+	// just based solely on its offset.  Naming it after the offset seems to be the next
+	// best thing.
+	return offsetZone(secondsEast), nil
 }
 
+// ParseISOOffset parses a UTC offset on its own, as found in headers, configuration, or
+// file names: "Z", ±hh:mm, ±hhmm, or ±hh, with an ASCII "+" or "-" sign.
+//
+// Zero offsets (including "-00:00") give time.UTC, and other offsets give a fixed zone named
+// after the offset, as with ParseISODatetime.  Unlike ParseISODatetime, which tolerates
+// offsets of up to 24 hours, the hours must be 00 through 23, as in RFC 3339.
+func ParseISOOffset(offset string) (*time.Location, error) {
+	if offset == "" {
+		return nil, &ParseError{offset, "empty offset", 0, "offset", ErrMissingOffset}
+	}
+	loc, err := parseTimezone(offset)
+	if err != nil {
+		return nil, err
+	}
+	if _, secondsEast := time.Date(2000, 1, 1, 0, 0, 0, 0, loc).Zone(); secondsEast <= -24*60*60 || secondsEast >= 24*60*60 {
+		return nil, &ParseError{offset, "offset hours must be less than 24", 1, "offset-hours", ErrInvalidOffset}
+	}
+	return loc, nil
+}
+
+// offsetZone returns a fixed zone for the given offset, named after the offset
+// as in "UTC+05:30" or "UTC-08:00" (with seconds, "UTC+00:19:32", only if nonzero).
+//
+// Zones for whole-minute offsets under 24 hours, which is all that parsing can produce,
+// are cached, since otherwise every string with an offset would allocate a new one.
+func offsetZone(secondsEast int) *time.Location {
+	if secondsEast%60 != 0 || secondsEast <= -24*60*60 || secondsEast >= 24*60*60 {
+		return time.FixedZone("UTC"+formatOffset(secondsEast, ":"), secondsEast)
+	}
+	if loc, ok := offsetZones.Load(secondsEast); ok {
+		return loc.(*time.Location)
+	}
+	loc, _ := offsetZones.LoadOrStore(secondsEast, time.FixedZone("UTC"+formatOffset(secondsEast, ":"), secondsEast))
+	return loc.(*time.Location)
+}
+
+// offsetZones caches the results of offsetZone, keyed by seconds east of UTC.
+var offsetZones sync.Map
+
 // Note: an all-out-regex may work for ParseISOTime, such as:
 // re := regexp.MustCompile(`(?P<hour>\d{2}):?(?P<minute>\d{2})?:?(?P<second>\d{2})?[\\.,]?(?P<frac>\d{1,9})?(?P<offset>Z|[+-]\d{2}:?\d{2}?)?`)
 // However, this would yield "false positives" for times such as "12:", and Go does not support lookahead.
 // The time complexity of the existing approach is good, so we stick with that.
 
+// TimeParts is the result of parsing a time string with no date component.
+//
+// The embedded TimeOfDay holds the hour, minute, second, and nanosecond.  Hour may be 24
+// (with all other components 0) to represent midnight at the end of a day.
+type TimeParts struct {
+	TimeOfDay
+
+	// Loc is the location given by the string's UTC offset, or time.Local if there was none.
+	Loc *time.Location
+
+	// HasOffset reports whether the string contained a UTC offset ("Z" or ±hh[:mm]).
+	// When it is false, Loc is merely the time.Local default.
+	HasOffset bool
+}
+
+// ParseISOTimeParts parses an ISO-8601 time string with no date component.
+// Examples: HH, HH:MM or HHMM, HH:MM:SS or HHMMSS, HH:MM:SS.ssssss.  (Plus an optional time zone portion.)
+//
+// If parse error is not nil, the returned TimeParts will be the zero value.
+//
+// It is a thin wrapper around Parser.ParseTime for a Parser with default options.
+func ParseISOTimeParts(timeString string) (TimeParts, error) {
+	return defaultParser.ParseTime(timeString)
+}
+
 // ParseISOTime parses an ISO-8601 time string with no date component.
 // Examples: HH, HH:MM or HHMM, HH:MM:SS or HHMMSS, HH:MM:SS.ssssss.  (Plus an optional time zone portion.)
 // `components` here represents hour, minute, second, nanosecond.
+//
+// Deprecated: the positional [4]int is easy to misuse, and there is no way to tell a
+// time.Local default apart from an explicit offset.  Use ParseISOTimeParts instead.
 func ParseISOTime(timeString string) (components [4]int, tz *time.Location, err error) {
+	components, tz, _, err = parseISOTime(timeString)
+	if err != nil {
+		err = diagnoseLookalike(err)
+	}
+	return components, tz, err
+}
+
+// timeElements names the components filled in by parseISOTime, for use in a ParseError.
+var timeElements = [...]string{"hour", "minute", "second", "fraction"}
+
+// parseISOTime does the work for ParseISOTime and ParseISOTimeParts.
+// `hasOffset` reports whether a time zone portion was present.
+func parseISOTime(timeString string) (components [4]int, tz *time.Location, hasOffset bool, err error) {
 	tz = time.Local
 	length := len(timeString)
 	// `comp` represents the current index for `components` as we proceed through
 	pos, comp := 0, -1
 
 	if length < 2 {
-		return components, tz, &ParseError{timeString, "length of time string must be >= 2"}
+		return components, tz, hasOffset, &ParseError{timeString, "length of time string must be >= 2", 0, "hour", ErrSyntax}
 	}
 
 	hasSep := length >= 3 && timeString[2] == timeSep
@@ -592,15 +869,24 @@ func ParseISOTime(timeString string) (components [4]int, tz *time.Location, err
 			// Timezone "boundary" detected
 			tz, err = parseTimezone(timeString[pos:])
 			if err != nil {
-				return components, tz, err
+				e := err.(*ParseError)
+				e.Datetime, e.Pos = timeString, e.Pos+pos
+				return components, tz, hasOffset, e
 			}
+			hasOffset = true
 			pos = length
 			break
 		}
 
 		if comp < 3 {
 			// Hour, minute, second
-			components[comp], _ = strconv.Atoi(timeString[pos : pos+2])
+			var ok bool
+			if length-pos >= 2 {
+				components[comp], ok = parseDigits(timeString[pos : pos+2])
+			}
+			if !ok {
+				return components, tz, hasOffset, &ParseError{timeString, "time components must be two digits", pos, timeElements[comp], ErrSyntax}
+			}
 			pos += 2
 			if hasSep && pos < length && timeString[pos] == timeSep {
 				pos += 1
@@ -608,9 +894,16 @@ func ParseISOTime(timeString string) (components [4]int, tz *time.Location, err
 		}
 
 		if comp == 3 {
-			// Second fraction (optional)
-			frac := fractionRegex.FindStringSubmatch(timeString[pos:])
-			if frac == nil {
+			// Second fraction (optional): a period or comma, followed by 1 or more digits.
+			// This is scanned by hand rather than with a regexp, since it is on the hot path.
+			if pos >= length || (timeString[pos] != '.' && timeString[pos] != ',') {
+				continue
+			}
+			end := pos + 1
+			for end < length && isDigit(timeString[end]) {
+				end++
+			}
+			if end == pos+1 {
 				continue
 			}
 
@@ -621,19 +914,16 @@ func ParseISOTime(timeString string) (components [4]int, tz *time.Location, err
 			// We do not raise if caller tries to pass 10 or more digits; we simply chop off to 9.
 			// For example, .3684000309 seconds becomes 368400030 nanoseconds
 			//
-			// Unlike Python, need to make sure we aren't slicing the fraction digits
-			// outside the string capacity. There is probably a more idiomatic way to do this slicing.
-			secondsFrac, _ := strconv.ParseFloat("0."+frac[1][:min(9, len(frac[1]))], 64)
-			// We have float seconds, convert to nanoseconds (10 ** 9 seconds).
+			// The digits are accumulated as an integer and scaled up to nanoseconds, rather than
+			// going through a float, which would turn .512419 into 512418999 nanoseconds.
 			// Note that there is no rounding done here, just truncation.
-			// time.Nanosecond (and other constants) are available but not really needed.
-			components[comp] = int(secondsFrac * 1e9)
-			pos += len(frac[0])
+			components[comp] = fractionNanos(timeString[pos+1 : end])
+			pos = end
 		}
 	}
 
 	if pos < length {
-		return components, tz, &ParseError{timeString, "unused components"}
+		return components, tz, hasOffset, &ParseError{timeString, "unused components", pos, "", ErrTrailingData}
 	}
 
 	if components[0] == 24 {
@@ -641,7 +931,7 @@ func ParseISOTime(timeString string) (components [4]int, tz *time.Location, err
 			// Standard supports 00:00 and 24:00 as representations of midnight
 			// But this means no minutes may be attached with hour 24
 			if i != 0 {
-				return components, tz, &ParseError{timeString, "hour == 24 implies 0 for other time units"}
+				return components, tz, hasOffset, &ParseError{timeString, "hour == 24 implies 0 for other time units", 0, "hour", ErrTimeRange}
 			}
 		}
 		// Otherwise, we don't need to set to 0.  This is the only time we want to take advantage of
@@ -653,7 +943,7 @@ func ParseISOTime(timeString string) (components [4]int, tz *time.Location, err
 	// - time.Local is, roughly, the zero value for time.Location; it is just `var localLoc Location; var Local *Location = &localLoc`
 	// - time.UTC is `var utcLoc = Location{name: "UTC"}; var UTC *Location = &utcLoc`
 	// - String() for the time.Location zero value will return time.UTC; see also `func (l *Location) get()`
-	return components, tz, nil
+	return components, tz, hasOffset, nil
 }
 
 // ParseISODatetime parses an ISO-8601 datetime (combined date and time string).
@@ -668,53 +958,39 @@ func ParseISOTime(timeString string) (components [4]int, tz *time.Location, err
 //
 // If no timezone/offset is detected (either with 'Z' or an hh[:mm] offset), the result will
 // have loc time.Local.
+//
+// The whole string must be consumed; see Parser.Parse for how trailing content is
+// reported, and ParsePrefix for parsing a datetime at the start of a longer string.
+//
+// It is a thin wrapper around Parser.Parse for a Parser with default options.
 func ParseISODatetime(datetime string) (time.Time, error) {
-	// Date first
-	// We get position to know where the date stops
-	dateParts, pos, err := parseISODate(datetime)
-	if err != nil {
-		// Stop here, and keep just the dateString in the ParseError message.
-		return time.Time{}, err
+	return defaultParser.Parse(datetime)
+}
+
+// ParseISODatetimeInLocation is like ParseISODatetime but, in the manner of
+// time.ParseInLocation, attaches loc rather than time.Local to datetimes that have no UTC
+// offset.  Datetimes with an explicit offset keep it.  It panics if loc is nil.
+//
+// It is equivalent to NewParser(WithLocation(loc)).Parse(datetime).
+func ParseISODatetimeInLocation(datetime string, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		panic("isoparse: ParseISODatetimeInLocation called with nil location")
 	}
+	p := Parser{loc: loc}
+	return p.Parse(datetime)
+}
 
-	var (
-		hour, minute, second, nsec int
-		tz                         *time.Location // time.UTC zero value
-	)
-
-	// If len(datetime) > pos, it appears we have a time portion
-	// If len(datetime) < pos, something's gone very wrong with parseISODate
-	// If they're equal, we just have a (seemingly valid) date
-
-	if len(datetime) > pos {
-		// Make sure the sep between date and time (strictly just "T") is a non-numeric ASCII character.
-		// This means: 0 thru 127 except 48 thru 57 in decimal.
-		if sep := datetime[pos]; (sep >= 0 && sep < 48) || (sep > 47 && sep <= 127) {
-			var (
-				timeParts [4]int
-				err       error
-			)
-			timeParts, tz, err = ParseISOTime(datetime[pos+1:])
-			if err != nil {
-				tz = time.Local
-				// Only erring out because we were signaled that a time portion should be there.
-				// Note that passing nil for tz will cause time.Date to panic.
-				return time.Date(1, 1, 1, 0, 0, 0, 0, tz), err
-			}
-			hour, minute, second, nsec = timeParts[0], timeParts[1], timeParts[2], timeParts[3]
-		} else {
-			tz = time.Local
-			return time.Date(1, 1, 1, 0, 0, 0, 0, tz), &ParseError{datetime, "date/time separator must be a non-numeric ASCII character"}
-		}
+// utcParser backs ParseISODatetimeUTC.
+var utcParser = &Parser{loc: time.UTC}
 
-	} else if len(datetime) < pos {
-		// This really shouldn't be reached, but represents a case where the
-		// position cursor moved past the entire string in parsing just the date.
-		return time.Time{}, &ParseError{Datetime: datetime}
-	}
-	// We need to be very careful about passing the zero value for time.Location here
-	res, err := strictDate(dateParts[0], time.Month(dateParts[1]), dateParts[2], hour, minute, second, nsec, tz)
-	return res, err
+// ParseISODatetimeUTC is like ParseISODatetime, but returns the instant in UTC whatever
+// the string looked like: a datetime with a UTC offset is converted to UTC, and one
+// without is read as UTC in the first place, rather than as time.Local.
+//
+// It is equivalent to ParseISODatetimeInLocation(datetime, time.UTC) followed by UTC.
+func ParseISODatetimeUTC(datetime string) (time.Time, error) {
+	t, err := utcParser.Parse(datetime)
+	return t.UTC(), err
 }
 
 // Note that this differs from time.Time.In or time.Time.UTC in that it does not change the
@@ -14,6 +14,7 @@ package isoparse
 // 		discussion of equality testing for Time values.
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 	"time"
@@ -140,38 +141,38 @@ var timesWithComponents = map[string][4]int{
 var tzStrings = map[string]*time.Location{
 	"+0000":  time.UTC,
 	"+00:00": time.UTC,
-	"-0002":  time.FixedZone("UTC", -120),
-	"-00:02": time.FixedZone("UTC", -120),
-	"+0002":  time.FixedZone("UTC", 120),
-	"+00:02": time.FixedZone("UTC", 120),
-	"-0015":  time.FixedZone("UTC", -900),
-	"-00:15": time.FixedZone("UTC", -900),
-	"+0015":  time.FixedZone("UTC", 900),
-	"+00:15": time.FixedZone("UTC", 900),
-	"-0500":  time.FixedZone("UTC", -18000),
-	"-05:00": time.FixedZone("UTC", -18000),
-	"+0500":  time.FixedZone("UTC", 18000),
-	"+05:00": time.FixedZone("UTC", 18000),
-	"-0502":  time.FixedZone("UTC", -18120),
-	"-05:02": time.FixedZone("UTC", -18120),
-	"+0502":  time.FixedZone("UTC", 18120),
-	"+05:02": time.FixedZone("UTC", 18120),
-	"-0515":  time.FixedZone("UTC", -18900),
-	"-05:15": time.FixedZone("UTC", -18900),
-	"+0515":  time.FixedZone("UTC", 18900),
-	"+05:15": time.FixedZone("UTC", 18900),
-	"-2300":  time.FixedZone("UTC", -82800),
-	"-23:00": time.FixedZone("UTC", -82800),
-	"+2300":  time.FixedZone("UTC", 82800),
-	"+23:00": time.FixedZone("UTC", 82800),
-	"-2302":  time.FixedZone("UTC", -82920),
-	"-23:02": time.FixedZone("UTC", -82920),
-	"+2302":  time.FixedZone("UTC", 82920),
-	"+23:02": time.FixedZone("UTC", 82920),
-	"-2315":  time.FixedZone("UTC", -83700),
-	"-23:15": time.FixedZone("UTC", -83700),
-	"+2315":  time.FixedZone("UTC", 83700),
-	"+23:15": time.FixedZone("UTC", 83700),
+	"-0002":  offsetZone(-120),
+	"-00:02": offsetZone(-120),
+	"+0002":  offsetZone(120),
+	"+00:02": offsetZone(120),
+	"-0015":  offsetZone(-900),
+	"-00:15": offsetZone(-900),
+	"+0015":  offsetZone(900),
+	"+00:15": offsetZone(900),
+	"-0500":  offsetZone(-18000),
+	"-05:00": offsetZone(-18000),
+	"+0500":  offsetZone(18000),
+	"+05:00": offsetZone(18000),
+	"-0502":  offsetZone(-18120),
+	"-05:02": offsetZone(-18120),
+	"+0502":  offsetZone(18120),
+	"+05:02": offsetZone(18120),
+	"-0515":  offsetZone(-18900),
+	"-05:15": offsetZone(-18900),
+	"+0515":  offsetZone(18900),
+	"+05:15": offsetZone(18900),
+	"-2300":  offsetZone(-82800),
+	"-23:00": offsetZone(-82800),
+	"+2300":  offsetZone(82800),
+	"+23:00": offsetZone(82800),
+	"-2302":  offsetZone(-82920),
+	"-23:02": offsetZone(-82920),
+	"+2302":  offsetZone(82920),
+	"+23:02": offsetZone(82920),
+	"-2315":  offsetZone(-83700),
+	"-23:15": offsetZone(-83700),
+	"+2315":  offsetZone(83700),
+	"+23:15": offsetZone(83700),
 }
 
 // Invalid ISO strings per 2004 standard
@@ -192,6 +193,7 @@ var invalidDates = []string{
 	"201404-23",   // Inconsistent date separators
 	"2014日03月14",  // Not ASCII
 	"2013-02-29",  // Invalid day
+	"2013-02-00",  // Invalid day
 	"2014/12/03",  // Wrong separators
 	"2014-04-19T", // Unknown components
 }
@@ -209,6 +211,7 @@ var invalidDatetimes = []string{
 	"20120411T03:30+1234567",       // Time zone too long
 	"20120411T03:30-25:40",         // Time zone invalid
 	"2012-1a",                      // Invalid month
+	"2012-04-00T12:00",             // Invalid day
 	"20120411T03:30+00:60",         // Time zone invalid minutes
 	"20120411T03:30+00:61",         // Time zone invalid minutes
 	"20120411T033030.123456012:00", // No sign in time zone
@@ -221,6 +224,7 @@ var invalidDatetimes = []string{
 	"2013366",                      // Invalid ordinal day
 	"2014-03-12Т12:30:14",          // Cyrillic T
 	"2014-04-21T24:00:01",          // Invalid use of 24 for midnight
+	"2014-04-21T24:00:01Z",         // Invalid use of 24 for midnight
 	"2014_W01-1",                   // Invalid separator
 	"1985-102☐10:15Z",              // Invalid separator
 	"2014W01-1",                    // Inconsistent use of dashes
@@ -249,6 +253,9 @@ var invalidTzStrings = []string{
 	"05:00",   // No sign
 	"_00:00",  // Invalid sign
 	"00:0000", // # String too long
+	"+ab:cd",  // Not digits
+	"+05-00",  // Invalid separator
+	"Zulu",    // Trailing characters after Z
 }
 
 var zeroTzs = []string{
@@ -350,18 +357,18 @@ var allFormats = map[string]connector{
 	// (combinatorial product) between valid dates and valid times.
 	"19850412T101530":           {t: time.Date(1985, time.Month(4), 12, 10, 15, 30, 0, time.Local), f: "YYYYMMDDTHHMMSS"},
 	"19850412T101530Z":          {t: time.Date(1985, time.Month(4), 12, 10, 15, 30, 0, time.UTC), f: "YYYYMMDDTHHMMSSZ"},
-	"19850412T101530+0400":      {t: time.Date(1985, time.Month(4), 12, 10, 15, 30, 0, time.FixedZone("UTC", int(4*60*60))), f: "YYYYMMDDTHHMMSS±hhmm"},
-	"19850412T101530+04":        {t: time.Date(1985, time.Month(4), 12, 10, 15, 30, 0, time.FixedZone("UTC", int(4*60*60))), f: "YYYYMMDDTHHMMSS±hh"},
+	"19850412T101530+0400":      {t: time.Date(1985, time.Month(4), 12, 10, 15, 30, 0, offsetZone(int(4*60*60))), f: "YYYYMMDDTHHMMSS±hhmm"},
+	"19850412T101530+04":        {t: time.Date(1985, time.Month(4), 12, 10, 15, 30, 0, offsetZone(int(4*60*60))), f: "YYYYMMDDTHHMMSS±hh"},
 	"1985-04-12T10:15:30":       {t: time.Date(1985, time.Month(4), 12, 10, 15, 30, 0, time.Local), f: "YYYYMMDDTHH:MM:SS"},
 	"1985-04-12T10:15:30Z":      {t: time.Date(1985, time.Month(4), 12, 10, 15, 30, 0, time.UTC), f: "YYYYMMDDTHH:MM:SSZ"},
-	"1985-04-12T10:15:30+04:00": {t: time.Date(1985, time.Month(4), 12, 10, 15, 30, 0, time.FixedZone("UTC", int(4*60*60))), f: "YYYYMMDDTHH:MM:SS±hhmm"},
-	"1985-04-12T10:15:30+04":    {t: time.Date(1985, time.Month(4), 12, 10, 15, 30, 0, time.FixedZone("UTC", int(4*60*60))), f: "YYYYMMDDTHH:MM:SS±hh"},
+	"1985-04-12T10:15:30+04:00": {t: time.Date(1985, time.Month(4), 12, 10, 15, 30, 0, offsetZone(int(4*60*60))), f: "YYYYMMDDTHH:MM:SS±hhmm"},
+	"1985-04-12T10:15:30+04":    {t: time.Date(1985, time.Month(4), 12, 10, 15, 30, 0, offsetZone(int(4*60*60))), f: "YYYYMMDDTHH:MM:SS±hh"},
 	"19850412T1015":             {t: time.Date(1985, time.Month(4), 12, 10, 15, 0, 0, time.Local), f: "YYYY-MM-DDTHHMM"},
 	"1985-04-12T10:15":          {t: time.Date(1985, time.Month(4), 12, 10, 15, 0, 0, time.Local), f: "YYYY-MM-DDTHHMM"},
 	"1985102T1015Z":             {t: time.Date(1985, time.Month(4), 12, 10, 15, 0, 0, time.UTC), f: "YYYYDDDDTHHMMZ"},
 	"1985-102T10:15Z":           {t: time.Date(1985, time.Month(4), 12, 10, 15, 0, 0, time.UTC), f: "YYYY-DDD:MMZ"},
-	"1985W155T1015+0400":        {t: time.Date(1985, time.Month(4), 12, 10, 15, 0, 0, time.FixedZone("UTC", int(4*60*60))), f: "YYYY-WwwTHH:MMZ"},
-	"1985-W15-5T10:15+04":       {t: time.Date(1985, time.Month(4), 12, 10, 15, 0, 0, time.FixedZone("UTC", int(4*60*60))), f: "YYYY-Www-DTHH:MM±hh"},
+	"1985W155T1015+0400":        {t: time.Date(1985, time.Month(4), 12, 10, 15, 0, 0, offsetZone(int(4*60*60))), f: "YYYY-WwwTHH:MMZ"},
+	"1985-W15-5T10:15+04":       {t: time.Date(1985, time.Month(4), 12, 10, 15, 0, 0, offsetZone(int(4*60*60))), f: "YYYY-Www-DTHH:MM±hh"},
 }
 
 // //////////////////////////////////////////////////
@@ -378,6 +385,21 @@ func TestStrictDate(t *testing.T) {
 	}
 }
 
+func TestStrictDateError(t *testing.T) {
+	_, err := strictDate(2018, 2, 30, 5, 0, 0, 0, time.UTC)
+	want := "cannot parse 2018-02-30T05:00:00.000000000UTC: day out of valid range (day)"
+	if err == nil || err.Error() != want {
+		t.Errorf(`strictDate(2018, 2, 30, ...) -> %v (should be %v)`, err, want)
+	}
+	if !errors.Is(err, ErrInvalidDay) {
+		t.Errorf(`strictDate(2018, 2, 30, ...) -> %v (should wrap ErrInvalidDay)`, err)
+	}
+	err = locateElement("2018-02-30T05:00:00Z", 11, err)
+	if e, ok := err.(*ParseError); !ok || e.Datetime != "2018-02-30T05:00:00Z" || e.Pos != 8 {
+		t.Errorf(`locateElement(...) -> %#v (should be a ParseError for the day at byte 8)`, err)
+	}
+}
+
 func TestIsLeapYear(t *testing.T) {
 	for _, year := range leapYears {
 		if !isLeapYear(year) {
@@ -491,6 +513,38 @@ func TestParseTimezone(t *testing.T) {
 	}
 }
 
+func TestParseISODatetimeInLocation(t *testing.T) {
+	loc := offsetZone(-7 * 60 * 60)
+	naive, err := ParseISODatetimeInLocation("2018-09-27T11:52:59", loc)
+	if want := time.Date(2018, 9, 27, 11, 52, 59, 0, loc); err != nil || !naive.Equal(want) || naive.Location() != loc {
+		t.Errorf(`ParseISODatetimeInLocation("2018-09-27T11:52:59", %v) -> %v, %v (should be %v)`, loc, naive, err, want)
+	}
+	explicit, err := ParseISODatetimeInLocation("2018-09-27T11:52:59Z", loc)
+	if want := time.Date(2018, 9, 27, 11, 52, 59, 0, time.UTC); err != nil || !explicit.Equal(want) || explicit.Location() != time.UTC {
+		t.Errorf(`ParseISODatetimeInLocation("2018-09-27T11:52:59Z", %v) -> %v, %v (should be %v)`, loc, explicit, err, want)
+	}
+	if _, err := ParseISODatetimeInLocation("2018-13-01", loc); err == nil {
+		t.Errorf(`ParseISODatetimeInLocation("2018-13-01", %v) returned nil error`, loc)
+	}
+}
+
+func TestParseISODatetimeUTC(t *testing.T) {
+	for s, want := range map[string]time.Time{
+		"2018-09-27T11:52:59":                            time.Date(2018, 9, 27, 11, 52, 59, 0, time.UTC),
+		"2018-09-27T11:52:59Z":                           time.Date(2018, 9, 27, 11, 52, 59, 0, time.UTC),
+		"2018-09-27T11:52:59+05:30":                      time.Date(2018, 9, 27, 6, 22, 59, 0, time.UTC),
+		"2018-09-27":                                     time.Date(2018, 9, 27, 0, 0, 0, 0, time.UTC),
+		"2018-09-27T11:52:59-07:00[America/Los_Angeles]": time.Date(2018, 9, 27, 18, 52, 59, 0, time.UTC),
+	} {
+		if got, err := ParseISODatetimeUTC(s); err != nil || !got.Equal(want) || got.Location() != time.UTC {
+			t.Errorf(`ParseISODatetimeUTC(%q) -> %v, %v (should be %v)`, s, got, err, want)
+		}
+	}
+	if got, err := ParseISODatetimeUTC("2018-13-01"); err == nil || !got.IsZero() {
+		t.Errorf(`ParseISODatetimeUTC("2018-13-01") -> %v, %v (should be the zero time and an error)`, got, err)
+	}
+}
+
 func TestParseISODatetime(t *testing.T) {
 	for datetime, c := range allFormats {
 		if dt, err := ParseISODatetime(datetime); err != nil {
@@ -561,6 +615,37 @@ func TestUnequalISOWeekDay(t *testing.T) {
 	}
 }
 
+func TestOffsetZoneName(t *testing.T) {
+	names := map[int]string{
+		5*60*60 + 30*60: "UTC+05:30",
+		-8 * 60 * 60:    "UTC-08:00",
+		-(9*60 + 30):    "UTC-00:09:30",
+	}
+	for secondsEast, trueName := range names {
+		if name, offset := time.Date(2018, 9, 27, 0, 0, 0, 0, offsetZone(secondsEast)).Zone(); name != trueName || offset != secondsEast {
+			t.Errorf(`offsetZone(%d) -> %q, %d (should be %q, %d)`, secondsEast, name, offset, trueName, secondsEast)
+		}
+	}
+	if tz, _ := parseTimezone("+05:30"); tz.String() != "UTC+05:30" {
+		t.Errorf(`parseTimezone("+05:30") -> %v (should be named UTC+05:30)`, tz)
+	}
+}
+
+func TestParseISOOffset(t *testing.T) {
+	for tzString, trueTZ := range tzStrings {
+		if tz, err := ParseISOOffset(tzString); err != nil {
+			t.Errorf(`ParseISOOffset(%q) -> non-nil error (%v) for valid tzString`, tzString, err)
+		} else if !reflect.DeepEqual(tz, trueTZ) {
+			t.Errorf(`ParseISOOffset(%q) -> %v (should be %v)`, tzString, tz, trueTZ)
+		}
+	}
+	for _, tzString := range append(invalidTzStrings, "", "+24:00", "-2400", "+05:60", "+5:00", "\u221205:00") {
+		if tz, err := ParseISOOffset(tzString); err == nil {
+			t.Errorf(`ParseISOOffset(%q) -> %v (should be an error)`, tzString, tz)
+		}
+	}
+}
+
 func TestTzZeroUTC(t *testing.T) {
 	for _, tzString := range zeroTzs {
 		if _, err := parseTimezone(tzString); err != nil {
@@ -604,3 +689,184 @@ func TestTruncateNsec(t *testing.T) {
 		}
 	}
 }
+
+func TestParseISOTimeParts(t *testing.T) {
+	for timeString, trueComp := range timesWithComponents {
+		parts, err := ParseISOTimeParts(timeString)
+		if err != nil {
+			t.Errorf(`ParseISOTimeParts(%q) -> non-nil error (%v) for valid time string`, timeString, err)
+			continue
+		}
+		want := TimeOfDay{trueComp[0], trueComp[1], trueComp[2], trueComp[3]}
+		if parts.TimeOfDay != want || parts.Hour != trueComp[0] {
+			t.Errorf(`ParseISOTimeParts(%q) -> %v (should be %v)`, timeString, parts.TimeOfDay, want)
+		}
+	}
+	for timeString, hasOffset := range map[string]bool{"13:47:30": false, "134730": false, "13:47:30Z": true, "13:47:30-05:00": true} {
+		if parts, err := ParseISOTimeParts(timeString); err != nil {
+			t.Errorf(`ParseISOTimeParts(%q) -> non-nil error (%v) for valid time string`, timeString, err)
+		} else if parts.HasOffset != hasOffset {
+			t.Errorf(`ParseISOTimeParts(%q).HasOffset -> %v (should be %v)`, timeString, parts.HasOffset, hasOffset)
+		} else if !hasOffset && parts.Loc != time.Local {
+			t.Errorf(`ParseISOTimeParts(%q).Loc -> %v (should be time.Local)`, timeString, parts.Loc)
+		}
+	}
+	for _, timeString := range invalidTimes {
+		if parts, err := ParseISOTimeParts(timeString); err == nil {
+			t.Errorf(`ParseISOTimeParts(%q) -> %v returned nil error (invalid timeString should error)`, timeString, parts)
+		}
+	}
+}
+
+func TestParseErrorPosition(t *testing.T) {
+	cases := []struct {
+		s       string
+		pos     int
+		element string
+	}{
+		{"2013-02-29", 8, "day"},
+		{"20130229", 6, "day"},
+		{"2013-13-01", 5, "month"},
+		{"2013-W54-1", 6, "week"},
+		{"2013W548", 5, "week"},
+		{"2013-W01-8", 9, "weekday"},
+		{"2013-366", 5, "ordinal-day"},
+		{"2013-01-01T25:00", 11, "hour"},
+		{"2013-01-01T12:60:00Z", 14, "minute"},
+		{"20130101T126000", 11, "minute"},
+		{"2013-01-01T12:00:61", 17, "second"},
+		{"2013-01-01T12:00+05:61", 20, "offset-minutes"},
+		{"2013-01-01T12:6x", 14, "minute"},
+		{"2013-01-01T12:00[Nowhere/Special]", 17, "zone"},
+		{"2013-01-01T12:00Z[u-ca=iso8601][!x-foo=bar]", 31, "annotation"},
+	}
+	for _, c := range cases {
+		_, err := ParseISODatetime(c.s)
+		e, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf(`ParseISODatetime(%q) -> %v (should be a *ParseError)`, c.s, err)
+			continue
+		}
+		if e.Pos != c.pos || e.Element != c.element {
+			t.Errorf(`ParseISODatetime(%q) -> %s at %d (should be %s at %d)`, c.s, e.Element, e.Pos, c.element, c.pos)
+		}
+	}
+
+	if _, err := ParseISODate("2013-02-29"); err == nil || err.Error() != "cannot parse 2013-02-29: day out of valid range (day at byte 8)" {
+		t.Errorf(`ParseISODate("2013-02-29") -> %v`, err)
+	}
+	if _, err := ParseISODuration("P1Y2X"); err == nil || err.(*ParseError).Pos != 4 {
+		t.Errorf(`ParseISODuration("P1Y2X") -> %v (should fail at byte 4)`, err)
+	}
+}
+
+func TestParseErrorIs(t *testing.T) {
+	cases := map[string]error{
+		"2013-01-01Tab":             ErrSyntax,
+		"2013-02-29":                ErrInvalidDay,
+		"2013-13-01":                ErrInvalidMonth,
+		"2013-W54":                  ErrInvalidWeek,
+		"2013-01-01T24:30":          ErrTimeRange,
+		"2013-01-01T12:00:00+25:00": ErrInvalidOffset,
+		"2013-01-01T12:00:00x":      ErrTrailingData,
+		"2013-01-01T12:00[Nowhere]": ErrUnknownZone,
+	}
+	for s, want := range cases {
+		_, err := ParseISODatetime(s)
+		if !errors.Is(err, want) {
+			t.Errorf(`ParseISODatetime(%q) -> %v (should wrap %v)`, s, err, want)
+		}
+	}
+	if _, err := ParseISOOffset(""); !errors.Is(err, ErrMissingOffset) {
+		t.Errorf(`ParseISOOffset("") -> %v (should wrap %v)`, err, ErrMissingOffset)
+	}
+	if _, err := ParseISODuration("PT1.5HS"); errors.Is(err, ErrTimeRange) || !errors.Is(err, ErrSyntax) {
+		t.Errorf(`ParseISODuration("PT1.5HS") -> %v (should wrap only %v)`, err, ErrSyntax)
+	}
+}
+
+func TestParseErrorKind(t *testing.T) {
+	cases := map[string]ErrorKind{
+		"2013-01-01Tab":                     ErrorKindSyntax,
+		"2013-01-01T12:00:00x":              ErrorKindSyntax,
+		"2013-02-29":                        ErrorKindRange,
+		"2013-01-01T12:00:00+25:00":         ErrorKindRange,
+		"2013-01-01T12:00[Nowhere/Special]": ErrorKindUnsupported,
+		"2013-01-01T12:00Z[!u-ca=hebrew]":   ErrorKindUnsupported,
+	}
+	for s, want := range cases {
+		_, err := ParseISODatetime(s)
+		e, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf(`ParseISODatetime(%q) -> %v (should be a *ParseError)`, s, err)
+		} else if e.Kind() != want {
+			t.Errorf(`ParseISODatetime(%q).Kind() -> %v (should be %v)`, s, e.Kind(), want)
+		}
+	}
+}
+
+func TestParseISOTimePartsRange(t *testing.T) {
+	for timeString, want := range map[string]struct {
+		pos     int
+		element string
+	}{
+		"25:00":    {0, "hour"},
+		"12:60":    {3, "minute"},
+		"1260":     {2, "minute"},
+		"12:30:61": {6, "second"},
+		"123061Z":  {4, "second"},
+	} {
+		_, err := ParseISOTimeParts(timeString)
+		var e *ParseError
+		if !errors.As(err, &e) || !errors.Is(err, ErrTimeRange) || e.Pos != want.pos || e.Element != want.element {
+			t.Errorf(`ParseISOTimeParts(%q) -> %#v (should be a time range error for the %s at byte %d)`, timeString, err, want.element, want.pos)
+		}
+	}
+}
+
+func TestTimePortionErrorContext(t *testing.T) {
+	for datetime, want := range map[string]string{
+		"2013-01-01T12:6x":         `cannot parse 2013-01-01T12:6x: time components must be two digits in time "12:6x" (minute at byte 14)`,
+		"2013-01-01T12:00+05:61":   `cannot parse 2013-01-01T12:00+05:61: offset component out of valid range in time "12:00+05:61" (offset-minutes at byte 20)`,
+		"2013-01-01T1[Asia/Tokyo]": `cannot parse 2013-01-01T1[Asia/Tokyo]: length of time string must be >= 2 in time "1" (hour at byte 11)`,
+	} {
+		_, err := ParseISODatetime(datetime)
+		if err == nil || err.Error() != want {
+			t.Errorf(`ParseISODatetime(%q) -> %v (should be %s)`, datetime, err, want)
+		}
+	}
+}
+
+func TestFractionPrecision(t *testing.T) {
+	// Each of these went wrong by a nanosecond when the fraction was converted via a float.
+	for frac, nsec := range map[string]int{".512419": 512419000, ",512419": 512419000, ".1": 100000000, ".000000001": 1, ".999999999": 999999999, ".29": 290000000} {
+		tm, err := ParseISODatetime("2018-09-27T05:00:00" + frac + "Z")
+		if err != nil || tm.Nanosecond() != nsec {
+			t.Errorf(`ParseISODatetime(%q) -> %v, %v (should have nanosecond %d)`, "2018-09-27T05:00:00"+frac+"Z", tm, err, nsec)
+		}
+	}
+	// The fraction must directly follow the seconds.
+	if tm, err := ParseISODatetime("2018-09-27T05:00:00x.5"); err == nil {
+		t.Errorf(`ParseISODatetime("2018-09-27T05:00:00x.5") -> %v returned nil error`, tm)
+	}
+}
+
+func TestOffsetZoneCache(t *testing.T) {
+	if offsetZone(-8*60*60) != offsetZone(-8*60*60) {
+		t.Errorf(`offsetZone(-8h) is not cached`)
+	}
+}
+
+func TestDigitsOnly(t *testing.T) {
+	// strconv.Atoi would have accepted the signs in each of these.
+	for _, datetime := range []string{"2018-+1-01", "2018-01-+1", "+018-01-01", "-018", "2018-W+1", "2018-W01-+", "2018-+12", "2018-09-27T+1:00", "2018-09-27T12:00+-1:00", "P+1D"} {
+		if tm, err := ParseISODatetime(datetime); err == nil {
+			t.Errorf(`ParseISODatetime(%q) -> %v returned nil error`, datetime, tm)
+		} else if !errors.Is(err, ErrSyntax) && !errors.Is(err, ErrInvalidOffset) {
+			t.Errorf(`ParseISODatetime(%q) -> %v (should be a syntax error)`, datetime, err)
+		}
+	}
+	if _, err := ParseISODatetime("not a timestamp"); !errors.Is(err, ErrSyntax) {
+		t.Errorf(`ParseISODatetime("not a timestamp") -> %v (should wrap %v)`, err, ErrSyntax)
+	}
+}
@@ -0,0 +1,135 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"strings"
+	"time"
+)
+
+// RFC 9557 suffixes
+//
+// RFC 9557 (the Internet Extended Date/Time Format, IXDTF) extends RFC 3339 with bracketed
+// annotations after the offset, such as "2022-07-08T00:14:07+01:00[Europe/Paris]".  The
+// first annotation may name a time zone, either an IANA zone or an offset "±hh:mm"; the
+// rest are key=value tags, such as "[u-ca=gregory]".  A leading "!" marks an annotation as
+// critical, meaning it must not be ignored.
+//
+// Parser.Parse accepts these suffixes and attaches the named zone to its result:
+//   - With a numeric offset, the zone must agree with it at that instant, or it is an error.
+//     (RFC 9557 leaves elective inconsistencies up to the application; this package rejects
+//     them regardless, since they almost always indicate stale tz data or a bug upstream.)
+//   - With "Z", which RFC 9557 reads as "UTC is known, the local offset is not", any zone
+//     is consistent, and the result is simply the instant in that zone.
+//   - With no offset at all, the wall-clock time is read in the named zone, resolving DST
+//     gaps and overlaps according to the Parser's DSTPolicy.
+//
+// Named zones are loaded with time.LoadLocation, so they need either the system's zoneinfo
+// files or an embedded database; see the tzdata subpackage.
+//
+// The only tag understood is u-ca (calendar), and only for the ISO 8601 calendar that this
+// package implements.  Other elective tags are ignored; other critical tags are errors.
+
+// splitIXDTF splits any RFC 9557 annotations off the end of datetime.
+// `zone` is the time zone annotation, without brackets or "!", or empty if there is none.
+func splitIXDTF(datetime string) (rest, zone string, err error) {
+	start := strings.IndexByte(datetime, '[')
+	if start < 0 {
+		return datetime, "", nil
+	}
+	rest = datetime[:start]
+	for i, s := 0, datetime[start:]; s != ""; i++ {
+		pos := len(datetime) - len(s)
+		end := strings.IndexByte(s, ']')
+		if s[0] != '[' || end < 0 {
+			return rest, zone, &ParseError{datetime, "malformed bracketed annotation", pos, "annotation", ErrSyntax}
+		}
+		content := s[1:end]
+		s = s[end+1:]
+		critical := strings.HasPrefix(content, "!")
+		if critical {
+			content = content[1:]
+		}
+		eq := strings.IndexByte(content, '=')
+		if eq < 0 {
+			if i != 0 || content == "" {
+				return rest, zone, &ParseError{datetime, "time zone annotation must come first", pos, "annotation", ErrSyntax}
+			}
+			zone = content
+			continue
+		}
+		key, value := content[:eq], content[eq+1:]
+		if !validIXDTFKey(key) || value == "" {
+			return rest, zone, &ParseError{datetime, "malformed annotation " + content, pos, "annotation", ErrSyntax}
+		}
+		if key == "u-ca" && (value == "iso8601" || value == "gregory") {
+			continue
+		}
+		if critical {
+			return rest, zone, &ParseError{datetime, "unsupported critical annotation " + content, pos, "annotation", ErrUnsupported}
+		}
+	}
+	return rest, zone, nil
+}
+
+// validIXDTFKey reports whether key is a suffix key as defined by RFC 9557: a lowercase
+// letter or underscore, followed by lowercase letters, digits, underscores, or hyphens.
+func validIXDTFKey(key string) bool {
+	if key == "" || !(key[0] == '_' || ('a' <= key[0] && key[0] <= 'z')) {
+		return false
+	}
+	for i := 1; i < len(key); i++ {
+		if c := key[i]; !(c == '_' || c == '-' || isDigit(c) || ('a' <= c && c <= 'z')) {
+			return false
+		}
+	}
+	return true
+}
+
+// loadIXDTFZone loads a time zone annotation: an IANA name, or an offset of the form ±hh:mm.
+func loadIXDTFZone(datetime, zone string) (*time.Location, error) {
+	pos := strings.Index(datetime, zone)
+	if zone[0] == '+' || zone[0] == '-' {
+		if len(zone) != len("+hh:mm") || zone[3] != ':' {
+			return nil, &ParseError{datetime, "offset annotation must be ±hh:mm", pos, "zone", ErrInvalidOffset}
+		}
+		loc, err := parseTimezone(zone)
+		if err != nil {
+			e := err.(*ParseError)
+			e.Datetime, e.Pos = datetime, e.Pos+pos
+			return nil, e
+		}
+		return loc, nil
+	}
+	if zone == "Local" {
+		// time.LoadLocation would return time.Local, which is not an IANA zone.
+		return nil, &ParseError{datetime, "unknown time zone " + zone, pos, "zone", ErrUnknownZone}
+	}
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, &ParseError{datetime, "unknown time zone " + zone, pos, "zone", ErrUnknownZone}
+	}
+	return loc, nil
+}
+
+// applyIXDTFZone attaches the named zone to the result of parsing rest.
+func (p *Parser) applyIXDTFZone(datetime, rest string, parts datetimeParts, zone string) (time.Time, error) {
+	loc, err := loadIXDTFZone(datetime, zone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !parts.hasOffset {
+		return p.resolveParts(datetime, parts, loc)
+	}
+	t, err := strictDate(parts.date[0], time.Month(parts.date[1]), parts.date[2], parts.time[0], parts.time[1], parts.time[2], parts.time[3], parts.tz)
+	if err != nil {
+		return time.Time{}, parts.locate(datetime, err)
+	}
+	_, got := t.Zone()
+	t = t.In(loc)
+	if _, want := t.Zone(); got != want && !strings.HasSuffix(rest, "Z") {
+		return time.Time{}, &ParseError{datetime, "offset is inconsistent with time zone " + zone, -1, "offset", ErrOffsetMismatch}
+	}
+	return t, nil
+}
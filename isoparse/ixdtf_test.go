@@ -0,0 +1,54 @@
+package isoparse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseIXDTF(t *testing.T) {
+	paris := loadLocation(t, "Europe/Paris")
+	newYork := loadLocation(t, "America/New_York")
+	cases := map[string]time.Time{
+		"2022-07-08T00:14:07+02:00[Europe/Paris]":               time.Date(2022, 7, 8, 0, 14, 7, 0, paris),
+		"2022-07-08T00:14:07+02:00[!Europe/Paris]":              time.Date(2022, 7, 8, 0, 14, 7, 0, paris),
+		"2022-07-07T22:14:07Z[Europe/Paris]":                    time.Date(2022, 7, 8, 0, 14, 7, 0, paris),
+		"2022-07-08T00:14:07[Europe/Paris]":                     time.Date(2022, 7, 8, 0, 14, 7, 0, paris),
+		"2022-07-08T00:14:07+02:00[Europe/Paris][u-ca=gregory]": time.Date(2022, 7, 8, 0, 14, 7, 0, paris),
+		"2022-07-08T00:14:07+02:00[Europe/Paris][foo=bar]":      time.Date(2022, 7, 8, 0, 14, 7, 0, paris),
+		"2022-07-08T00:14:07+02:00[u-ca=iso8601]":               time.Date(2022, 7, 8, 0, 14, 7, 0, offsetZone(2*60*60)),
+		"2022-07-08T00:14:07+02:00[+02:00]":                     time.Date(2022, 7, 8, 0, 14, 7, 0, offsetZone(2*60*60)),
+		// Nonexistent wall time: shifted forward by the gap.
+		"2022-03-13T02:30:00[America/New_York]": time.Date(2022, 3, 13, 3, 30, 0, 0, newYork),
+	}
+	for s, want := range cases {
+		got, err := ParseISODatetime(s)
+		if err != nil {
+			t.Errorf(`ParseISODatetime(%q) -> non-nil error (%v)`, s, err)
+		} else if !got.Equal(want) || got.Location().String() != want.Location().String() {
+			t.Errorf(`ParseISODatetime(%q) -> %v (should be %v)`, s, got, want)
+		}
+	}
+}
+
+var invalidIXDTF = []string{
+	"2022-07-08T00:14:07+01:00[Europe/Paris]", // Paris is +02:00 in July
+	"2022-07-08T00:14:07+00:00[Europe/Paris]", // Only Z means "offset unknown"
+	"2022-07-08T00:14:07+02:00[Not/A_Zone]",
+	"2022-07-08T00:14:07+02:00[Local]",
+	"2022-07-08T00:14:07+02:00[+0200]", // Offset annotations need the colon
+	"2022-07-08T00:14:07+02:00[Europe/Paris",
+	"2022-07-08T00:14:07+02:00[]",
+	"2022-07-08T00:14:07+02:00[u-ca=gregory][Europe/Paris]",
+	"2022-07-08T00:14:07+02:00[!u-ca=hebrew]",
+	"2022-07-08T00:14:07+02:00[!foo=bar]",
+	"2022-07-08T00:14:07+02:00[Foo=bar]",
+	"2022-07-08T00:14:07+02:00[Europe/Paris]x",
+}
+
+func TestParseIXDTFInvalid(t *testing.T) {
+	for _, s := range invalidIXDTF {
+		if got, err := ParseISODatetime(s); err == nil {
+			t.Errorf(`ParseISODatetime(%q) -> %v (should be an error)`, s, got)
+		}
+	}
+}
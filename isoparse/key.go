@@ -0,0 +1,51 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import "hash/fnv"
+
+// Layouts for InstantKey: fixed width, so that keys also sort chronologically.
+const (
+	layoutInstantKey = "2006-01-02T15:04:05.000000000Z"
+	layoutNaiveKey   = "2006-01-02T15:04:05.000000000"
+)
+
+// InstantKey returns a key for datetime, in any form accepted by ParseISODatetime, that is
+// the same for every string denoting the same instant, for deduplicating streams of
+// events from systems that write timestamps differently: "2024-01-02T03:04:05+00:00",
+// "20240102T030405Z", "2024-002T03:04:05Z", and "2024-01-02T04:04:05+01:00" all have the
+// key "2024-01-02T03:04:05.000000000Z".  The key is the instant in UTC, with all nine
+// digits of the fraction.
+//
+// A string with neither a UTC offset nor an RFC 9557 time zone doesn't denote an instant,
+// and reading it in time.Local would make its key depend on the machine.  Its key is the
+// wall-clock reading instead, in the same layout but without the "Z", so that it never
+// collides with the key of a string that has an offset.
+//
+// If datetime is invalid, InstantKey returns the error from parsing it.
+func InstantKey(datetime string) (string, error) {
+	t, err := utcParser.Parse(datetime)
+	if err != nil {
+		return "", err
+	}
+	if hasOffset, _ := writtenOffset(datetime); !hasOffset {
+		if _, zone, _ := splitIXDTF(datetime); zone == "" {
+			return t.Format(layoutNaiveKey), nil
+		}
+	}
+	return t.UTC().Format(layoutInstantKey), nil
+}
+
+// InstantHash returns the 64-bit FNV-1a hash of the InstantKey of datetime, for
+// deduplication structures that want a number rather than a string.  Like the key, it is
+// stable across processes and machines.
+func InstantHash(datetime string) (uint64, error) {
+	key, err := InstantKey(datetime)
+	if err != nil {
+		return 0, err
+	}
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return h.Sum64(), nil
+}
@@ -0,0 +1,56 @@
+package isoparse
+
+import (
+	"errors"
+	"testing"
+)
+
+var instantKeys = map[string]string{
+	"2024-01-02T03:04:05+00:00":               "2024-01-02T03:04:05.000000000Z",
+	"20240102T030405Z":                        "2024-01-02T03:04:05.000000000Z",
+	"2024-002T03:04:05Z":                      "2024-01-02T03:04:05.000000000Z",
+	"2024-W01-2T03:04:05Z":                    "2024-01-02T03:04:05.000000000Z",
+	"2024-01-02T04:04:05+01:00":               "2024-01-02T03:04:05.000000000Z",
+	"2024-01-01T22:04:05-05":                  "2024-01-02T03:04:05.000000000Z",
+	"2024-01-02T03:04:05,000Z":                "2024-01-02T03:04:05.000000000Z",
+	"2024-01-02T04:04:05[Europe/Paris]":       "2024-01-02T03:04:05.000000000Z",
+	"2024-01-02T03:04:05.5Z":                  "2024-01-02T03:04:05.500000000Z",
+	"2024-01-02T03:04:05":                     "2024-01-02T03:04:05.000000000",
+	"20240102T030405":                         "2024-01-02T03:04:05.000000000",
+	"2024-01-02":                              "2024-01-02T00:00:00.000000000",
+	"2024-01-01T24:00Z":                       "2024-01-02T00:00:00.000000000Z",
+	"2024-01-02T04:04:05+01:00[u-ca=gregory]": "2024-01-02T03:04:05.000000000Z",
+}
+
+func TestInstantKey(t *testing.T) {
+	for s, want := range instantKeys {
+		if got, err := InstantKey(s); err != nil || got != want {
+			t.Errorf(`InstantKey(%q) -> (%q, %v) (should be %q)`, s, got, err, want)
+		}
+	}
+	if _, err := InstantKey("2024-02-30T00:00Z"); !errors.Is(err, ErrInvalidDay) {
+		t.Errorf(`InstantKey("2024-02-30T00:00Z") -> %v (should wrap %v)`, err, ErrInvalidDay)
+	}
+}
+
+func TestInstantHash(t *testing.T) {
+	hashes := map[string]uint64{}
+	for s, key := range instantKeys {
+		h, err := InstantHash(s)
+		if err != nil {
+			t.Errorf(`InstantHash(%q) -> %v`, s, err)
+			continue
+		}
+		if other, ok := hashes[key]; ok && other != h {
+			t.Errorf(`InstantHash(%q) -> %#x (should be %#x, as for the same key)`, s, h, other)
+		}
+		hashes[key] = h
+	}
+	if len(hashes) != 5 {
+		t.Errorf(`InstantHash gave %d distinct hashes for %d distinct keys`, len(hashes), 5)
+	}
+	// The hash is part of the contract: it must not change between releases.
+	if h, _ := InstantHash("2024-01-02T03:04:05Z"); h != 0x33601757692701aa {
+		t.Errorf(`InstantHash("2024-01-02T03:04:05Z") -> %#x (should be 0x33601757692701aa)`, h)
+	}
+}
@@ -0,0 +1,118 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import "strings"
+
+// dateLayouts holds the Go reference-time layout for each date format that time.Parse
+// can handle.  Every one of them has a fixed width, the same as the strings it matches.
+var dateLayouts = map[Format]string{
+	CalendarDateExtended: "2006-01-02",
+	CalendarDateBasic:    "20060102",
+	YearMonthDate:        "2006-01",
+	YearDate:             "2006",
+	OrdinalDateExtended:  "2006-002",
+	OrdinalDateBasic:     "2006002",
+}
+
+// LayoutOf returns the layout for time.Parse that matches s, which must be a string that
+// DetectFormat accepts.  It lets a format be discovered with this package and then
+// handed to the standard library, say for a hot loop over data that is known to be
+// uniform:
+//
+//	layout, err := isoparse.LayoutOf(rows[0])
+//	...
+//	for _, row := range rows {
+//		t, err := time.Parse(layout, row)
+//
+// A string with a UTC offset gets a layout that also accepts "Z", such as
+// "2006-01-02T15:04:05Z07:00".  A fraction of up to nine digits is matched exactly, as in
+// ".000"; time.Parse reads a longer one without being asked.
+//
+// time.Parse has no layout for week dates, for the hour 24, or for a UTC offset with no
+// time in front of it, so for those LayoutOf returns an error wrapping ErrUnsupported.  Note too that time.Parse gives UTC, rather
+// than time.Local, for a string with no offset.
+func LayoutOf(s string) (string, error) {
+	format, err := DetectFormat(s)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	timeString := s
+	if format != TimeOnly {
+		date, ok := dateLayouts[dateFormatOf(format)]
+		if !ok {
+			return "", &ParseError{s, "time.Parse has no layout for week dates", 4 + btoi(s[4] == dateSep), "week", ErrUnsupported}
+		}
+		b.WriteString(date)
+		if len(s) == len(date) {
+			return b.String(), nil
+		}
+		// The date/time separator is copied as it is.  No layout element is a single
+		// character followed by the "15" of the hour, so it can't be misread as one, unless
+		// it is a digit that runs on from the end of the date.
+		if isDigit(s[len(date)]) {
+			return "", &ParseError{s, "time.Parse has no layout for a digit as the date/time separator", len(date), "", ErrUnsupported}
+		}
+		b.WriteByte(s[len(date)])
+		timeString = s[len(date)+1:]
+	}
+	if len(timeString) < 2 || !isDigit(timeString[0]) {
+		// An offset alone, as in "2018-09-27T-05:00" or "-0530", reads as midnight.
+		return "", &ParseError{s, "time.Parse has no layout for an offset without a time", len(s) - len(timeString), "hour", ErrUnsupported}
+	}
+	if timeString[:2] == "24" {
+		return "", &ParseError{s, "time.Parse has no layout for the hour 24", len(s) - len(timeString), "hour", ErrUnsupported}
+	}
+	writeTimeLayout(&b, timeString)
+	return b.String(), nil
+}
+
+// dateFormatOf returns the format of the date portion of a datetime in format f.
+func dateFormatOf(f Format) Format {
+	for date, datetime := range dateTimeFormats {
+		if datetime == f {
+			return date
+		}
+	}
+	return f
+}
+
+// writeTimeLayout writes the layout for timeString, a valid time with no date portion.
+func writeTimeLayout(b *strings.Builder, timeString string) {
+	b.WriteString("15")
+	pos := 2
+	for _, field := range [...]string{"04", "05"} {
+		if pos < len(timeString) && timeString[pos] == timeSep {
+			b.WriteByte(timeSep)
+			pos++
+		}
+		if pos >= len(timeString) || !isDigit(timeString[pos]) {
+			break
+		}
+		b.WriteString(field)
+		pos += 2
+	}
+	if pos < len(timeString) && (timeString[pos] == '.' || timeString[pos] == ',') {
+		end := pos + 1
+		for end < len(timeString) && isDigit(timeString[end]) {
+			end++
+		}
+		if digits := end - pos - 1; digits <= 9 {
+			b.WriteByte(timeString[pos])
+			b.WriteString(strings.Repeat("0", digits))
+		}
+		pos = end
+	}
+	switch offset := timeString[pos:]; {
+	case offset == "":
+	case len(offset) == 3:
+		b.WriteString("Z07")
+	case len(offset) == 5:
+		b.WriteString("Z0700")
+	default:
+		// "Z" or ±hh:mm
+		b.WriteString("Z07:00")
+	}
+}
@@ -0,0 +1,75 @@
+package isoparse
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var layouts = map[string]string{
+	"2018-09-27":                           "2006-01-02",
+	"20180927":                             "20060102",
+	"2018-09":                              "2006-01",
+	"2018":                                 "2006",
+	"2018-270":                             "2006-002",
+	"2018270":                              "2006002",
+	"2018-09-27T05:00:00Z":                 "2006-01-02T15:04:05Z07:00",
+	"2018-09-27T05:00:00+05:30":            "2006-01-02T15:04:05Z07:00",
+	"2018-09-27 05:00":                     "2006-01-02 15:04",
+	"20180927T050000.123-0800":             "20060102T150405.000Z0700",
+	"2018-09-27T05:00:00,5-08":             "2006-01-02T15:04:05,0Z07",
+	"2018-09-27T05:00:00.1234567891+01:00": "2006-01-02T15:04:05Z07:00",
+	"2018-270T05":                          "2006-002T15",
+	"05:00:00.123456":                      "15:04:05.000000",
+	"0500Z":                                "1504Z07:00",
+}
+
+func TestLayoutOf(t *testing.T) {
+	for s, want := range layouts {
+		if got, err := LayoutOf(s); err != nil || got != want {
+			t.Errorf(`LayoutOf(%q) -> (%q, %v) (should be %q)`, s, got, err, want)
+		}
+	}
+}
+
+// Every layout must parse its string to the same instant as ParseISODatetime does, taking
+// the naive strings as UTC as time.Parse does.
+func TestLayoutOfRoundTrip(t *testing.T) {
+	p := NewParser(WithLocation(time.UTC))
+	for datetime := range allFormats {
+		layout, err := LayoutOf(datetime)
+		if errors.Is(err, ErrUnsupported) {
+			continue
+		}
+		if err != nil {
+			t.Errorf(`LayoutOf(%q) -> non-nil error (%v) for valid datetime string`, datetime, err)
+			continue
+		}
+		want, _ := p.Parse(datetime)
+		if got, err := time.Parse(layout, datetime); err != nil || !got.Equal(want) {
+			t.Errorf(`time.Parse(%q, %q) -> (%v, %v) (should be %v)`, layout, datetime, got, err, want)
+		}
+	}
+}
+
+var layoutErrors = map[string]error{
+	"2018-W39-4":        ErrUnsupported,
+	"2018W394T05":       ErrUnsupported,
+	"2018-09-27T24:00":  ErrUnsupported,
+	"24:00":             ErrUnsupported,
+	"-1821":             ErrUnsupported,
+	"-18":               ErrUnsupported,
+	"+05:30":            ErrUnsupported,
+	"2018-09-27T+05":    ErrUnsupported,
+	"2018-09-27T-05:00": ErrUnsupported,
+	"0001-100000Z":      ErrUnsupported,
+	"2018-02-30":        ErrInvalidDay,
+}
+
+func TestLayoutOfErrors(t *testing.T) {
+	for s, want := range layoutErrors {
+		if got, err := LayoutOf(s); !errors.Is(err, want) {
+			t.Errorf(`LayoutOf(%q) -> (%q, %v) (should wrap %v)`, s, got, err, want)
+		}
+	}
+}
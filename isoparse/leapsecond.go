@@ -0,0 +1,41 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+// LeapSecondPolicy chooses how a Parser treats a second of 60, which ISO-8601 allows for a
+// positive leap second ("2016-12-31T23:59:60Z") but time.Time can't represent.
+type LeapSecondPolicy int
+
+const (
+	// LeapSecondReject, the default, makes a second of 60 an error wrapping ErrTimeRange.
+	LeapSecondReject LeapSecondPolicy = iota
+
+	// LeapSecondClamp reads a second of 60 as 59.999999999, the last instant before the
+	// next minute, so that the result still sorts before anything in that minute.
+	LeapSecondClamp
+
+	// LeapSecondNextMinute reads a second of 60 as the first instant of the next minute,
+	// as POSIX time does for the second after 23:59:59.
+	LeapSecondNextMinute
+)
+
+// WithLeapSeconds sets how Parse and ParseDateTime treat a second of 60, for ingesting
+// data from sources such as GPS receivers and NTP servers that write leap seconds out.
+// Any fraction on the leap second is dropped.  The second is accepted at the end of any
+// minute, with any offset, since which minutes had a leap second isn't known in advance.
+func WithLeapSeconds(policy LeapSecondPolicy) Option {
+	return func(p *Parser) {
+		p.leapSeconds = policy
+	}
+}
+
+// mapLeapSecond replaces a second of 60 in parts with 59.999999999, if p's policy accepts
+// it, and reports whether the caller must then move the result on to the next minute.
+func (p *Parser) mapLeapSecond(parts *datetimeParts) (nextMinute bool) {
+	if parts.time[2] != 60 || p.leapSeconds == LeapSecondReject {
+		return false
+	}
+	parts.time[2], parts.time[3] = maxSec, maxNsec
+	return p.leapSeconds == LeapSecondNextMinute
+}
@@ -0,0 +1,54 @@
+package isoparse
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithLeapSeconds(t *testing.T) {
+	kolkata := offsetZone(5*60*60 + 30*60)
+	for _, c := range []struct {
+		policy LeapSecondPolicy
+		s      string
+		want   time.Time
+	}{
+		{LeapSecondClamp, "2016-12-31T23:59:60Z", time.Date(2016, 12, 31, 23, 59, 59, 999999999, time.UTC)},
+		{LeapSecondClamp, "2016-12-31T23:59:60.5Z", time.Date(2016, 12, 31, 23, 59, 59, 999999999, time.UTC)},
+		{LeapSecondClamp, "20170101T052960+0530", time.Date(2017, 1, 1, 5, 29, 59, 999999999, kolkata)},
+		{LeapSecondNextMinute, "2016-12-31T23:59:60Z", time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{LeapSecondNextMinute, "2016-12-31T23:59:60", time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{LeapSecondNextMinute, "2017-01-01T05:29:60+05:30", time.Date(2017, 1, 1, 5, 30, 0, 0, kolkata)},
+	} {
+		p := NewParser(WithLeapSeconds(c.policy), WithLocation(time.UTC))
+		if got, err := p.Parse(c.s); err != nil || !got.Equal(c.want) {
+			t.Errorf(`Parse(%q) with policy %d -> (%v, %v) (should be %v)`, c.s, c.policy, got, err, c.want)
+		}
+	}
+}
+
+func TestWithLeapSecondsRejects(t *testing.T) {
+	for _, p := range []*Parser{NewParser(), NewParser(WithLeapSeconds(LeapSecondReject))} {
+		if _, err := p.Parse("2016-12-31T23:59:60Z"); !errors.Is(err, ErrTimeRange) {
+			t.Errorf(`Parse("2016-12-31T23:59:60Z") -> %v (should wrap %v)`, err, ErrTimeRange)
+		}
+	}
+	p := NewParser(WithLeapSeconds(LeapSecondNextMinute))
+	for _, s := range []string{"2016-12-31T23:59:61Z", "2016-12-31T23:60:00Z", "2016-12-31T24:00:60Z"} {
+		if _, err := p.Parse(s); !errors.Is(err, ErrTimeRange) {
+			t.Errorf(`Parse(%q) -> %v (should wrap %v)`, s, err, ErrTimeRange)
+		}
+	}
+}
+
+func TestWithLeapSecondsDateTime(t *testing.T) {
+	for policy, want := range map[LeapSecondPolicy]string{
+		LeapSecondClamp:      "2016-12-31T23:59:59.999999999",
+		LeapSecondNextMinute: "2017-01-01T00:00:00",
+	} {
+		p := NewParser(WithLeapSeconds(policy))
+		if dt, err := p.ParseDateTime("2016-12-31T23:59:60"); err != nil || dt.String() != want {
+			t.Errorf(`ParseDateTime("2016-12-31T23:59:60") with policy %d -> (%v, %v) (should be %s)`, policy, dt, err, want)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// lookalike is a non-ASCII character that is commonly mistaken for, or substituted for,
+// an ASCII character in ISO-8601 strings.  These turn up in copy-pasted data, where
+// they're all but invisible.
+type lookalike struct {
+	name  string // The Unicode character name
+	ascii byte   // The character that was probably meant, or 0 if that depends on where it is
+}
+
+var lookalikes = map[rune]lookalike{
+	'Т': {"CYRILLIC CAPITAL LETTER TE", 'T'},
+	'Τ': {"GREEK CAPITAL LETTER TAU", 'T'},
+	'Ζ': {"GREEK CAPITAL LETTER ZETA", 'Z'},
+	'‐': {"HYPHEN", '-'},
+	'‑': {"NON-BREAKING HYPHEN", '-'},
+	'‒': {"FIGURE DASH", '-'},
+	'–': {"EN DASH", '-'},
+	'—': {"EM DASH", '-'},
+	'−': {"MINUS SIGN", '-'},
+	'∶': {"RATIO", ':'},
+	'＋': {"FULLWIDTH PLUS SIGN", '+'},
+	'，': {"FULLWIDTH COMMA", ','},
+	'－': {"FULLWIDTH HYPHEN-MINUS", '-'},
+	'．': {"FULLWIDTH FULL STOP", '.'},
+	'：': {"FULLWIDTH COLON", ':'},
+	'Ｔ': {"FULLWIDTH LATIN CAPITAL LETTER T", 'T'},
+	'Ｚ': {"FULLWIDTH LATIN CAPITAL LETTER Z", 'Z'},
+	// CJK dates and times are written 2014年03月14日 and 14時30分15秒.  Which separator
+	// each marker stands for depends on where it is: 日 is usually the 'T', but not in
+	// "2014日03月14".
+	'年': {"CJK UNIFIED IDEOGRAPH-5E74 (year)", 0},
+	'月': {"CJK UNIFIED IDEOGRAPH-6708 (month)", 0},
+	'日': {"CJK UNIFIED IDEOGRAPH-65E5 (day)", 0},
+	'時': {"CJK UNIFIED IDEOGRAPH-6642 (hour)", 0},
+	'分': {"CJK UNIFIED IDEOGRAPH-5206 (minute)", 0},
+}
+
+var digitNames = [...]string{"ZERO", "ONE", "TWO", "THREE", "FOUR", "FIVE", "SIX", "SEVEN", "EIGHT", "NINE"}
+
+// findLookalike returns the first lookalike character in s and its byte offset.
+func findLookalike(s string) (pos int, r rune, l lookalike, ok bool) {
+	for pos, r = range s {
+		if r < utf8.RuneSelf {
+			continue
+		}
+		if '０' <= r && r <= '９' {
+			return pos, r, lookalike{"FULLWIDTH DIGIT " + digitNames[r-'０'], byte('0' + r - '０')}, true
+		}
+		if l, ok = lookalikes[r]; ok {
+			return pos, r, l, true
+		}
+		if unicode.IsDigit(r) {
+			return pos, r, lookalike{"", byte('0' + digitValue(r))}, true
+		}
+	}
+	return 0, 0, l, false
+}
+
+// digitValue returns the value of r, a Unicode decimal digit.  Unicode encodes each set
+// of decimal digits as a contiguous run from zero to nine, and some sets follow one
+// another directly, so the value is the distance from the start of the run, modulo 10.
+func digitValue(r rune) int {
+	start := r
+	for unicode.IsDigit(start - 1) {
+		start--
+	}
+	return int(r-start) % 10
+}
+
+// findNonASCII returns the first non-ASCII character in s and its byte offset.
+func findNonASCII(s string) (pos int, r rune, ok bool) {
+	for pos, r = range s {
+		if r >= utf8.RuneSelf {
+			return pos, r, true
+		}
+	}
+	return 0, 0, false
+}
+
+// diagnoseLookalike rewrites an error for a string containing a lookalike character to
+// name that character, and for a string containing any other non-ASCII character to say
+// so.  No valid string contains one, so it is the real cause of the failure, whatever the
+// parser happened to trip over first; and the generic message gives no hint of it.  The
+// message also says what was probably meant, except for the CJK markers, where that
+// depends on the position.
+func diagnoseLookalike(err error) error {
+	e, ok := err.(*ParseError)
+	if !ok {
+		return err
+	}
+	pos, r, l, ok := findLookalike(e.Datetime)
+	switch {
+	case ok && l.ascii == 0:
+		e.Message = fmt.Sprintf("found U+%04X %s", r, l.name)
+	case ok && l.name != "":
+		e.Message = fmt.Sprintf("found U+%04X %s where %q expected", r, l.name, l.ascii)
+	case ok:
+		e.Message = fmt.Sprintf("found U+%04X %q, a non-ASCII decimal digit, where %q expected", r, r, l.ascii)
+	default:
+		if pos, r, ok = findNonASCII(e.Datetime); !ok {
+			return err
+		}
+		e.Message = fmt.Sprintf("found non-ASCII character U+%04X %q; ISO-8601 strings are ASCII", r, r)
+	}
+	e.Pos, e.Element, e.Err = pos, "", ErrSyntax
+	return e
+}
+
+// WithUnicodeDigits makes a Parser accept Unicode decimal digits wherever it accepts ASCII
+// ones, for data scraped from sources that write full-width digits, such as
+// "２０１４-０３-１４", or the digits of other scripts.  Each is read by its value, as given
+// by the Unicode character database.  Only digits are mapped: full-width punctuation, and
+// CJK date markers such as 年, are still errors, which name the character found.  Errors
+// quote the string with its digits mapped to ASCII, and positions are within that string.
+//
+// It applies to Parse, ParseDate, ParseTime, ParseDateTime, ParseDuration, and the
+// functions built on them.
+func WithUnicodeDigits() Option {
+	return func(p *Parser) {
+		p.unicodeDigits = true
+	}
+}
+
+// asciiDigits returns s with its Unicode decimal digits mapped to ASCII, if p accepts
+// them.  It returns s itself if there are none.
+func (p *Parser) asciiDigits(s string) string {
+	if !p.unicodeDigits {
+		return s
+	}
+	if _, _, ok := findNonASCII(s); !ok {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		if r >= utf8.RuneSelf && unicode.IsDigit(r) {
+			return '0' + rune(digitValue(r))
+		}
+		return r
+	}, s)
+}
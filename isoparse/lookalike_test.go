@@ -0,0 +1,100 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLookalikeDiagnostics(t *testing.T) {
+	cases := []struct {
+		parse func(string) error
+		s     string
+		want  string
+	}{
+		{parseDatetime, "2014-03-12Т12:30:14", "cannot parse 2014-03-12Т12:30:14: found U+0422 CYRILLIC CAPITAL LETTER TE where 'T' expected (at byte 10)"},
+		{parseDate, "2014日03月14", "cannot parse 2014日03月14: found U+65E5 CJK UNIFIED IDEOGRAPH-65E5 (day) (at byte 4)"},
+		{parseDate, "２０１４-03-14", "cannot parse ２０１４-03-14: found U+FF12 FULLWIDTH DIGIT TWO where '2' expected (at byte 0)"},
+		{parseDatetime, "2014-03-14T12：30", "cannot parse 2014-03-14T12：30: found U+FF1A FULLWIDTH COLON where ':' expected (at byte 13)"},
+		{parseTime, "14時30分15秒", "cannot parse 14時30分15秒: found U+6642 CJK UNIFIED IDEOGRAPH-6642 (hour) (at byte 2)"},
+		{parseDuration, "P１D", "cannot parse P１D: found U+FF11 FULLWIDTH DIGIT ONE where '1' expected (at byte 1)"},
+	}
+	for _, c := range cases {
+		err := c.parse(c.s)
+		if err == nil || err.Error() != c.want {
+			t.Errorf(`parse(%q) -> %v (should be %s)`, c.s, err, c.want)
+		}
+		if !errors.Is(err, ErrSyntax) {
+			t.Errorf(`parse(%q) -> %v (should wrap %v)`, c.s, err, ErrSyntax)
+		}
+	}
+
+}
+
+func TestNonASCIIDiagnostics(t *testing.T) {
+	cases := []struct {
+		parse func(string) error
+		s     string
+		want  string
+	}{
+		{parseDatetime, "2014-03-14☐12:30", "cannot parse 2014-03-14☐12:30: found non-ASCII character U+2610 '☐'; ISO-8601 strings are ASCII (at byte 10)"},
+		{parseDate, "2014-03-14\u00a0", "cannot parse 2014-03-14\u00a0: found non-ASCII character U+00A0 '\\u00a0'; ISO-8601 strings are ASCII (at byte 10)"},
+		{parseTime, "12:30秒", "cannot parse 12:30秒: found non-ASCII character U+79D2 '秒'; ISO-8601 strings are ASCII (at byte 5)"},
+		{parseDate, "2014-0٣-14", "cannot parse 2014-0٣-14: found U+0663 '٣', a non-ASCII decimal digit, where '3' expected (at byte 6)"},
+		{parseDuration, "PT१H", "cannot parse PT१H: found U+0967 '१', a non-ASCII decimal digit, where '1' expected (at byte 2)"},
+	}
+	for _, c := range cases {
+		err := c.parse(c.s)
+		if err == nil || err.Error() != c.want {
+			t.Errorf(`parse(%q) -> %v (should be %s)`, c.s, err, c.want)
+		}
+		if !errors.Is(err, ErrSyntax) {
+			t.Errorf(`parse(%q) -> %v (should wrap %v)`, c.s, err, ErrSyntax)
+		}
+	}
+}
+
+func TestDigitValue(t *testing.T) {
+	for r, want := range map[rune]int{
+		'0': 0, '7': 7, '０': 0, '９': 9, '٣': 3, '۹': 9, '१': 1, '๕': 5, '𝟎': 0, '𝟗': 9, '𝟘': 0, '𝟡': 9, '𝟿': 9,
+	} {
+		if got := digitValue(r); got != want {
+			t.Errorf(`digitValue(%q) -> %d (should be %d)`, r, got, want)
+		}
+	}
+}
+
+func TestWithUnicodeDigits(t *testing.T) {
+	p := NewParser(WithLocation(time.UTC), WithUnicodeDigits())
+	want := time.Date(2014, 3, 14, 12, 30, 15, 0, time.UTC)
+	for _, s := range []string{"２０１４-０３-１４T１２:３０:１５", "٢٠١٤-٠٣-١٤T١٢:٣٠:١٥Z", "2014-03-14T12:30:15"} {
+		if got, err := p.Parse(s); err != nil || !got.Equal(want) {
+			t.Errorf(`Parse(%q) with Unicode digits -> (%v, %v) (should be %v)`, s, got, err, want)
+		}
+	}
+	if got, err := p.ParseDate("२०१४-०३-१४"); err != nil || got != (Date{2014, time.March, 14}) {
+		t.Errorf(`ParseDate("२०१४-०३-१४") with Unicode digits -> (%v, %v)`, got, err)
+	}
+	if got, err := p.ParseTime("１２：３０"); err == nil {
+		t.Errorf(`ParseTime("１２：３０") with Unicode digits -> %v (the full-width colon should be an error)`, got)
+	} else if want := "found U+FF1A FULLWIDTH COLON where ':' expected"; err.(*ParseError).Message != want {
+		t.Errorf(`ParseTime("１２：３０") with Unicode digits -> %v (should say %s)`, err, want)
+	}
+	if got, err := p.ParseDuration("P１DT２H"); err != nil || got != (Period{Days: 1, Hours: 2}) {
+		t.Errorf(`ParseDuration("P１DT２H") with Unicode digits -> (%v, %v)`, got, err)
+	}
+	if _, err := p.Parse("2014年03月14日"); !errors.Is(err, ErrSyntax) {
+		t.Errorf(`Parse("2014年03月14日") with Unicode digits -> %v (should wrap %v)`, err, ErrSyntax)
+	}
+	if _, err := ParseISODatetime("２０１４-０３-１４"); err == nil {
+		t.Errorf(`ParseISODatetime("２０１４-０３-１４") returned nil error (Unicode digits should be opt-in)`)
+	}
+}
+
+func parseDatetime(s string) error { _, err := ParseISODatetime(s); return err }
+func parseDate(s string) error     { _, err := ParseISODate(s); return err }
+func parseTime(s string) error     { _, err := ParseISOTimeParts(s); return err }
+func parseDuration(s string) error { _, err := ParseISODuration(s); return err }
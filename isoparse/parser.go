@@ -0,0 +1,580 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Parser parses ISO-8601 strings under a configurable set of options.
+//
+// The package-level functions (ParseISODatetime, ParseISODate, ParseISOTimeParts) are thin
+// wrappers around a Parser with default options; a Parser is the place to go when those
+// defaults don't fit.  Options are given to NewParser as a list of Option values:
+//
+//	p := isoparse.NewParser(isoparse.WithLocation(time.UTC))
+//	t, err := p.Parse("2018-09-27T11:52:59")  // 11:52:59 UTC, rather than local time
+//
+// A Parser is safe for concurrent use by multiple goroutines.
+// The zero value is a Parser with default options.
+type Parser struct {
+	loc           *time.Location // Attached to inputs with no UTC offset.  nil means time.Local.
+	unknownOffset bool           // Whether "-00:00" gives UnknownOffset rather than time.UTC.
+	dstPolicy     DSTPolicy      // Resolves DST gaps and overlaps for inputs with no UTC offset.
+	resolveOffset func(secondsEast int, t time.Time) *time.Location
+	offsetMinutes []int            // If non-nil, the only minutes allowed in a UTC offset.
+	formatHints   bool             // Whether syntax errors list the accepted formats.
+	cache         *parseCache      // Results of Parse, if enabled with WithCache.
+	edtfLevel     int              // The EDTF level accepted by the EDTF methods, set with WithEDTF.
+	syntax        *syntaxRules     // The checks of the profile set with WithProfile, if any.
+	leapSeconds   LeapSecondPolicy // How a second of 60 is read, set with WithLeapSeconds.
+	dateAnchor    *TimeOfDay       // The time of day for dates alone, if not the start of the day.
+	completeDates bool             // Whether dates must have a month and a day.
+	quarters      bool             // Whether dates may be quarters, set with WithQuarters.
+	truncatedRef  *time.Time       // The reference for truncated times, if they are accepted.
+	defaults      *time.Time       // Fills in missing components, if set with WithDefault.
+	hooks         *Hooks           // Called after each parse, if set with WithHooks.
+	unicodeDigits bool             // Whether non-ASCII decimal digits are accepted.
+	// Tried in order when Parse fails, if added with WithFallback.
+	fallbacks []func(s string) (time.Time, error)
+}
+
+// Option configures a Parser.  See NewParser.
+type Option func(*Parser)
+
+// defaultParser backs the package-level parsing functions.
+var defaultParser = &Parser{}
+
+// NewParser returns a Parser configured with the given options.
+func NewParser(opts ...Option) *Parser {
+	p := &Parser{}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// WithLocation sets the location attached to results whose input has no UTC offset.
+//
+// The default is time.Local, which represents a "best assumption" that the string is from
+// the package user's local time zone.  Passing nil restores the default.
+func WithLocation(loc *time.Location) Option {
+	return func(p *Parser) {
+		p.loc = loc
+	}
+}
+
+// WithDateAnchor sets the time of day given to results whose input is a date alone, in
+// place of the start of the day.  Midnight is a fragile choice for a value that is
+// really a calendar date, since converting it to a zone even an hour to the west moves it
+// onto the day before; anchoring at noon, with WithDateAnchor(TimeOfDay{Hour: 12}), leaves
+// room for a change of up to twelve hours either way.  As with a time in the string, a
+// reading that falls in a DST gap or overlap is resolved by the DST policy.
+//
+// It applies to Parse and the functions built on it, such as ParseInterval, and not to
+// the civil Date returned by ParseDate.  Passing an invalid TimeOfDay, or 24:00, restores
+// the default of the start of the day.
+func WithDateAnchor(t TimeOfDay) Option {
+	return func(p *Parser) {
+		p.dateAnchor = nil
+		if t.IsValid() && t.Hour < maxHour {
+			p.dateAnchor = &t
+		}
+	}
+}
+
+// WithCompleteDates makes a Parser reject dates that lack a month or a day, such as
+// "2018", "2018-09", and "2018-W39", where a complete date is mandatory and defaulting
+// to the first of the month or year would hide missing data.  Complete calendar, week, and
+// ordinal dates are still accepted, unlike with a profile such as RFC3339.  It applies
+// to Parse, ParseDate, ParseDateTime, and the functions built on them, and the error
+// wraps ErrSyntax.
+func WithCompleteDates() Option {
+	return func(p *Parser) {
+		p.completeDates = true
+	}
+}
+
+// checkComplete returns an error if p requires complete dates and date, a valid date
+// that is all or the start of s, isn't one.
+func (p *Parser) checkComplete(s, date string) error {
+	if !p.completeDates {
+		return nil
+	}
+	switch dateFormat(date) {
+	case YearDate:
+		return &ParseError{s, "date must have a month and a day", len(date), "month", ErrSyntax}
+	case YearMonthDate:
+		return &ParseError{s, "date must have a day", len(date), "day", ErrSyntax}
+	case WeekDateExtended, WeekDateBasic:
+		if len(date) == len("2018W39")+btoi(date[4] == dateSep) {
+			return &ParseError{s, "date must have a day", len(date), "day", ErrSyntax}
+		}
+	}
+	return nil
+}
+
+// UnknownOffset is the location that a Parser created with WithUnknownOffset attaches to
+// times written with the offset "-00:00".  It is a fixed zone with offset 0, named "-00:00",
+// so the instant is the same as with time.UTC; compare locations to tell them apart:
+//
+//	if t.Location() == isoparse.UnknownOffset { ... }
+//
+// FormatISO writes times in this location with "-00:00" rather than "Z".
+var UnknownOffset = time.FixedZone("-00:00", 0)
+
+// WithUnknownOffset makes a Parser distinguish "-00:00" from "Z" and "+00:00".
+//
+// RFC 3339 (section 4.3) uses "-00:00" to mean that the time is known in UTC but the
+// offset to local time is unknown, while "Z" and "+00:00" mean that UTC is the preferred
+// reference point.  By default both are parsed as time.UTC; with this option, "-00:00"
+// (and "-0000" and "-00") are parsed as UnknownOffset instead.
+func WithUnknownOffset() Option {
+	return func(p *Parser) {
+		p.unknownOffset = true
+	}
+}
+
+// WithOffsetResolver sets a function that maps each parsed UTC offset to a location,
+// for applications that know more about their data than the offset alone can say.
+// For example, to attach Europe/Berlin rather than a fixed +01:00 or +02:00 zone:
+//
+//	berlin, _ := time.LoadLocation("Europe/Berlin")
+//	p := isoparse.NewParser(isoparse.WithOffsetResolver(func(int, time.Time) *time.Location {
+//		return berlin
+//	}))
+//
+// The function is called by Parse for every string with an explicit offset ("Z" included,
+// but not "-00:00" under WithUnknownOffset), with the offset in seconds east of UTC and the
+// parsed instant.  It may return nil to keep the default fixed zone.  The instant is never
+// changed: if the returned location's offset at that instant differs from the string's,
+// Parse returns an error.
+func WithOffsetResolver(resolve func(secondsEast int, t time.Time) *time.Location) Option {
+	return func(p *Parser) {
+		p.resolveOffset = resolve
+	}
+}
+
+// WithOffsetMinutes restricts the minutes that a UTC offset may have.  Every zone in use
+// today has an offset that is a whole number of quarter hours, so
+//
+//	isoparse.NewParser(isoparse.WithOffsetMinutes(0, 15, 30, 45))
+//
+// rejects garbled offsets such as "+05:07" that could otherwise pass for valid data.
+// Calling it with no arguments removes the restriction.
+func WithOffsetMinutes(minutes ...int) Option {
+	return func(p *Parser) {
+		p.offsetMinutes = nil
+		if len(minutes) > 0 {
+			p.offsetMinutes = append([]int(nil), minutes...)
+		}
+	}
+}
+
+// checkOffsetMinutes applies WithOffsetMinutes to the fixed zone tz parsed from s.
+func (p *Parser) checkOffsetMinutes(s string, tz *time.Location) error {
+	if p.offsetMinutes == nil {
+		return nil
+	}
+	_, secondsEast := time.Date(2000, time.January, 1, 0, 0, 0, 0, tz).Zone()
+	if secondsEast < 0 {
+		secondsEast = -secondsEast
+	}
+	for _, m := range p.offsetMinutes {
+		if secondsEast/60%60 == m {
+			return nil
+		}
+	}
+	return &ParseError{s, "offset minutes not allowed", -1, "offset-minutes", ErrInvalidOffset}
+}
+
+// offsetLocation returns the location for a string with an explicit UTC offset, given the
+// location tz parsed from that offset.  It applies WithUnknownOffset.
+func (p *Parser) offsetLocation(s string, tz *time.Location) *time.Location {
+	if p.unknownOffset && tz == time.UTC {
+		for _, zero := range [...]string{"-00:00", "-0000", "-00"} {
+			if strings.HasSuffix(s, zero) {
+				return UnknownOffset
+			}
+		}
+	}
+	return tz
+}
+
+// location returns the location to use for inputs with no UTC offset.
+// time.Local is read at call time rather than captured, since callers may reassign it.
+func (p *Parser) location() *time.Location {
+	if p.loc == nil {
+		return time.Local
+	}
+	return p.loc
+}
+
+// Parse parses an ISO-8601 datetime (combined date and time string).
+// It can also parse just a date in isolation, in which case the result is midnight at
+// the start of that date.
+//
+// If parse error is not nil, the returned Time will be the zero value (or very close to it).
+// If no timezone/offset is detected, the result will have the location configured with
+// WithLocation (time.Local by default).  If that location observes DST, a wall-clock time
+// that it skips or repeats is resolved according to the policy set with WithDSTPolicy.
+//
+// The string may end with RFC 9557 bracketed annotations, as in
+// "2022-07-08T00:14:07+01:00[Europe/Paris]", in which case the result is in the named zone
+// (an IANA name or "±hh:mm").  A numeric offset must agree with the zone at that instant;
+// "Z" agrees with any zone; and a string with no offset is read as wall-clock time in the
+// zone, again subject to the DST policy.  A "[u-ca=iso8601]" or
+// "[u-ca=gregory]" calendar tag is accepted, other tags are ignored, and other tags
+// marked critical with "!" are an error.
+//
+// Every byte of the string must be part of the datetime or its annotations: trailing
+// content, even a space or a newline, is an error.  Where it follows something complete,
+// such as an offset or a fraction, the error wraps ErrTrailingData; elsewhere it may read
+// as a malformed component instead.  ParsePrefix is the way to accept trailing content.
+func (p *Parser) Parse(datetime string) (time.Time, error) {
+	t, err := p.parseCached(datetime)
+	p.observe(datetime, err)
+	return t, err
+}
+
+// parseCached does the work for Parse, through the cache if p has one.
+func (p *Parser) parseCached(datetime string) (time.Time, error) {
+	if p.cache == nil {
+		return p.parseWithFallbacks(datetime)
+	}
+	if t, ok := p.cache.get(datetime); ok {
+		return t, nil
+	}
+	t, err := p.parseWithFallbacks(datetime)
+	if err == nil {
+		p.cache.add(datetime, t)
+	}
+	return t, err
+}
+
+func (p *Parser) parse(datetime string) (time.Time, error) {
+	datetime = p.asciiDigits(datetime)
+	if p.syntax != nil && p.syntax.parse != nil {
+		return p.syntax.parse(p, datetime)
+	}
+	if err := p.syntax.check(datetime, "datetime"); err != nil {
+		return time.Time{}, err
+	}
+	if p.isQuarter(datetime) {
+		d, err := p.quarterDate(datetime)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return p.anchorDate(datetime, d, p.location())
+	}
+	if p.isTruncated(datetime) {
+		return p.parseTruncated(datetime)
+	}
+	rest, zone, err := splitIXDTF(datetime)
+	if err != nil {
+		return time.Time{}, p.hintFormats(err)
+	}
+	parts, ok := scanRFC3339(rest)
+	if !ok {
+		if parts, err = parseISODatetime(rest); err != nil {
+			// rest is a prefix of datetime, so the position still holds.
+			err.(*ParseError).Datetime = datetime
+			return time.Time{}, p.hintFormats(err)
+		}
+	}
+	if !parts.hasTime {
+		if err := p.checkComplete(datetime, rest); err != nil {
+			return time.Time{}, err
+		}
+	}
+	p.fillDefaults(rest, &parts)
+	nextMinute := p.mapLeapSecond(&parts)
+	t, err := p.resolveDatetime(datetime, rest, zone, parts)
+	if nextMinute && err == nil {
+		t = t.Add(time.Nanosecond)
+	}
+	return t, err
+}
+
+// resolveDatetime does the rest of the work for parse, given the parts of datetime and its
+// RFC 9557 zone, if any.  rest is datetime without its annotations.
+func (p *Parser) resolveDatetime(datetime, rest, zone string, parts datetimeParts) (time.Time, error) {
+	if parts.hasOffset {
+		if err := p.checkOffsetMinutes(datetime, parts.tz); err != nil {
+			return time.Time{}, err
+		}
+	}
+	if zone != "" {
+		return p.applyIXDTFZone(datetime, rest, parts, zone)
+	}
+	if !parts.hasOffset {
+		return p.resolveParts(datetime, parts, p.location())
+	}
+	t, err := strictDate(parts.date[0], time.Month(parts.date[1]), parts.date[2], parts.time[0], parts.time[1], parts.time[2], parts.time[3], p.offsetLocation(rest, parts.tz))
+	if err != nil {
+		return t, parts.locate(datetime, err)
+	}
+	if p.resolveOffset == nil || t.Location() == UnknownOffset {
+		return t, nil
+	}
+	_, secondsEast := t.Zone()
+	loc := p.resolveOffset(secondsEast, t)
+	if loc == nil {
+		return t, nil
+	}
+	t = t.In(loc)
+	if _, offset := t.Zone(); offset != secondsEast {
+		return time.Time{}, &ParseError{datetime, "offset is inconsistent with location " + loc.String(), -1, "offset", ErrOffsetMismatch}
+	}
+	return t, nil
+}
+
+// resolveParts validates the components of a string with no UTC offset and reads them as
+// wall-clock time in loc, applying p's DST policy.
+func (p *Parser) resolveParts(datetime string, parts datetimeParts, loc *time.Location) (time.Time, error) {
+	if _, err := strictDate(parts.date[0], time.Month(parts.date[1]), parts.date[2], parts.time[0], parts.time[1], parts.time[2], parts.time[3], loc); err != nil {
+		return time.Time{}, parts.locate(datetime, err)
+	}
+	if !parts.hasTime && p.defaults == nil {
+		return p.anchorDate(datetime, Date{parts.date[0], time.Month(parts.date[1]), parts.date[2]}, loc)
+	}
+	// Roll hour 24 over to the next day before resolving.
+	wall := time.Date(parts.date[0], time.Month(parts.date[1]), parts.date[2], parts.time[0], parts.time[1], parts.time[2], parts.time[3], time.UTC)
+	return p.resolveWall(datetime, wall, loc)
+}
+
+// ParseDateTime parses an ISO-8601 datetime into a DateTime, i.e. the wall-clock
+// reading with no location.  It accepts the same inputs as Parse, except that a string
+// with a UTC offset is an error, since the offset would be silently lost otherwise.
+// (Use Parse and DateTimeOf to deliberately convert such a string.)
+//
+// Unlike Parse, an hour of 24 is kept as-is rather than rolled over to the next day.
+func (p *Parser) ParseDateTime(datetime string) (DateTime, error) {
+	v, err := p.parseDateTime(datetime)
+	p.observe(datetime, err)
+	return v, err
+}
+
+// parseDateTime does the work for ParseDateTime.
+func (p *Parser) parseDateTime(datetime string) (DateTime, error) {
+	datetime = p.asciiDigits(datetime)
+	if err := p.syntax.check(datetime, "datetime"); err != nil {
+		return DateTime{}, err
+	}
+	parts, err := parseISODatetime(datetime)
+	if err != nil {
+		return DateTime{}, p.hintFormats(err)
+	}
+	if parts.hasOffset {
+		return DateTime{}, &ParseError{datetime, "DateTime cannot hold a UTC offset", -1, "offset", ErrUnexpectedOffset}
+	}
+	if !parts.hasTime {
+		if err := p.checkComplete(datetime, datetime); err != nil {
+			return DateTime{}, err
+		}
+	}
+	p.fillDefaults(datetime, &parts)
+	nextMinute := p.mapLeapSecond(&parts)
+	// We borrow strictDate for its validation only.
+	t, err := strictDate(parts.date[0], time.Month(parts.date[1]), parts.date[2], parts.time[0], parts.time[1], parts.time[2], parts.time[3], time.UTC)
+	if err != nil {
+		return DateTime{}, parts.locate(datetime, err)
+	}
+	if nextMinute {
+		return DateTimeOf(t.Add(time.Nanosecond)), nil
+	}
+	return DateTime{
+		Date{parts.date[0], time.Month(parts.date[1]), parts.date[2]},
+		TimeOfDay{parts.time[0], parts.time[1], parts.time[2], parts.time[3]},
+	}, nil
+}
+
+// datetimeParts holds the raw components of a datetime string, before any location is
+// attached or the components are validated against the calendar.
+type datetimeParts struct {
+	date      [3]int         // year, month, day
+	time      [4]int         // hour, minute, second, nanosecond
+	tz        *time.Location // Only meaningful if hasOffset
+	hasOffset bool
+	hasTime   bool // Whether there was a time portion at all
+	timePos   int  // Index of the time portion in the source string; only meaningful if hasTime
+}
+
+// locate rewrites an error from strictDate to point at the offending element of s, the
+// string that parts was parsed from.
+func (parts datetimeParts) locate(s string, err error) error {
+	if !parts.hasTime {
+		return locateElement(s, -1, err)
+	}
+	return locateElement(s, parts.timePos, err)
+}
+
+// locateElement rewrites a range error from strictDate, which sees only the components,
+// so that it reports s and the position of the element within it.  Only calendar dates
+// can produce such errors, since week and ordinal dates are validated as they're parsed.
+// timeStart is the index of the time portion in s, or -1 if there is none.
+func locateElement(s string, timeStart int, err error) error {
+	r, ok := err.(*rangeError)
+	if !ok {
+		return err
+	}
+	e := &ParseError{s, r.message, -1, r.element, r.err}
+	dateSepWidth := btoi(len(s) > 4 && s[4] == dateSep)
+	switch e.Element {
+	case "year":
+		e.Pos = 0
+	case "month":
+		e.Pos = 4 + dateSepWidth
+	case "day":
+		e.Pos = 6 + 2*dateSepWidth
+	}
+	if timeStart < 0 {
+		return e
+	}
+	timeSepWidth := btoi(len(s) > timeStart+2 && s[timeStart+2] == timeSep)
+	switch e.Element {
+	case "hour":
+		e.Pos = timeStart
+	case "minute":
+		e.Pos = timeStart + 2 + timeSepWidth
+	case "second":
+		e.Pos = timeStart + 4 + 2*timeSepWidth
+	case "fraction":
+		e.Pos = timeStart + 7 + 2*timeSepWidth
+	}
+	return e
+}
+
+// parseISODatetime does the syntactic work for Parse and ParseDateTime.
+func parseISODatetime(datetime string) (parts datetimeParts, err error) {
+	// Date first
+	// We get position to know where the date stops
+	dateParts, pos, err := parseISODate(datetime)
+	if err != nil {
+		// Stop here, and keep just the dateString in the ParseError message.
+		return parts, diagnoseLookalike(err)
+	}
+	parts.date = dateParts
+
+	// If len(datetime) > pos, it appears we have a time portion
+	// If len(datetime) < pos, something's gone very wrong with parseISODate
+	// If they're equal, we just have a (seemingly valid) date
+
+	if len(datetime) > pos {
+		// Make sure the sep between date and time (strictly just "T") is a non-numeric ASCII character.
+		// This means: 0 thru 127 except 48 thru 57 in decimal.
+		if sep := datetime[pos]; (sep >= 0 && sep < 48) || (sep > 47 && sep <= 127) {
+			// Only erring out because we were signaled that a time portion should be there.
+			parts.time, parts.tz, parts.hasOffset, err = parseISOTime(datetime[pos+1:])
+			if err != nil {
+				// Report the whole string, and point at the time portion within it.
+				e := err.(*ParseError)
+				e.Message += " in time " + strconv.Quote(e.Datetime)
+				e.Datetime = datetime
+				if e.Pos >= 0 {
+					e.Pos += pos + 1
+				}
+				return parts, diagnoseLookalike(e)
+			}
+			parts.hasTime, parts.timePos = true, pos+1
+		} else {
+			return parts, diagnoseLookalike(&ParseError{datetime, "date/time separator must be a non-numeric ASCII character", pos, "time-separator", ErrSyntax})
+		}
+
+	} else if len(datetime) < pos {
+		// This really shouldn't be reached, but represents a case where the
+		// position cursor moved past the entire string in parsing just the date.
+		return parts, &ParseError{datetime, "", -1, "", ErrSyntax}
+	}
+	return parts, nil
+}
+
+// ParseDate parses an ISO-8601 date string with no time component.
+// Examples: YYYY-MM-DD, YYYYMMDD, YYYY-MM, YYYY, YYYY-Www-D, YYYY-DDD.
+func (p *Parser) ParseDate(dateString string) (Date, error) {
+	v, err := p.parseDate(dateString)
+	p.observe(dateString, err)
+	return v, err
+}
+
+// parseDate does the work for ParseDate.
+func (p *Parser) parseDate(dateString string) (Date, error) {
+	dateString = p.asciiDigits(dateString)
+	if err := p.syntax.check(dateString, "date"); err != nil {
+		return Date{}, err
+	}
+	if p.isQuarter(dateString) {
+		return p.quarterDate(dateString)
+	}
+	components, pos, err := parseISODate(dateString)
+	if err != nil {
+		return Date{}, p.hintFormats(diagnoseLookalike(err))
+	}
+	if pos < len(dateString) {
+		// This final check needs to remain separate.
+		// I.e. this logic is not followed in Parse
+		return Date{}, diagnoseLookalike(&ParseError{dateString, "string contains unknown iso components", pos, "", ErrTrailingData})
+	}
+	if err := p.checkComplete(dateString, dateString); err != nil {
+		return Date{}, err
+	}
+	if p.defaults != nil {
+		parts := datetimeParts{date: components}
+		p.fillDefaults(dateString, &parts)
+		components = parts.date
+	}
+	// We borrow strictDate for its validation only.
+	t, err := strictDate(components[0], time.Month(components[1]), components[2], 0, 0, 0, 0, time.UTC)
+	if err != nil {
+		return Date{}, locateElement(dateString, -1, err)
+	}
+	return DateOf(t), nil
+}
+
+// ParseTime parses an ISO-8601 time string with no date component.
+// Examples: HH, HH:MM or HHMM, HH:MM:SS or HHMMSS, HH:MM:SS.ssssss.  (Plus an optional time zone portion.)
+//
+// If the string has no UTC offset, the result's Loc is the location configured with
+// WithLocation (time.Local by default), and HasOffset is false.
+func (p *Parser) ParseTime(timeString string) (TimeParts, error) {
+	v, err := p.parseTime(timeString)
+	p.observe(timeString, err)
+	return v, err
+}
+
+// parseTime does the work for ParseTime.
+func (p *Parser) parseTime(timeString string) (TimeParts, error) {
+	timeString = p.asciiDigits(timeString)
+	if err := p.syntax.check(timeString, "time"); err != nil {
+		return TimeParts{}, err
+	}
+	if p.isTruncated(timeString) {
+		t, err := p.parseTruncated(timeString)
+		if err != nil {
+			return TimeParts{}, err
+		}
+		return TimeParts{TimeOfDayOf(t), t.Location(), false}, nil
+	}
+	components, tz, hasOffset, err := parseISOTime(timeString)
+	if err != nil {
+		return TimeParts{}, p.hintFormats(diagnoseLookalike(err))
+	}
+	// parseISOTime doesn't check the ranges of the components, other than for the hour 24.
+	if err := checkRanges(minYear, time.January, 1, components[0], components[1], components[2], components[3], tz); err != nil {
+		return TimeParts{}, locateElement(timeString, 0, err)
+	}
+	tod := TimeOfDay{components[0], components[1], components[2], components[3]}
+	if hasOffset {
+		if err := p.checkOffsetMinutes(timeString, tz); err != nil {
+			return TimeParts{}, err
+		}
+	}
+	tz = p.offsetLocation(timeString, tz)
+	if !hasOffset {
+		tz = p.location()
+	}
+	return TimeParts{tod, tz, hasOffset}, nil
+}
@@ -0,0 +1,240 @@
+package isoparse
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var parserLocs = []*time.Location{
+	time.UTC,
+	offsetZone(-7 * 60 * 60),
+}
+
+func TestParserWithLocation(t *testing.T) {
+	for _, loc := range parserLocs {
+		p := NewParser(WithLocation(loc))
+		for datetime, c := range allFormats {
+			want := c.t
+			if want.Location() == time.Local {
+				want = SetLoc(want, loc)
+			}
+			if dt, err := p.Parse(datetime); err != nil {
+				t.Errorf(`Parse(%q) -> non-nil error (%v) for valid datetime string`, datetime, err)
+			} else if !dt.Equal(want) {
+				t.Errorf(`Parse(%q) with location %v -> %v (should be %v)`, datetime, loc, dt, want)
+			} else if c.t.Location() == time.Local && dt.Location() != loc {
+				t.Errorf(`Parse(%q) with location %v -> %v (naive input should get the configured location)`, datetime, loc, dt)
+			}
+		}
+		if parts, err := p.ParseTime("13:47:30"); err != nil || parts.Loc != loc {
+			t.Errorf(`ParseTime("13:47:30") with location %v -> (%v, %v) (should have the configured location)`, loc, parts, err)
+		}
+		if parts, err := p.ParseTime("13:47:30Z"); err != nil || parts.Loc != time.UTC {
+			t.Errorf(`ParseTime("13:47:30Z") with location %v -> (%v, %v) (should keep UTC)`, loc, parts, err)
+		}
+	}
+}
+
+func TestParserZeroValue(t *testing.T) {
+	var p Parser
+	for datetime, c := range allFormats {
+		if dt, err := p.Parse(datetime); err != nil {
+			t.Errorf(`Parse(%q) -> non-nil error (%v) for valid datetime string`, datetime, err)
+		} else if !dt.Equal(c.t) {
+			t.Errorf(`Parse(%q) -> %v (should be %v)`, datetime, dt, c.t)
+		}
+	}
+}
+
+func TestParserParseDate(t *testing.T) {
+	p := NewParser()
+	for dateString, trueDate := range commonDates {
+		if d, err := p.ParseDate(dateString); err != nil {
+			t.Errorf(`ParseDate(%q) -> non-nil error (%v) for valid date string`, dateString, err)
+		} else if d != DateOf(trueDate) {
+			t.Errorf(`ParseDate(%q) -> %v (should be %v)`, dateString, d, DateOf(trueDate))
+		}
+	}
+	for _, dateString := range invalidDates {
+		if d, err := p.ParseDate(dateString); err == nil {
+			t.Errorf(`ParseDate(%q) -> %v returned nil error (invalid dateString should error)`, dateString, d)
+		}
+	}
+}
+
+func TestUnknownOffset(t *testing.T) {
+	p := NewParser(WithUnknownOffset())
+	for _, s := range []string{"2018-09-27T05:00:00-00:00", "20180927T050000-0000", "2018-09-27T05:00-00"} {
+		got, err := p.Parse(s)
+		if err != nil || got.Location() != UnknownOffset || !got.Equal(time.Date(2018, 9, 27, 5, 0, 0, 0, time.UTC)) {
+			t.Errorf(`Parse(%q) with WithUnknownOffset -> %v, %v (should be 05:00 UTC in UnknownOffset)`, s, got, err)
+		}
+		if got, _ := ParseISODatetime(s); got.Location() != time.UTC {
+			t.Errorf(`ParseISODatetime(%q) -> %v (should be in time.UTC by default)`, s, got)
+		}
+	}
+	for _, s := range []string{"2018-09-27T05:00:00Z", "2018-09-27T05:00:00+00:00"} {
+		if got, _ := p.Parse(s); got.Location() != time.UTC {
+			t.Errorf(`Parse(%q) with WithUnknownOffset -> %v (should be in time.UTC)`, s, got)
+		}
+	}
+	if parts, err := p.ParseTime("05:00-00:00"); err != nil || parts.Loc != UnknownOffset {
+		t.Errorf(`ParseTime("05:00-00:00") with WithUnknownOffset -> %v, %v (should be in UnknownOffset)`, parts, err)
+	}
+
+	tm := time.Date(2018, 9, 27, 5, 0, 0, 0, UnknownOffset)
+	for style, want := range map[string]string{"": "2018-09-27T05:00:00-00:00", "basic": "20180927T050000-0000", "time": "05:00:00-00:00", "utc": "2018-09-27T05:00:00Z"} {
+		if got, _ := FormatISO(tm, style); got != want {
+			t.Errorf(`FormatISO(%v, %q) -> %q (should be %q)`, tm, style, got, want)
+		}
+	}
+}
+
+func TestOffsetResolver(t *testing.T) {
+	berlin := loadLocation(t, "Europe/Berlin")
+	var calls int
+	p := NewParser(WithOffsetResolver(func(secondsEast int, t time.Time) *time.Location {
+		calls++
+		if secondsEast == 5*60*60 {
+			return nil // Not one of ours; keep the fixed zone.
+		}
+		return berlin
+	}))
+	cases := map[string]time.Time{
+		"2018-01-15T12:00:00+01:00": time.Date(2018, 1, 15, 12, 0, 0, 0, berlin),
+		"2018-07-15T12:00:00+02:00": time.Date(2018, 7, 15, 12, 0, 0, 0, berlin),
+		"2018-07-15T12:00:00+05:00": time.Date(2018, 7, 15, 12, 0, 0, 0, offsetZone(5*60*60)),
+	}
+	for s, want := range cases {
+		got, err := p.Parse(s)
+		if err != nil || !got.Equal(want) || got.Location().String() != want.Location().String() {
+			t.Errorf(`Parse(%q) with resolver -> %v, %v (should be %v)`, s, got, err, want)
+		}
+	}
+	// +01:00 is not Berlin's offset in July.
+	if got, err := p.Parse("2018-07-15T12:00:00+01:00"); err == nil {
+		t.Errorf(`Parse("2018-07-15T12:00:00+01:00") with Berlin resolver -> %v (should be an error)`, got)
+	}
+	// Strings without an offset don't consult the resolver.
+	calls = 0
+	if _, err := p.Parse("2018-07-15T12:00:00"); err != nil || calls != 0 {
+		t.Errorf(`Parse of a naive string -> %v with %d resolver calls (should be nil with none)`, err, calls)
+	}
+}
+
+func TestOffsetMinutes(t *testing.T) {
+	p := NewParser(WithOffsetMinutes(0, 15, 30, 45))
+	for _, s := range []string{"2018-09-27T12:00+05:45", "2018-09-27T12:00-0330", "2018-09-27T12:00Z", "2018-09-27T12:00+05", "2018-09-27T12:00"} {
+		if _, err := p.Parse(s); err != nil {
+			t.Errorf(`Parse(%q) with quarter-hour offsets -> non-nil error (%v)`, s, err)
+		}
+	}
+	for _, s := range []string{"2018-09-27T12:00+05:07", "2018-09-27T12:00-0001"} {
+		if got, err := p.Parse(s); err == nil {
+			t.Errorf(`Parse(%q) with quarter-hour offsets -> %v (should be an error)`, s, got)
+		}
+	}
+	if _, err := p.ParseTime("12:00+05:07"); err == nil {
+		t.Errorf(`ParseTime("12:00+05:07") with quarter-hour offsets returned nil error`)
+	}
+	if _, err := NewParser(WithOffsetMinutes(0), WithOffsetMinutes()).Parse("2018-09-27T12:00+05:07"); err != nil {
+		t.Errorf(`Parse with the restriction removed -> non-nil error (%v)`, err)
+	}
+}
+
+func TestDateAnchor(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no zone data: %v", err)
+	}
+	p := NewParser(WithLocation(ny), WithDateAnchor(TimeOfDay{Hour: 12}))
+	for s, want := range map[string]time.Time{
+		"2018-09-27":              time.Date(2018, 9, 27, 12, 0, 0, 0, ny),
+		"2018-W39-4":              time.Date(2018, 9, 27, 12, 0, 0, 0, ny),
+		"2018-09":                 time.Date(2018, 9, 1, 12, 0, 0, 0, ny),
+		"2018-09-27[Asia/Tokyo]":  time.Date(2018, 9, 27, 3, 0, 0, 0, time.UTC),
+		"2018-09-27T00:00":        time.Date(2018, 9, 27, 0, 0, 0, 0, ny),
+		"2018-09-27T05:00:00Z":    time.Date(2018, 9, 27, 5, 0, 0, 0, time.UTC),
+		"2018-09-27T00:00:00.000": time.Date(2018, 9, 27, 0, 0, 0, 0, ny),
+	} {
+		if got, err := p.Parse(s); err != nil || !got.Equal(want) {
+			t.Errorf(`Parse(%q) anchored at noon -> (%v, %v) (should be %v)`, s, got, err, want)
+		}
+	}
+	// The anchored date stays on the same day when moved up to twelve hours either way.
+	got, _ := NewParser(WithLocation(time.UTC), WithDateAnchor(TimeOfDay{Hour: 12})).Parse("2018-09-27")
+	for _, offset := range []int{-11 * 60 * 60, 11*60*60 + 59*60} {
+		if d := DateOf(got.In(time.FixedZone("", offset))); d != (Date{2018, time.September, 27}) {
+			t.Errorf(`Parse("2018-09-27") anchored at noon UTC -> %v at offset %d (should stay on 2018-09-27)`, d, offset)
+		}
+	}
+
+	xsd := NewParser(WithProfile(XSD), WithDateAnchor(TimeOfDay{Hour: 12}))
+	if got, err := xsd.Parse("2018-09-27-05:00"); err != nil || !got.Equal(time.Date(2018, 9, 27, 17, 0, 0, 0, time.UTC)) {
+		t.Errorf(`XSD Parse("2018-09-27-05:00") anchored at noon -> (%v, %v)`, got, err)
+	}
+
+	for _, anchor := range []TimeOfDay{{Hour: 24}, {Hour: 12, Minute: 60}} {
+		p := NewParser(WithLocation(time.UTC), WithDateAnchor(TimeOfDay{Hour: 12}), WithDateAnchor(anchor))
+		if got, err := p.Parse("2018-09-27"); err != nil || !got.Equal(time.Date(2018, 9, 27, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf(`Parse("2018-09-27") with anchor %v -> (%v, %v) (should be the start of the day)`, anchor, got, err)
+		}
+	}
+}
+
+func TestCompleteDates(t *testing.T) {
+	p := NewParser(WithLocation(time.UTC), WithCompleteDates())
+	for _, s := range []string{"2018-09-27", "20180927", "2018-W39-4", "2018W394", "2018-270", "2018270", "2018-09-27T05:00Z"} {
+		if _, err := p.Parse(s); err != nil {
+			t.Errorf(`Parse(%q) with complete dates -> %v`, s, err)
+		}
+	}
+	for s, element := range map[string]string{
+		"2018":     "month",
+		"2018-09":  "day",
+		"2018-W39": "day",
+		"2018W39":  "day",
+	} {
+		var e *ParseError
+		for method, parse := range map[string]func(string) error{
+			"Parse":         func(s string) error { _, err := p.Parse(s); return err },
+			"ParseDate":     func(s string) error { _, err := p.ParseDate(s); return err },
+			"ParseDateTime": func(s string) error { _, err := p.ParseDateTime(s); return err },
+		} {
+			if err := parse(s); !errors.As(err, &e) || e.Err != ErrSyntax || e.Element != element || e.Pos != len(s) {
+				t.Errorf(`%s(%q) with complete dates -> %v (should be a syntax error in the %s)`, method, s, err, element)
+			}
+		}
+	}
+}
+
+func TestParseTrailingData(t *testing.T) {
+	for s, pos := range map[string]int{
+		"2018-09-27T05:00:00Zjunk":      20,
+		"2018-09-27T05:00:00Z ":         20,
+		"2018-09-27T05:00:00+05:30\n":   25,
+		"2018-09-27T05:00:00+0530x":     24,
+		"2018-09-27T05:00+05 UTC":       19,
+		"2018-09-27T05:00:00.5 ":        21,
+		"2018-09-27T05:00:00.5xyz":      21,
+		"20180927T050000-0800 (PST)":    20,
+		"2018-09-27T05:00:00.123Z\x00":  24,
+		"2018-09-27T05:00:00+05:30 foo": 25,
+	} {
+		var e *ParseError
+		if _, err := ParseISODatetime(s); !errors.As(err, &e) || e.Err != ErrTrailingData || e.Pos != pos {
+			t.Errorf(`ParseISODatetime(%q) -> %v (should wrap %v at byte %d)`, s, err, ErrTrailingData, pos)
+		}
+		// ParsePrefix stops where the datetime does.
+		if _, rest, err := ParsePrefix(s); err != nil || rest != s[pos:] {
+			t.Errorf(`ParsePrefix(%q) -> (%q, %v) (should leave %q)`, s, rest, err, s[pos:])
+		}
+	}
+	// A malformed offset is still an offset error.
+	for _, s := range []string{"2018-09-27T05:00:00+053", "2018-09-27T05:00:00+05:3", "2018-09-27T05:00:00+05:300"} {
+		if _, err := ParseISODatetime(s); !errors.Is(err, ErrInvalidOffset) {
+			t.Errorf(`ParseISODatetime(%q) -> %v (should wrap %v)`, s, err, ErrInvalidOffset)
+		}
+	}
+}
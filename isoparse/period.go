@@ -0,0 +1,365 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Designators of a duration, in the order they must appear.  The first four come before
+// the 'T' time designator and the last three after it.
+const (
+	slotYears = iota
+	slotMonths
+	slotWeeks
+	slotDays
+	slotHours
+	slotMinutes
+	slotSeconds
+)
+
+// Period represents an ISO-8601 duration, such as "P1Y2M10DT2H30M" (ISO 8601:2004 4.4.3).
+//
+// A Period is not the same thing as a time.Duration.  The calendar components (years,
+// months, weeks, and days) have no fixed length: P1M is 28 days long when added to
+// February 1st and 31 days long when added to March 1st, and P1D is 23 hours long
+// on the day of a spring-forward DST transition.  Only the clock components
+// (hours, minutes, seconds, nanoseconds) are exact.
+//
+// The components are kept separately, exactly as given, rather than being normalized,
+// so "PT90M" stays 90 minutes rather than becoming 1 hour and 30 minutes.
+// A negative period (an extension to ISO-8601 permitted by XML Schema, among
+// others) is represented by setting Negative; the components themselves are never negative.
+// Nanoseconds is less than one second in a parsed Period; in one built by hand, any whole
+// seconds in it are carried into the seconds when it is formatted.
+type Period struct {
+	Negative    bool
+	Years       int
+	Months      int
+	Weeks       int
+	Days        int
+	Hours       int
+	Minutes     int
+	Seconds     int
+	Nanoseconds int
+}
+
+// IsZero reports whether p has no nonzero components.
+func (p Period) IsZero() bool {
+	return p.Years == 0 && p.Months == 0 && p.Weeks == 0 && p.Days == 0 &&
+		p.Hours == 0 && p.Minutes == 0 && p.Seconds == 0 && p.Nanoseconds == 0
+}
+
+// String returns p in ISO-8601 format, such as "P1Y2M10DT2H30M".
+// Zero components are omitted; the zero Period is written as "PT0S".
+func (p Period) String() string {
+	if p.IsZero() {
+		return "PT0S"
+	}
+	var b strings.Builder
+	if p.Negative {
+		b.WriteByte('-')
+	}
+	b.WriteByte('P')
+	for _, c := range [...]struct {
+		n    int
+		unit byte
+	}{{p.Years, 'Y'}, {p.Months, 'M'}, {p.Weeks, 'W'}, {p.Days, 'D'}} {
+		if c.n != 0 {
+			b.WriteString(strconv.Itoa(c.n))
+			b.WriteByte(c.unit)
+		}
+	}
+	if p.Hours == 0 && p.Minutes == 0 && p.Seconds == 0 && p.Nanoseconds == 0 {
+		return b.String()
+	}
+	b.WriteByte('T')
+	if p.Hours != 0 {
+		b.WriteString(strconv.Itoa(p.Hours))
+		b.WriteByte('H')
+	}
+	if p.Minutes != 0 {
+		b.WriteString(strconv.Itoa(p.Minutes))
+		b.WriteByte('M')
+	}
+	if p.Seconds != 0 || p.Nanoseconds != 0 {
+		seconds, nanos := p.Seconds+p.Nanoseconds/1e9, p.Nanoseconds%1e9
+		b.WriteString(strconv.Itoa(seconds))
+		if nanos != 0 {
+			frac := strconv.Itoa(nanos + 1e9)[1:]
+			b.WriteByte('.')
+			b.WriteString(strings.TrimRight(frac, "0"))
+		}
+		b.WriteByte('S')
+	}
+	return b.String()
+}
+
+// ParseISODuration parses an ISO-8601 duration string (ISO 8601:2004 4.4.3.2) into a Period.
+// Examples: P1Y2M10DT2H30M, P3W, PT36H, PT0.5S, -P1D.
+//
+// A leading '-' (or '+') sign is accepted as an extension.  A decimal fraction (with either
+// '.' or ',') is accepted on the last component only, and only on the hours, minutes, or
+// seconds; a fraction of an hour or minute is carried down into the smaller components,
+// so PT1.5H becomes PT1H30M.  The alternative format "PYYYY-MM-DDThh:mm:ss" is not supported.
+//
+// Each component is limited to nine digits, and the hours, minutes, and seconds together
+// to the range of a time.Duration (about 292 years), so that AddTo and Compare are exact;
+// larger durations are errors wrapping ErrOverflow.
+//
+// It is a thin wrapper around Parser.ParseDuration for a Parser with default options.
+func ParseISODuration(durationString string) (Period, error) {
+	return defaultParser.ParseDuration(durationString)
+}
+
+// ParseDuration parses an ISO-8601 duration string into a Period.  See ParseISODuration.
+func (p *Parser) ParseDuration(durationString string) (Period, error) {
+	durationString = p.asciiDigits(durationString)
+	if err := p.syntax.check(durationString, "duration"); err != nil {
+		return Period{}, err
+	}
+	period, err := parseISODuration(durationString)
+	if err != nil {
+		return Period{}, p.hintFormats(diagnoseLookalike(err))
+	}
+	return period, nil
+}
+
+// parseISODuration does the work for ParseDuration.
+func parseISODuration(durationString string) (period Period, err error) {
+	length := len(durationString)
+	pos := 0
+	if length > 0 && (durationString[0] == '-' || durationString[0] == '+') {
+		period.Negative = durationString[0] == '-'
+		pos++
+	}
+	if pos >= length || durationString[pos] != 'P' {
+		return Period{}, &ParseError{durationString, "duration must begin with P", pos, "duration", ErrSyntax}
+	}
+	pos++
+
+	// `next` is the earliest designator slot that may still appear.  It enforces ordering
+	// and prevents repeats.
+	next, inTime, timeComponents, components := slotYears, false, 0, 0
+	hasFrac := false
+	for pos < length {
+		if durationString[pos] == 'T' {
+			if inTime {
+				return Period{}, &ParseError{durationString, "repeated time designator", pos, "duration", ErrSyntax}
+			}
+			inTime, next = true, slotHours
+			pos++
+			continue
+		}
+		if hasFrac {
+			return Period{}, &ParseError{durationString, "fraction is only allowed on the last component", pos, "duration", ErrSyntax}
+		}
+
+		start := pos
+		for pos < length && isDigit(durationString[pos]) {
+			pos++
+		}
+		if pos == start {
+			return Period{}, &ParseError{durationString, "expected digits", pos, "duration", ErrSyntax}
+		}
+		if pos-start > 9 {
+			// Keep the components themselves clear of overflow; the clock total is
+			// checked once they are all in.
+			return Period{}, &ParseError{durationString, "duration component too large", start, "duration", ErrOverflow}
+		}
+		n, _ := parseDigits(durationString[start:pos])
+
+		var frac string
+		if pos < length && (durationString[pos] == '.' || durationString[pos] == ',') {
+			pos++
+			fracStart := pos
+			for pos < length && isDigit(durationString[pos]) {
+				pos++
+			}
+			if pos == fracStart {
+				return Period{}, &ParseError{durationString, "expected digits after decimal sign", pos, "duration", ErrSyntax}
+			}
+			frac, hasFrac = durationString[fracStart:pos], true
+		}
+
+		if pos >= length {
+			return Period{}, &ParseError{durationString, "missing designator", pos, "duration", ErrSyntax}
+		}
+		slot := durationSlot(durationString[pos], inTime)
+		pos++
+		if slot < 0 {
+			return Period{}, &ParseError{durationString, "unknown designator", pos - 1, "duration", ErrSyntax}
+		}
+		if slot < next {
+			return Period{}, &ParseError{durationString, "designators out of order or repeated", pos - 1, "duration", ErrSyntax}
+		}
+		next = slot + 1
+		components++
+		if inTime {
+			timeComponents++
+		}
+
+		switch slot {
+		case slotYears:
+			period.Years = n
+		case slotMonths:
+			period.Months = n
+		case slotWeeks:
+			period.Weeks = n
+		case slotDays:
+			period.Days = n
+		case slotHours:
+			period.Hours = n
+		case slotMinutes:
+			period.Minutes = n
+		case slotSeconds:
+			period.Seconds = n
+		}
+		if frac != "" {
+			if slot < slotHours {
+				return Period{}, &ParseError{durationString, "fractional calendar components are not supported", pos - 1, "duration", ErrUnsupported}
+			}
+			period.addFraction(slot, frac)
+		}
+	}
+
+	if components == 0 {
+		return Period{}, &ParseError{durationString, "duration has no components", -1, "duration", ErrSyntax}
+	}
+	if inTime && timeComponents == 0 {
+		return Period{}, &ParseError{durationString, "time designator must be followed by a time component", -1, "duration", ErrSyntax}
+	}
+	if _, ok := period.clockDuration(); !ok {
+		return Period{}, &ParseError{durationString, "clock components exceed the range of time.Duration", -1, "duration", ErrOverflow}
+	}
+	return period, nil
+}
+
+// durationSlot maps a designator character to its slot, or -1 if there is none.
+// 'M' means months before the time designator and minutes after it.
+func durationSlot(c byte, inTime bool) int {
+	if inTime {
+		switch c {
+		case 'H':
+			return slotHours
+		case 'M':
+			return slotMinutes
+		case 'S':
+			return slotSeconds
+		}
+		return -1
+	}
+	switch c {
+	case 'Y':
+		return slotYears
+	case 'M':
+		return slotMonths
+	case 'W':
+		return slotWeeks
+	case 'D':
+		return slotDays
+	}
+	return -1
+}
+
+// addFraction carries a decimal fraction (digits after the decimal sign) of an hour,
+// minute, or second down into the smaller clock components.
+// As with times, digits beyond nanosecond precision are truncated.
+func (p *Period) addFraction(slot int, digits string) {
+	// Billionths of one unit.
+	billionths := fractionNanos(digits)
+	var ns int64
+	switch slot {
+	case slotHours:
+		ns = int64(billionths) * 3600
+	case slotMinutes:
+		ns = int64(billionths) * 60
+	default:
+		ns = int64(billionths)
+	}
+	p.Minutes += int(ns / int64(time.Minute))
+	ns %= int64(time.Minute)
+	p.Seconds += int(ns / int64(time.Second))
+	p.Nanoseconds += int(ns % int64(time.Second))
+}
+
+// isDigit reports whether c is an ASCII digit.
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// AddTo returns t shifted by p (or shifted back, if p is Negative).
+//
+// The calendar components are applied first, to the wall-clock date of t in its own
+// location, and are clamped rather than normalized in the same manner as Date.AddMonths:
+// January 31 plus P1M is the last day of February.  The clock components are then added
+// as an exact time.Duration.
+func (p Period) AddTo(t time.Time) time.Time {
+	sign := p.sign()
+	d := DateOf(t).AddMonths(sign * (p.Years*12 + p.Months)).AddDays(sign * (p.Weeks*7 + p.Days))
+	shifted := time.Date(d.Year, d.Month, d.Day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	return shifted.Add(p.clock())
+}
+
+// sign returns -1 for a negative period and 1 otherwise.
+func (p Period) sign() int {
+	if p.Negative {
+		return -1
+	}
+	return 1
+}
+
+// clock returns the exact (clock) portion of p as a time.Duration, with p's sign applied.
+func (p Period) clock() time.Duration {
+	d, _ := p.clockDuration()
+	return time.Duration(p.sign()) * d
+}
+
+// clockDuration returns the clock portion of p as a time.Duration, without p's sign, and
+// whether it fits in one.  ParseDuration rejects periods for which it doesn't.
+func (p Period) clockDuration() (d time.Duration, ok bool) {
+	d, ok = time.Duration(p.Nanoseconds), true
+	for _, c := range [...]struct {
+		n    int
+		unit time.Duration
+	}{{p.Seconds, time.Second}, {p.Minutes, time.Minute}, {p.Hours, time.Hour}} {
+		if c.n > 0 && d >= 0 && time.Duration(c.n) > (math.MaxInt64-d)/c.unit {
+			ok = false
+		}
+		d += time.Duration(c.n) * c.unit
+	}
+	return d, ok
+}
+
+// Compare compares p and other, returning -1, 0, or +1.
+//
+// Because the calendar components have no fixed length, there is no ordering of
+// periods that agrees with elapsed time in every context (is P1M longer than P30D?).
+// Compare instead defines a total order that is consistent within each class of
+// component: periods are ordered first by their total months (years*12 + months),
+// then by total days (weeks*7 + days), then by the exact clock duration.
+// Thus P1Y and P12M compare as equal, as do P1W and P7D, and PT1H and PT60M.
+func (p Period) Compare(other Period) int {
+	pm, om := p.sign()*(p.Years*12+p.Months), other.sign()*(other.Years*12+other.Months)
+	if c := cmpInt(pm, om); c != 0 {
+		return c
+	}
+	pd, od := p.sign()*(p.Weeks*7+p.Days), other.sign()*(other.Weeks*7+other.Days)
+	if c := cmpInt(pd, od); c != 0 {
+		return c
+	}
+	return cmpInt(int(p.clock()), int(other.clock()))
+}
+
+// Before reports whether p orders before other.  See Compare.
+func (p Period) Before(other Period) bool { return p.Compare(other) < 0 }
+
+// After reports whether p orders after other.  See Compare.
+func (p Period) After(other Period) bool { return p.Compare(other) > 0 }
+
+// Equal reports whether p and other compare as equal.  See Compare.
+func (p Period) Equal(other Period) bool { return p.Compare(other) == 0 }
@@ -0,0 +1,200 @@
+package isoparse
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+var periodStrings = map[Period]string{
+	{}:                                  "PT0S",
+	{Years: 1, Months: 2, Days: 10}:     "P1Y2M10D",
+	{Hours: 2, Minutes: 30}:             "PT2H30M",
+	{Weeks: 3}:                          "P3W",
+	{Days: 1, Seconds: 1}:               "P1DT1S",
+	{Seconds: 0, Nanoseconds: 5e8}:      "PT0.5S",
+	{Minutes: 90}:                       "PT90M",
+	{Negative: true, Days: 2, Hours: 1}: "-P2DT1H",
+}
+
+// Each pair is ordered: the first element is strictly before the second.
+var orderedPeriods = [][2]Period{
+	{{Days: 1}, {Days: 2}},
+	{{Days: 40}, {Months: 1}},
+	{{Weeks: 1}, {Days: 8}},
+	{{Hours: 23}, {Days: 1}},
+	{{Negative: true, Days: 1}, {}},
+	{{Negative: true, Months: 2}, {Negative: true, Months: 1}},
+	{{Minutes: 59, Seconds: 59}, {Hours: 1}},
+}
+
+// Each pair compares as equal, even though the components differ.
+var equalPeriods = [][2]Period{
+	{{Years: 1}, {Months: 12}},
+	{{Weeks: 1}, {Days: 7}},
+	{{Hours: 1}, {Minutes: 60}},
+	{{Seconds: 1}, {Nanoseconds: 1e9}},
+	{{}, {Negative: true}},
+}
+
+func TestPeriodString(t *testing.T) {
+	for p, want := range periodStrings {
+		if got := p.String(); got != want {
+			t.Errorf(`%#v.String() -> %q (should be %q)`, p, got, want)
+		}
+	}
+	// Whole seconds in Nanoseconds are carried, so that the result parses back.
+	for p, want := range map[Period]string{
+		{Seconds: 1, Nanoseconds: 2e9}:       "PT3S",
+		{Nanoseconds: 1500000000}:            "PT1.5S",
+		{Minutes: 1, Nanoseconds: 61e9 + 25}: "PT1M61.000000025S",
+	} {
+		if got := p.String(); got != want {
+			t.Errorf(`%#v.String() -> %q (should be %q)`, p, got, want)
+		}
+		if again, err := ParseISODuration(p.String()); err != nil || !again.Equal(p) {
+			t.Errorf(`ParseISODuration(%q) -> %v, %v (should equal %#v)`, p.String(), again, err, p)
+		}
+	}
+}
+
+func TestPeriodCompare(t *testing.T) {
+	for _, pair := range orderedPeriods {
+		a, b := pair[0], pair[1]
+		if a.Compare(b) != -1 || b.Compare(a) != 1 || !a.Before(b) || !b.After(a) || a.Equal(b) {
+			t.Errorf(`Period comparison of %v and %v is inconsistent`, a, b)
+		}
+	}
+	for _, pair := range equalPeriods {
+		a, b := pair[0], pair[1]
+		if !a.Equal(b) || a.Compare(b) != 0 || a.Before(b) || a.After(b) {
+			t.Errorf(`Period %v and %v should compare as equal`, a, b)
+		}
+	}
+}
+
+var isoDurations = map[string]Period{
+	"P1Y2M10DT2H30M":   {Years: 1, Months: 2, Days: 10, Hours: 2, Minutes: 30},
+	"P3W":              {Weeks: 3},
+	"P1D":              {Days: 1},
+	"PT36H":            {Hours: 36},
+	"PT90M":            {Minutes: 90},
+	"PT0S":             {},
+	"P0D":              {},
+	"PT0.5S":           {Nanoseconds: 5e8},
+	"PT0,5S":           {Nanoseconds: 5e8},
+	"PT1.5H":           {Hours: 1, Minutes: 30},
+	"PT1.5M":           {Minutes: 1, Seconds: 30},
+	"PT2H0.25M":        {Hours: 2, Seconds: 15},
+	"PT1.123456789S":   {Seconds: 1, Nanoseconds: 123456789},
+	"PT1.1234567891S":  {Seconds: 1, Nanoseconds: 123456789}, // Truncated
+	"P1Y1M1W1DT1H1M1S": {Years: 1, Months: 1, Weeks: 1, Days: 1, Hours: 1, Minutes: 1, Seconds: 1},
+	"-P1D":             {Negative: true, Days: 1},
+	"+P1D":             {Days: 1},
+	"P10000Y":          {Years: 10000},
+
+	// The longest clock that fits in a time.Duration
+	"PT2562047H47M16.854775807S":  {Hours: 2562047, Minutes: 47, Seconds: 16, Nanoseconds: 854775807},
+	"-PT2562047H47M16.854775807S": {Negative: true, Hours: 2562047, Minutes: 47, Seconds: 16, Nanoseconds: 854775807},
+	"PT153722867M":                {Minutes: 153722867},
+}
+
+var invalidDurations = []string{
+	"",              // Empty
+	"P",             // No components
+	"PT",            // No time components
+	"P1DT",          // Time designator with no time components
+	"1D",            // Missing P
+	"PD",            // Missing digits
+	"P1",            // Missing designator
+	"P1H",           // Hours before the time designator
+	"PT1D",          // Days after the time designator
+	"P1M1Y",         // Out of order
+	"P1D1D",         // Repeated
+	"PT1S1M",        // Out of order
+	"P1.5Y",         // Fractional calendar component
+	"P1.5D",         // Fractional calendar component
+	"PT1.5H1M",      // Fraction not on the last component
+	"PT1.S",         // Fraction with no digits
+	"PT1H T1M",      // Junk
+	"P1DTT1H",       // Repeated time designator
+	"P1234567890Y",  // Too large
+	"P-1D",          // Negative component
+	"p1d",           // Lower case
+	"P1Y2M10DT2H30", // Missing designator
+}
+
+var overflowingDurations = []string{
+	"P1234567890Y",
+	"PT2562047H47M16.854775808S",
+	"PT2562048H",
+	"-PT2562048H",
+	"PT999999999H",
+	"PT153722868M",
+	"PT2562047H60M",
+	"PT2562047.9H",
+}
+
+func TestParseISODurationOverflow(t *testing.T) {
+	for _, s := range overflowingDurations {
+		if got, err := ParseISODuration(s); !errors.Is(err, ErrOverflow) {
+			t.Errorf(`ParseISODuration(%q) -> (%v, %v) (should wrap %v)`, s, got, err, ErrOverflow)
+		}
+	}
+	longest, _ := ParseISODuration("PT2562047H47M16.854775807S")
+	start := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got, want := longest.AddTo(start), start.Add(math.MaxInt64); !got.Equal(want) {
+		t.Errorf(`%v.AddTo(%v) -> %v (should be %v)`, longest, start, got, want)
+	}
+	if c := longest.Compare(Period{Hours: 1}); c != 1 {
+		t.Errorf(`%v.Compare(PT1H) -> %d (should be 1)`, longest, c)
+	}
+}
+
+func TestParseISODuration(t *testing.T) {
+	for s, want := range isoDurations {
+		if got, err := ParseISODuration(s); err != nil {
+			t.Errorf(`ParseISODuration(%q) -> non-nil error (%v) for valid duration`, s, err)
+		} else if got != want {
+			t.Errorf(`ParseISODuration(%q) -> %#v (should be %#v)`, s, got, want)
+		}
+	}
+	for _, s := range invalidDurations {
+		if got, err := ParseISODuration(s); err == nil {
+			t.Errorf(`ParseISODuration(%q) -> %v returned nil error (invalid duration should error)`, s, got)
+		}
+	}
+}
+
+// String and ParseISODuration should round trip.
+func TestPeriodRoundTrip(t *testing.T) {
+	for p, s := range periodStrings {
+		if got, err := ParseISODuration(s); err != nil {
+			t.Errorf(`ParseISODuration(%q) -> non-nil error (%v) for valid duration`, s, err)
+		} else if got != p && !(p.IsZero() && got.IsZero()) {
+			t.Errorf(`ParseISODuration(%q) -> %#v (should be %#v)`, s, got, p)
+		}
+	}
+}
+
+type periodAddition struct {
+	t time.Time
+	p Period
+}
+
+var periodAdditions = map[periodAddition]time.Time{
+	{time.Date(2018, 1, 31, 10, 0, 0, 0, time.UTC), Period{Months: 1}}:                 time.Date(2018, 2, 28, 10, 0, 0, 0, time.UTC),
+	{time.Date(2016, 2, 29, 10, 0, 0, 0, time.UTC), Period{Years: 1}}:                  time.Date(2017, 2, 28, 10, 0, 0, 0, time.UTC),
+	{time.Date(2018, 1, 31, 10, 0, 0, 0, time.UTC), Period{Days: 1, Hours: 14}}:        time.Date(2018, 2, 2, 0, 0, 0, 0, time.UTC),
+	{time.Date(2018, 3, 31, 10, 0, 0, 0, time.UTC), Period{Negative: true, Months: 1}}: time.Date(2018, 2, 28, 10, 0, 0, 0, time.UTC),
+	{time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC), Period{Weeks: 2, Nanoseconds: 1}}:    time.Date(2018, 1, 15, 0, 0, 0, 1, time.UTC),
+}
+
+func TestPeriodAddTo(t *testing.T) {
+	for c, want := range periodAdditions {
+		if got := c.p.AddTo(c.t); !got.Equal(want) {
+			t.Errorf(`%v.AddTo(%v) -> %v (should be %v)`, c.p, c.t, got, want)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"strconv"
+	"time"
+)
+
+// Precision is the smallest unit that an ISO-8601 string expresses: "2018-06" has month
+// precision, and "12:30" minute precision.  Fractions of a second count their digits, so
+// "12:30:00.250" has millisecond precision, SecondPrecision + 3.
+type Precision int
+
+const (
+	YearPrecision Precision = iota
+	MonthPrecision
+	WeekPrecision
+	DayPrecision
+	HourPrecision
+	MinutePrecision
+	SecondPrecision
+	MillisecondPrecision = SecondPrecision + 3
+	MicrosecondPrecision = SecondPrecision + 6
+	NanosecondPrecision  = SecondPrecision + 9
+)
+
+var precisionNames = [...]string{"year", "month", "week", "day", "hour", "minute", "second"}
+
+func (p Precision) String() string {
+	switch {
+	case p >= YearPrecision && p <= SecondPrecision:
+		return precisionNames[p]
+	case p > SecondPrecision && p <= NanosecondPrecision:
+		return "1e-" + strconv.Itoa(int(p-SecondPrecision)) + " second"
+	}
+	return "Precision(" + strconv.Itoa(int(p)) + ")"
+}
+
+// PrecisionOf returns the precision of s, a date, datetime, or time as accepted by
+// DetectFormat.  Week dates without a day have week precision, and ordinal dates day
+// precision.  A fraction of more than nine digits has NanosecondPrecision, since that is
+// as far as parsing goes.
+func PrecisionOf(s string) (Precision, error) {
+	format, timeStart, err := detectFormat(s)
+	if err != nil {
+		return 0, err
+	}
+	switch format {
+	case YearDate:
+		return YearPrecision, nil
+	case YearMonthDate:
+		return MonthPrecision, nil
+	case WeekDateExtended, WeekDateBasic:
+		if len(s) == len("2018W39")+btoi(format == WeekDateExtended) {
+			return WeekPrecision, nil
+		}
+	}
+	if timeStart < 0 {
+		return DayPrecision, nil
+	}
+	return timePrecision(s[timeStart:]), nil
+}
+
+// timePrecision returns the precision of timeString, a valid time with no date portion.
+// A time that is no more than a UTC offset, as in "-0530", reads as midnight and has
+// none of the time components, so its precision is just coarser than HourPrecision.
+func timePrecision(timeString string) Precision {
+	if !hasHour(timeString) {
+		return HourPrecision - 1
+	}
+	p, pos := HourPrecision, 2
+	for p < SecondPrecision {
+		if pos < len(timeString) && timeString[pos] == timeSep {
+			pos++
+		}
+		if pos >= len(timeString) || !isDigit(timeString[pos]) {
+			return p
+		}
+		p++
+		pos += 2
+	}
+	if pos < len(timeString) && (timeString[pos] == '.' || timeString[pos] == ',') {
+		for pos++; pos < len(timeString) && isDigit(timeString[pos]) && p < NanosecondPrecision; pos++ {
+			p++
+		}
+	}
+	return p
+}
+
+// hasHour reports whether timeString, a valid time with no date portion, starts with an
+// hour rather than a UTC offset.
+func hasHour(timeString string) bool {
+	return len(timeString) >= 2 && isDigit(timeString[0]) && isDigit(timeString[1])
+}
+
+// Truncate returns t with the components finer than p set to their minimum, on t's wall
+// clock and in t's location: with MonthPrecision, for example, the start of t's month.
+// WeekPrecision gives the Monday that starts t's ISO week.  This lets a time be compared
+// with a string at that string's own precision:
+//
+//	p, _ := isoparse.PrecisionOf("2018-06")
+//	start, _ := isoparse.ParseISODatetime("2018-06")
+//	inJune := p.Truncate(eventTime).Equal(start)
+//
+// As with time.Date, a truncated wall-clock time that falls in a daylight saving gap is
+// normalized.
+func (p Precision) Truncate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	nsec := t.Nanosecond()
+	switch {
+	case p <= YearPrecision:
+		month = time.January
+		fallthrough
+	case p == MonthPrecision:
+		day = 1
+		fallthrough
+	case p <= DayPrecision:
+		if p == WeekPrecision {
+			day -= isoWeekday(t) - 1
+		}
+		hour = 0
+		fallthrough
+	case p == HourPrecision:
+		min = 0
+		fallthrough
+	case p == MinutePrecision:
+		sec = 0
+		fallthrough
+	case p == SecondPrecision:
+		nsec = 0
+	case p < NanosecondPrecision:
+		unit := 1
+		for i := p; i < NanosecondPrecision; i++ {
+			unit *= 10
+		}
+		nsec -= nsec % unit
+	}
+	return time.Date(year, month, day, hour, min, sec, nsec, t.Location())
+}
@@ -0,0 +1,88 @@
+package isoparse
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var precisions = map[string]Precision{
+	"2018":                           YearPrecision,
+	"2018-06":                        MonthPrecision,
+	"2018-W39":                       WeekPrecision,
+	"2018W39":                        WeekPrecision,
+	"2018-W39-4":                     DayPrecision,
+	"2018W394":                       DayPrecision,
+	"2018-270":                       DayPrecision,
+	"20180927":                       DayPrecision,
+	"2018-09-27T-05:00":              DayPrecision, // An offset alone has no time components
+	"2018-09-27T12":                  HourPrecision,
+	"2018-09-27T12Z":                 HourPrecision,
+	"2018-09-27T12:30":               MinutePrecision,
+	"20180927T1230+0100":             MinutePrecision,
+	"2018-09-27T12:30:15":            SecondPrecision,
+	"2018-09-27T12:30:15.2":          SecondPrecision + 1,
+	"2018-09-27T12:30:15,250Z":       MillisecondPrecision,
+	"2018-W39-4T12:30:15.123456":     MicrosecondPrecision,
+	"2018-09-27T12:30:15.1234567891": NanosecondPrecision,
+	"12:30":                          MinutePrecision,
+	"123015.5-08:00":                 SecondPrecision + 1,
+}
+
+func TestPrecisionOf(t *testing.T) {
+	for s, want := range precisions {
+		if got, err := PrecisionOf(s); err != nil || got != want {
+			t.Errorf(`PrecisionOf(%q) -> (%v, %v) (should be %v)`, s, got, err, want)
+		}
+	}
+	if _, err := PrecisionOf("2018-02-30"); !errors.Is(err, ErrInvalidDay) {
+		t.Errorf(`PrecisionOf("2018-02-30") -> %v (should wrap ErrInvalidDay)`, err)
+	}
+}
+
+func TestPrecisionTruncate(t *testing.T) {
+	tm := time.Date(2018, 9, 27, 12, 30, 15, 123456789, time.UTC) // A Thursday
+	for p, want := range map[Precision]time.Time{
+		YearPrecision:        time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+		MonthPrecision:       time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC),
+		WeekPrecision:        time.Date(2018, 9, 24, 0, 0, 0, 0, time.UTC),
+		DayPrecision:         time.Date(2018, 9, 27, 0, 0, 0, 0, time.UTC),
+		HourPrecision:        time.Date(2018, 9, 27, 12, 0, 0, 0, time.UTC),
+		MinutePrecision:      time.Date(2018, 9, 27, 12, 30, 0, 0, time.UTC),
+		SecondPrecision:      time.Date(2018, 9, 27, 12, 30, 15, 0, time.UTC),
+		SecondPrecision + 1:  time.Date(2018, 9, 27, 12, 30, 15, 100000000, time.UTC),
+		MillisecondPrecision: time.Date(2018, 9, 27, 12, 30, 15, 123000000, time.UTC),
+		NanosecondPrecision:  tm,
+	} {
+		if got := p.Truncate(tm); !got.Equal(want) {
+			t.Errorf(`%v.Truncate(%v) -> %v (should be %v)`, p, tm, got, want)
+		}
+	}
+}
+
+// Truncating a parsed time to its string's precision must leave it as it is.
+func TestPrecisionTruncateParsed(t *testing.T) {
+	for s := range precisions {
+		tm, err := ParseISODatetime(s)
+		if err != nil {
+			continue // A time with no date
+		}
+		p, _ := PrecisionOf(s)
+		if got := p.Truncate(tm); !got.Equal(tm) {
+			t.Errorf(`PrecisionOf(%q).Truncate(%v) -> %v (should be unchanged)`, s, tm, got)
+		}
+	}
+}
+
+func TestPrecisionString(t *testing.T) {
+	for p, want := range map[Precision]string{
+		MonthPrecision:       "month",
+		SecondPrecision:      "second",
+		MillisecondPrecision: "1e-3 second",
+		Precision(-1):        "Precision(-1)",
+	} {
+		if got := p.String(); got != want {
+			t.Errorf(`Precision(%d).String() -> %q (should be %q)`, int(p), got, want)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"strings"
+	"time"
+)
+
+// ParsePrefix parses the longest ISO-8601 datetime at the start of s, as with
+// ParseISODatetime, and returns the rest of s after it.  It is meant for log lines:
+//
+//	t, rest, err := isoparse.ParsePrefix("2024-01-02T03:04:05Z level=info msg=...")
+//	// rest is " level=info msg=..."
+//
+// A space is taken as the date/time separator only when an extended time (hh:mm) follows
+// it, as in "2024-01-02 03:04:05 ...".  An RFC 9557 annotation directly after the
+// datetime is part of it.  If no prefix of s is a datetime, the error is the one for
+// the longest candidate.
+func ParsePrefix(s string) (t time.Time, rest string, err error) {
+	return defaultParser.ParsePrefix(s)
+}
+
+// ParsePrefix is like the package-level ParsePrefix, but parses with p.
+func (p *Parser) ParsePrefix(s string) (t time.Time, rest string, err error) {
+	if t, n := p.longestPrefix(s); n > 0 {
+		p.observe(s[:n], nil)
+		return t, s[n:], nil
+	}
+	candidate := s[:prefixCandidate(s)]
+	_, err = p.parseCached(candidate)
+	p.observe(candidate, err)
+	return time.Time{}, s, err
+}
+
+// longestPrefix returns the longest prefix of s that p parses as a datetime, as the
+// parsed time and the prefix's length.  The length is 0 if there is none.  The prefixes
+// it tries aren't reported to p's hooks; that is for the caller, once it has a result.
+func (p *Parser) longestPrefix(s string) (time.Time, int) {
+	for n := prefixCandidate(s); n >= len("YYYY"); n-- {
+		if t, err := p.parseCached(s[:n]); err == nil {
+			return t, n
+		}
+	}
+	return time.Time{}, 0
+}
+
+// maxCandidateLen caps the run of characters that prefixCandidate considers.  Every
+// datetime worth finding is far shorter ("2018-09-27T05:00:00.123456789+05:30" is 35
+// bytes), and the cap keeps the search through shorter prefixes cheap on long runs.
+const maxCandidateLen = 64
+
+// prefixCandidate returns the length of the longest prefix of s that could possibly be a
+// datetime: a run of the characters that datetimes are made of, plus any annotations.
+func prefixCandidate(s string) int {
+	end := 0
+	for end < len(s) && end < maxCandidateLen {
+		c := s[end]
+		if isDigit(c) || strings.IndexByte("-:.,+TWZ", c) >= 0 {
+			end++
+			continue
+		}
+		// A space separating the date from an extended time.
+		if c == ' ' && end+3 < len(s) && isDigit(s[end+1]) && isDigit(s[end+2]) && s[end+3] == timeSep {
+			end++
+			continue
+		}
+		break
+	}
+	for end < len(s) && s[end] == '[' {
+		n := strings.IndexByte(s[end:], ']')
+		if n < 0 {
+			break
+		}
+		end += n + 1
+	}
+	return end
+}
+
+// SplitLeadingTimestamp splits a log line that begins with a timestamp into the parsed
+// timestamp and the rest of the line, for log shippers:
+//
+//	t, rest, ok := isoparse.SplitLeadingTimestamp("2024-01-02T03:04:05Z - INFO started")
+//	// rest is "INFO started"
+//
+// The timestamp may be in square brackets, as in "[2024-01-02 03:04:05] ...", and the
+// separator after it is dropped: whitespace, and a "-", "|", or ":" set off by whitespace.
+// As with FindAll, the timestamp must be a datetime or an extended date, not a bare number,
+// and must not run on into a word.  If the line doesn't begin with one, ok is false and rest
+// is the whole line.
+func SplitLeadingTimestamp(line string) (t time.Time, rest string, ok bool) {
+	return defaultParser.SplitLeadingTimestamp(line)
+}
+
+// SplitLeadingTimestamp is like the package-level SplitLeadingTimestamp, but parses with p.
+func (p *Parser) SplitLeadingTimestamp(line string) (t time.Time, rest string, ok bool) {
+	s := line
+	bracketed := len(s) > 0 && s[0] == '['
+	if bracketed {
+		s = s[1:]
+	}
+	t, n := p.longestPrefix(s)
+	if n == 0 || !findable(s[:n]) {
+		return time.Time{}, line, false
+	}
+	stamp := s[:n]
+	s = s[n:]
+	if bracketed {
+		if len(s) == 0 || s[0] != ']' {
+			return time.Time{}, line, false
+		}
+		s = s[1:]
+	} else if len(s) > 0 && isWordByte(s[0]) {
+		return time.Time{}, line, false
+	}
+	s = strings.TrimLeft(s, " \t")
+	if len(s) > 0 && strings.IndexByte("-|:", s[0]) >= 0 && (len(s) == 1 || s[1] == ' ' || s[1] == '\t') {
+		s = strings.TrimLeft(s[1:], " \t")
+	}
+	p.observe(stamp, nil)
+	return t, s, true
+}
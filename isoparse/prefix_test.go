@@ -0,0 +1,68 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePrefix(t *testing.T) {
+	cases := []struct {
+		s    string
+		want time.Time
+		rest string
+	}{
+		{"2024-01-02T03:04:05Z level=info msg=hello", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), " level=info msg=hello"},
+		{"2024-01-02T03:04:05.123+00:00\tGET /", time.Date(2024, 1, 2, 3, 4, 5, 123e6, time.UTC), "\tGET /"},
+		{"2024-01-02 03:04:05Z starting", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), " starting"},
+		{"20240102T030405Z,worker=3", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), ",worker=3"},
+		{"2024-01-02T03:04:05+09:00[Asia/Tokyo] boot", time.Date(2024, 1, 1, 18, 4, 5, 0, time.UTC), " boot"},
+		{"2024-01-02T03:04:05Z", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), ""},
+		// The longest valid prefix wins, even when the run of datetime characters goes on.
+		{"2024-01-02T03:04:05Z-", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), "-"},
+		{"2024-01-02 15 apples", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), " 15 apples"},
+	}
+	p := NewParser(WithLocation(time.UTC))
+	for _, c := range cases {
+		got, rest, err := p.ParsePrefix(c.s)
+		if err != nil || !got.Equal(c.want) || rest != c.rest {
+			t.Errorf(`ParsePrefix(%q) -> %v, %q, %v (should be %v, %q)`, c.s, got, rest, err, c.want, c.rest)
+		}
+	}
+
+	for _, s := range []string{"", "level=info", "202 items", "0000-01-01T00:00 x"} {
+		if got, rest, err := ParsePrefix(s); err == nil || rest != s {
+			t.Errorf(`ParsePrefix(%q) -> %v, %q, %v (should fail and return s)`, s, got, rest, err)
+		}
+	}
+}
+
+func TestSplitLeadingTimestamp(t *testing.T) {
+	want := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	p := NewParser(WithLocation(time.UTC))
+	for _, c := range []struct {
+		line, rest string
+	}{
+		{"2024-01-02T03:04:05Z INFO started", "INFO started"},
+		{"2024-01-02T03:04:05Z - INFO started", "INFO started"},
+		{"2024-01-02 03:04:05\tINFO\tstarted", "INFO\tstarted"},
+		{"2024-01-02T03:04:05+00:00 | INFO | started", "INFO | started"},
+		{"[2024-01-02T03:04:05Z] INFO started", "INFO started"},
+		{"[20240102T030405Z]: INFO started", "INFO started"},
+		{"2024-01-02T03:04:05Z -1 retries", "-1 retries"},
+		{"2024-01-02T03:04:05Z", ""},
+	} {
+		got, rest, ok := p.SplitLeadingTimestamp(c.line)
+		if !ok || !got.Equal(want) || rest != c.rest {
+			t.Errorf(`SplitLeadingTimestamp(%q) -> %v, %q, %v (should be %v, %q, true)`, c.line, got, rest, ok, want, c.rest)
+		}
+	}
+
+	for _, line := range []string{"", "INFO 2024-01-02T03:04:05Z started", "2024 was a year", "2024-01-02T03:04:05Zulu", "[2024-01-02T03:04:05Z started"} {
+		if got, rest, ok := SplitLeadingTimestamp(line); ok || rest != line {
+			t.Errorf(`SplitLeadingTimestamp(%q) -> %v, %q, %v (should fail and return the line)`, line, got, rest, ok)
+		}
+	}
+}
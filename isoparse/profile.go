@@ -0,0 +1,575 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Profiles
+//
+// Several standards define a subset of ISO-8601 for their own use, such as RFC 3339 for
+// Internet timestamps.  A Profile restricts a Parser to one of those subsets, so that data
+// can be checked against the standard that governs it before it is stored:
+//
+//	p := isoparse.NewParser(isoparse.WithProfile(isoparse.RFC3339))
+//	_, err := p.Parse("2018-09-27")  // RFC 3339 requires a full date and time
+//
+// A profile only narrows what a Parser accepts.  A string that passes its checks is parsed
+// as usual.
+//
+// Applications can bundle options of their own into a profile with NewProfile, and
+// register it with RegisterProfile so that other code can select it by name:
+//
+//	func init() {
+//		isoparse.RegisterProfile(isoparse.NewProfile("EventsAPI",
+//			isoparse.WithProfile(isoparse.RFC3339), isoparse.WithOffsetMinutes(0, 30)))
+//	}
+//
+//	profile, ok := isoparse.LookupProfile(cfg.TimestampProfile)
+
+// A Profile is a named set of restrictions on the strings that a Parser accepts.
+type Profile struct {
+	name string
+	opts []Option
+}
+
+// NewProfile returns a profile named name that configures a Parser with opts, which may
+// include WithProfile to build on another profile.
+func NewProfile(name string, opts ...Option) *Profile {
+	return &Profile{name, append([]Option(nil), opts...)}
+}
+
+// Name returns the profile's name, such as "RFC3339".
+func (pr *Profile) Name() string {
+	return pr.name
+}
+
+// WithProfile restricts a Parser to the strings allowed by profile, and applies any other
+// options that it bundles.  Only one set of syntax rules is in force at a time, so a later
+// WithProfile with a standard's profile replaces the rules of an earlier one.
+func WithProfile(profile *Profile) Option {
+	return func(p *Parser) {
+		for _, opt := range profile.opts {
+			opt(p)
+		}
+	}
+}
+
+// RFC3339 is the profile of RFC 3339 section 5.6, for Internet timestamps.  Parse accepts
+// only a full date and time, with 'T' or a space between them, seconds, an optional
+// fraction after a '.', and a mandatory offset of "Z" or ±hh:mm: in other words,
+// "2018-09-27T05:00:00Z" and "2018-09-27 05:00:00.5+01:00", but no reduced precision,
+// week or ordinal dates, or basic format.  ParseDate accepts only YYYY-MM-DD, ParseTime
+// only a time of that shape with its offset, and durations are rejected.
+var RFC3339 = &Profile{"RFC3339", []Option{withSyntax(&syntaxRules{
+	name:     "RFC 3339",
+	datetime: shapeRule("RFC 3339", rfc3339Datetime),
+	date:     shapeRule("RFC 3339", rfc3339Date),
+	time:     shapeRule("RFC 3339", rfc3339Time),
+})}}
+
+// WHATWG is the profile of the date and time microsyntaxes of the WHATWG HTML standard,
+// which are what <input type="date">, "datetime-local", "month", "week", and "time" and the
+// datetime attribute of <time> produce.  Parse accepts a month string (2018-09), a week
+// string (2018-W39), a date string (2018-09-27), and a local or global date and time
+// string: a date and a time of hh:mm, hh:mm:ss, or hh:mm:ss with a fraction of up to three
+// digits, separated by 'T' or a space, with an optional offset of Z, ±hh:mm, or ±hhmm.
+// ParseDate accepts the month, week, and date strings; ParseTime a time string, with no
+// offset; and ParseDuration a duration string in the ISO form PnDTnHnMnS, with only days,
+// hours, minutes, and seconds, and a fraction of up to three digits on the seconds.  (The
+// alternative duration form, "1w 2d 3h", isn't supported.)
+var WHATWG = &Profile{"WHATWG", []Option{withSyntax(&syntaxRules{
+	name:     "the WHATWG profile",
+	datetime: shapeRule("the WHATWG profile", whatwgDatetime),
+	date:     shapeRule("the WHATWG profile", whatwgDate),
+	time:     shapeRule("the WHATWG profile", whatwgTime),
+	duration: shapeRule("the WHATWG profile", whatwgDuration),
+})}}
+
+// W3CDTF is the profile of the W3C note "Date and Time Formats" (W3C-DTF), which RSS,
+// Atom, and Dublin Core metadata use.  Parse accepts its six granularities and nothing
+// else: YYYY, YYYY-MM, YYYY-MM-DD, and a date with a time of hh:mm, hh:mm:ss, or hh:mm:ss
+// and a fraction, after a 'T' and followed by a mandatory time zone designator of Z or
+// ±hh:mm.  ParseDate accepts the first three; times and durations are rejected.
+var W3CDTF = &Profile{"W3CDTF", []Option{withSyntax(&syntaxRules{
+	name:     "W3C-DTF",
+	datetime: shapeRule("W3C-DTF", w3cdtfDatetime),
+	date:     shapeRule("W3C-DTF", w3cdtfDate),
+})}}
+
+// XSD is the profile of the lexical forms of XML Schema's xsd:dateTime, xsd:date,
+// xsd:time, and xsd:duration, as read by the XSD types in this package.  Parse accepts an
+// xsd:dateTime or an xsd:date, with the XML Schema rules: the extended format only, with
+// every component present; a year of four or more digits, which may be negative but not
+// 0000; 24:00:00 with no nonzero fraction, as the first instant of the next day; and an
+// optional timezone of Z or ±hh:mm within ±14:00.  Negative and five-digit years are read
+// as XSDDate does, and a value without a timezone is in the Parser's location.
+//
+// ParseDateTime, ParseDate, ParseTime, and ParseDuration check their input against the
+// rules for xsd:dateTime, xsd:date, xsd:time, and xsd:duration, and then parse it as usual,
+// so there the ISO-8601 limits still apply: years outside 0001-9999 are rejected, and so
+// are timezones on dates.
+var XSD = &Profile{"XSD", []Option{withSyntax(&syntaxRules{
+	name:     "XML Schema",
+	datetime: xsdDatetimeRule,
+	date:     func(s string) error { return new(XSDDate).UnmarshalText([]byte(s)) },
+	time:     func(s string) error { return new(XSDTime).UnmarshalText([]byte(s)) },
+	duration: func(s string) error { return new(XSDDuration).UnmarshalText([]byte(s)) },
+	parse:    xsdParse,
+})}}
+
+// ICalendar is the profile of the DATE, DATE-TIME, TIME, and DURATION value types of
+// iCalendar (RFC 5545 section 3.3), for calendar import code.  Parse accepts a DATE of
+// YYYYMMDD and a DATE-TIME of YYYYMMDDThhmmss, in the basic format with no fraction, and
+// with the hour no more than 23.  A DATE-TIME ending in 'Z' is in UTC.  One without is a
+// floating time, the same wall-clock reading wherever it is observed, or a local time in
+// the zone named by its TZID parameter, and Parse reads it in the Parser's location; set
+// that with WithLocation, or use ParseDateTime to keep the reading with no location at
+// all.  ParseDate accepts a DATE, ParseTime a TIME of hhmmss with an optional 'Z', and
+// ParseDuration a DURATION of weeks alone (P2W) or of days, hours, minutes, and seconds
+// (P1DT2H30M), with an optional sign and no fractions.
+//
+// FormatISO writes these forms with the styles "icaldate", "icalutc", and "icalfloating".
+var ICalendar = &Profile{"ICalendar", []Option{withSyntax(&syntaxRules{
+	name:     "RFC 5545",
+	datetime: shapeRule("RFC 5545", icalDatetime),
+	date:     shapeRule("RFC 5545", icalDate),
+	time:     shapeRule("RFC 5545", icalTime),
+	duration: shapeRule("RFC 5545", icalDuration),
+})}}
+
+// scanXSDDatetime reads s as an xsd:dateTime if it has a 'T', and as an xsd:date if not.
+func scanXSDDatetime(s string) (date Date, tod TimeOfDay, hasTime, hasTZ bool, offset int, err error) {
+	typ := "xsd:date"
+	if hasTime = strings.IndexByte(s, 'T') >= 0; hasTime {
+		typ = "xsd:dateTime"
+	}
+	date, pos, err := scanXSDDate(s, typ)
+	if err != nil {
+		return
+	}
+	if hasTime {
+		if pos >= len(s) || s[pos] != 'T' {
+			err = xsdError(s, typ, "date and time must be separated by T", ErrSyntax)
+			return
+		}
+		if tod, pos, err = scanXSDTime(s, pos+1, typ); err != nil {
+			return
+		}
+	}
+	hasTZ, offset, err = scanXSDTimezone(s, pos, typ)
+	return
+}
+
+func xsdDatetimeRule(s string) error {
+	_, _, _, _, _, err := scanXSDDatetime(s)
+	return err
+}
+
+// xsdParse parses an xsd:dateTime or xsd:date for Parse with the XSD profile.
+func xsdParse(p *Parser, s string) (time.Time, error) {
+	date, tod, hasTime, hasTZ, offset, err := scanXSDDatetime(s)
+	switch {
+	case err != nil:
+		return time.Time{}, err
+	case hasTZ:
+		if !hasTime && p.dateAnchor != nil {
+			tod = *p.dateAnchor
+		}
+		loc := time.UTC
+		if offset != 0 {
+			loc = offsetZone(offset)
+		}
+		// time.Date rolls 24:00:00 over to the next day, as XML Schema specifies.
+		return time.Date(date.Year, date.Month, date.Day, tod.Hour, tod.Minute, tod.Second, tod.Nanosecond, loc), nil
+	case !hasTime:
+		return p.anchorDate(s, date, p.location())
+	}
+	wall := time.Date(date.Year, date.Month, date.Day, tod.Hour, tod.Minute, tod.Second, tod.Nanosecond, time.UTC)
+	return p.resolveWall(s, wall, p.location())
+}
+
+var (
+	profilesMu sync.RWMutex
+	profiles   = map[string]*Profile{
+		ICalendar.name: ICalendar,
+		RFC3339.name:   RFC3339,
+		W3CDTF.name:    W3CDTF,
+		WHATWG.name:    WHATWG,
+		XSD.name:       XSD,
+	}
+)
+
+// RegisterProfile makes profile available by its name to LookupProfile.  The profiles of
+// this package are registered already.  It is meant to be called from an init function,
+// and panics if profile's name is empty or already registered.
+func RegisterProfile(profile *Profile) {
+	profilesMu.Lock()
+	defer profilesMu.Unlock()
+	if profile.name == "" {
+		panic("isoparse: RegisterProfile called with an unnamed profile")
+	}
+	if _, dup := profiles[profile.name]; dup {
+		panic("isoparse: RegisterProfile called twice for profile " + profile.name)
+	}
+	profiles[profile.name] = profile
+}
+
+// LookupProfile returns the registered profile with the given name, which is matched
+// exactly, and whether there is one.
+func LookupProfile(name string) (*Profile, bool) {
+	profilesMu.RLock()
+	defer profilesMu.RUnlock()
+	profile, ok := profiles[name]
+	return profile, ok
+}
+
+// ProfileNames returns the names of the registered profiles, in sorted order.
+func ProfileNames() []string {
+	profilesMu.RLock()
+	defer profilesMu.RUnlock()
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// syntaxRules are the checks that a profile makes on the strings given to each of a
+// Parser's methods, before parsing.  Each returns an error if s isn't allowed.  A nil
+// check means that the profile has no strings of that kind at all.
+type syntaxRules struct {
+	name                           string // The standard's name, for messages
+	datetime, date, time, duration func(s string) error
+
+	// parse, if set, takes the place of both the datetime check and the usual parsing in
+	// Parse, for a profile that allows strings that the ISO parser doesn't.
+	parse func(p *Parser, s string) (time.Time, error)
+}
+
+// withSyntax sets the syntax rules of a Parser.
+func withSyntax(rules *syntaxRules) Option {
+	return func(p *Parser) {
+		p.syntax = rules
+	}
+}
+
+// check applies the check in r for a string of the given kind, "datetime", "date",
+// "time", or "duration", to s.  r may be nil, for a Parser without a profile.
+func (r *syntaxRules) check(s, kind string) error {
+	if r == nil {
+		return nil
+	}
+	var rule func(string) error
+	switch kind {
+	case "datetime":
+		rule = r.datetime
+	case "date":
+		rule = r.date
+	case "time":
+		rule = r.time
+	case "duration":
+		rule = r.duration
+	}
+	if rule == nil {
+		return &ParseError{s, r.name + " has no " + kind + " values", -1, "", ErrUnsupported}
+	}
+	return rule(s)
+}
+
+// shapeRule makes a check from a function that returns a description of what the
+// standard named name requires, and the position and element it is about, if s isn't
+// allowed, or "" if it is.
+func shapeRule(name string, shape func(s string) (msg string, pos int, element string)) func(string) error {
+	return func(s string) error {
+		if msg, pos, element := shape(s); msg != "" {
+			return &ParseError{s, name + " requires " + msg, pos, element, ErrSyntax}
+		}
+		return nil
+	}
+}
+
+// matchShape reports whether s has the given shape, in which 'd' stands for any ASCII
+// digit and every other byte for itself.
+func matchShape(s, shape string) bool {
+	if len(s) != len(shape) {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if shape[i] == 'd' && !isDigit(s[i]) || shape[i] != 'd' && s[i] != shape[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// hasShape reports whether s[pos:] starts with the given shape, as for matchShape.
+func hasShape(s string, pos int, shape string) bool {
+	return pos <= len(s) && len(s)-pos >= len(shape) && matchShape(s[pos:pos+len(shape)], shape)
+}
+
+func rfc3339Date(s string) (string, int, string) {
+	if !matchShape(s, "dddd-dd-dd") {
+		return "a date in YYYY-MM-DD format", 0, "date"
+	}
+	return "", 0, ""
+}
+
+func rfc3339Datetime(s string) (string, int, string) {
+	switch {
+	case !hasShape(s, 0, "dddd-dd-dd"):
+		return "a date in YYYY-MM-DD format", 0, "date"
+	case len(s) == len("YYYY-MM-DD"):
+		return "a full date and time", len(s), "time"
+	case s[10] != 'T' && s[10] != ' ':
+		return "'T' or a space between the date and time", 10, "time-separator"
+	}
+	msg, pos, element := rfc3339Time(s[11:])
+	return msg, pos + 11, element
+}
+
+// rfc3339Time checks hh:mm:ss[.fff](Z|±hh:mm).
+func rfc3339Time(s string) (string, int, string) {
+	if !hasShape(s, 0, "dd:dd:dd") {
+		return "a time in hh:mm:ss format", 0, "time"
+	}
+	pos := len("hh:mm:ss")
+	if pos < len(s) && s[pos] == '.' {
+		for pos++; pos < len(s) && isDigit(s[pos]); pos++ {
+		}
+		if !isDigit(s[pos-1]) {
+			return "digits after the decimal point", pos, "fraction"
+		}
+	}
+	offset := s[pos:]
+	if offset == "" {
+		return "a UTC offset", pos, "offset"
+	}
+	if offset != "Z" && !((offset[0] == '+' || offset[0] == '-') && matchShape(offset[1:], "dd:dd")) {
+		return "a UTC offset in Z or ±hh:mm format", pos, "offset"
+	}
+	return "", 0, ""
+}
+
+func whatwgDate(s string) (string, int, string) {
+	if !matchShape(s, "dddd-dd-dd") && !matchShape(s, "dddd-dd") && !matchShape(s, "dddd-Wdd") {
+		return "a date in YYYY-MM-DD, YYYY-MM, or YYYY-Www format", 0, "date"
+	}
+	return "", 0, ""
+}
+
+func whatwgDatetime(s string) (string, int, string) {
+	if len(s) <= len("YYYY-MM-DD") {
+		return whatwgDate(s)
+	}
+	switch {
+	case !hasShape(s, 0, "dddd-dd-dd"):
+		return "a date in YYYY-MM-DD format", 0, "date"
+	case s[10] != 'T' && s[10] != ' ':
+		return "'T' or a space between the date and time", 10, "time-separator"
+	}
+	end, msg := whatwgClock(s[11:])
+	if msg != "" {
+		return msg, 11 + end, "time"
+	}
+	pos := 11 + end
+	offset := s[pos:]
+	if offset != "" && offset != "Z" && !((offset[0] == '+' || offset[0] == '-') && (matchShape(offset[1:], "dd:dd") || matchShape(offset[1:], "dddd"))) {
+		return "a UTC offset in Z, ±hh:mm, or ±hhmm format", pos, "offset"
+	}
+	return "", 0, ""
+}
+
+func whatwgTime(s string) (string, int, string) {
+	end, msg := whatwgClock(s)
+	switch {
+	case msg != "":
+		return msg, end, "time"
+	case end < len(s):
+		return "a time with no UTC offset", end, "offset"
+	}
+	return "", 0, ""
+}
+
+// whatwgClock checks the time at the start of s, hh:mm[:ss[.s{1,3}]], and returns its end.
+func whatwgClock(s string) (end int, msg string) {
+	if !hasShape(s, 0, "dd:dd") {
+		return 0, "a time in hh:mm, hh:mm:ss, or hh:mm:ss.sss format"
+	}
+	end = len("hh:mm")
+	if !hasShape(s, end, ":dd") {
+		return end, ""
+	}
+	end += len(":ss")
+	if end < len(s) && s[end] == '.' {
+		start := end + 1
+		for end = start; end < len(s) && isDigit(s[end]); end++ {
+		}
+		if end == start || end-start > 3 {
+			return start, "a fraction of one to three digits"
+		}
+	}
+	return end, ""
+}
+
+func whatwgDuration(s string) (string, int, string) {
+	if len(s) < 2 || s[0] != 'P' {
+		return "a duration in PnDTnHnMnS format", 0, "duration"
+	}
+	pos, inTime := 1, false
+	for _, designator := range "DTHMS" {
+		switch {
+		case pos == len(s):
+			continue
+		case designator == 'T':
+			if inTime = s[pos] == 'T'; inTime {
+				pos++
+			}
+			continue
+		case designator != 'D' && !inTime:
+			continue
+		}
+		start := pos
+		for pos < len(s) && isDigit(s[pos]) {
+			pos++
+		}
+		if designator == 'S' && pos > start && pos < len(s) && s[pos] == '.' {
+			fraction := pos + 1
+			for pos = fraction; pos < len(s) && isDigit(s[pos]); pos++ {
+			}
+			if pos == fraction || pos-fraction > 3 {
+				return "a fraction of one to three digits", fraction, "duration"
+			}
+		}
+		if pos > start && pos < len(s) && s[pos] == byte(designator) {
+			pos++
+		} else {
+			pos = start
+		}
+	}
+	if pos < len(s) || s[len(s)-1] == 'T' {
+		return "a duration in PnDTnHnMnS format", pos, "duration"
+	}
+	return "", 0, ""
+}
+
+func w3cdtfDate(s string) (string, int, string) {
+	if !matchShape(s, "dddd") && !matchShape(s, "dddd-dd") && !matchShape(s, "dddd-dd-dd") {
+		return "a date in YYYY, YYYY-MM, or YYYY-MM-DD format", 0, "date"
+	}
+	return "", 0, ""
+}
+
+func w3cdtfDatetime(s string) (string, int, string) {
+	if len(s) <= len("YYYY-MM-DD") {
+		return w3cdtfDate(s)
+	}
+	switch {
+	case !hasShape(s, 0, "dddd-dd-dd"):
+		return "a date in YYYY-MM-DD format", 0, "date"
+	case s[10] != 'T':
+		return "'T' between the date and time", 10, "time-separator"
+	case !hasShape(s, 11, "dd:dd"):
+		return "a time in hh:mm, hh:mm:ss, or hh:mm:ss.s format", 11, "time"
+	}
+	pos := len("YYYY-MM-DDThh:mm")
+	if hasShape(s, pos, ":dd") {
+		pos += len(":ss")
+		if pos < len(s) && s[pos] == '.' {
+			fraction := pos + 1
+			for pos = fraction; pos < len(s) && isDigit(s[pos]); pos++ {
+			}
+			if pos == fraction {
+				return "digits after the decimal point", pos, "fraction"
+			}
+		}
+	}
+	offset := s[pos:]
+	if offset == "" {
+		return "a time zone designator", pos, "offset"
+	}
+	if offset != "Z" && !((offset[0] == '+' || offset[0] == '-') && matchShape(offset[1:], "dd:dd")) {
+		return "a time zone designator in Z or ±hh:mm format", pos, "offset"
+	}
+	return "", 0, ""
+}
+
+func icalDate(s string) (string, int, string) {
+	if !matchShape(s, "dddddddd") {
+		return "a date in YYYYMMDD format", 0, "date"
+	}
+	return "", 0, ""
+}
+
+func icalDatetime(s string) (string, int, string) {
+	if len(s) <= len("YYYYMMDD") {
+		return icalDate(s)
+	}
+	switch {
+	case !hasShape(s, 0, "dddddddd"):
+		return "a date in YYYYMMDD format", 0, "date"
+	case s[8] != 'T':
+		return "'T' between the date and time", 8, "time-separator"
+	}
+	msg, pos, element := icalTime(s[9:])
+	return msg, pos + 9, element
+}
+
+func icalTime(s string) (string, int, string) {
+	switch {
+	case !matchShape(s, "dddddd") && !matchShape(s, "ddddddZ"):
+		return "a time in hhmmss or hhmmssZ format", 0, "time"
+	case s[:2] == "24":
+		return "an hour from 00 to 23", 0, "hour"
+	}
+	return "", 0, ""
+}
+
+// icalDuration checks [+|-]P(nW | nD[T...] | T...), where the time part has one or more
+// of nH, nM, and nS, in that order, with none skipped between the first and the last.
+func icalDuration(s string) (string, int, string) {
+	const msg = "a duration in PnW or PnDTnHnMnS format"
+	pos := 0
+	if pos < len(s) && (s[pos] == '+' || s[pos] == '-') {
+		pos++
+	}
+	if pos == len(s) || s[pos] != 'P' {
+		return msg, pos, "duration"
+	}
+	start := pos + 1
+	for pos = start; pos < len(s) && isDigit(s[pos]); pos++ {
+	}
+	switch {
+	case pos > start && pos+1 == len(s) && (s[pos] == 'W' || s[pos] == 'D'):
+		return "", 0, ""
+	case pos > start && pos < len(s) && s[pos] == 'D':
+		pos++
+	default:
+		pos = start
+	}
+	if pos+1 >= len(s) || s[pos] != 'T' {
+		return msg, pos, "duration"
+	}
+	designators := "HMS"
+	for pos++; pos < len(s); pos++ {
+		start := pos
+		for pos < len(s) && isDigit(s[pos]) {
+			pos++
+		}
+		if pos == start || pos == len(s) {
+			return msg, pos, "duration"
+		}
+		i := strings.IndexByte(designators, s[pos])
+		if i < 0 || i > 0 && len(designators) < len("HMS") {
+			return msg, pos, "duration"
+		}
+		designators = designators[i+1:]
+	}
+	return "", 0, ""
+}
@@ -0,0 +1,348 @@
+package isoparse
+
+import (
+	"errors"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestRFC3339Profile(t *testing.T) {
+	p := NewParser(WithProfile(RFC3339))
+	for _, s := range []string{
+		"2018-09-27T05:00:00Z",
+		"2018-09-27 05:00:00+01:00",
+		"2018-09-27T05:00:00.123456789-08:00",
+	} {
+		if _, err := p.Parse(s); err != nil {
+			t.Errorf(`RFC3339 Parse(%q) -> %v (should be accepted)`, s, err)
+		}
+	}
+
+	for s, element := range map[string]string{
+		"2018-09-27":                 "time",
+		"2018-09-27T05:00:00":        "offset",
+		"2018-09-27T05:00Z":          "time",
+		"2018-09-27T05:00:00,5Z":     "offset",
+		"2018-09-27T05:00:00.Z":      "fraction",
+		"2018-09-27T05:00:00+0100":   "offset",
+		"2018-09-27T05:00:00+01":     "offset",
+		"2018-09-27t05:00:00Z":       "time-separator",
+		"20180927T050000Z":           "date",
+		"2018-W39-4T05:00:00Z":       "date",
+		"2018-270T05:00:00Z":         "date",
+		"2018-09-27T05:00:00Z[UTC]":  "offset",
+		"2018-09-27T05:00:00+01:00 ": "offset",
+	} {
+		_, err := p.Parse(s)
+		var e *ParseError
+		if !errors.As(err, &e) || e.Err != ErrSyntax || e.Element != element {
+			t.Errorf(`RFC3339 Parse(%q) -> %v (should be a syntax error in the %s)`, s, err, element)
+		}
+	}
+
+	// Strings of the right shape are still checked as usual.
+	if _, err := p.Parse("2018-02-30T05:00:00Z"); !errors.Is(err, ErrInvalidDay) {
+		t.Errorf(`RFC3339 Parse("2018-02-30T05:00:00Z") -> %v (should wrap %v)`, err, ErrInvalidDay)
+	}
+}
+
+func TestRFC3339ProfileOtherKinds(t *testing.T) {
+	p := NewParser(WithProfile(RFC3339), WithLocation(time.UTC))
+	if _, err := p.ParseDate("2018-09-27"); err != nil {
+		t.Errorf(`RFC3339 ParseDate("2018-09-27") -> %v`, err)
+	}
+	if _, err := p.ParseDate("2018-09"); !errors.Is(err, ErrSyntax) {
+		t.Errorf(`RFC3339 ParseDate("2018-09") -> %v (should wrap %v)`, err, ErrSyntax)
+	}
+	if _, err := p.ParseTime("05:00:00.5Z"); err != nil {
+		t.Errorf(`RFC3339 ParseTime("05:00:00.5Z") -> %v`, err)
+	}
+	if _, err := p.ParseTime("05:00:00"); !errors.Is(err, ErrSyntax) {
+		t.Errorf(`RFC3339 ParseTime("05:00:00") -> %v (should wrap %v)`, err, ErrSyntax)
+	}
+	if _, err := p.ParseDuration("P1D"); !errors.Is(err, ErrUnsupported) {
+		t.Errorf(`RFC3339 ParseDuration("P1D") -> %v (should wrap %v)`, err, ErrUnsupported)
+	}
+	if _, err := p.ParseInterval("2018-09-27T05:00:00Z/2018-09-28T05:00:00Z"); err != nil {
+		t.Errorf(`RFC3339 ParseInterval -> %v`, err)
+	}
+}
+
+func TestWHATWGProfile(t *testing.T) {
+	p := NewParser(WithProfile(WHATWG), WithLocation(time.UTC))
+	check := func(method, s string, accept bool, err error) {
+		t.Helper()
+		if accept && err != nil {
+			t.Errorf(`WHATWG %s(%q) -> %v (should be accepted)`, method, s, err)
+		} else if !accept && !errors.Is(err, ErrSyntax) {
+			t.Errorf(`WHATWG %s(%q) -> %v (should wrap %v)`, method, s, err, ErrSyntax)
+		}
+	}
+	for s, accept := range map[string]bool{
+		"2018-09":                  true,
+		"2018-W39":                 true,
+		"2018-09-27":               true,
+		"2018-09-27T05:00":         true,
+		"2018-09-27 05:00:30":      true,
+		"2018-09-27T05:00:30.123":  true,
+		"2018-09-27T05:00Z":        true,
+		"2018-09-27T05:00:30-0800": true,
+		"2018-09-27T05:00+05:30":   true,
+		"2018":                     false,
+		"2018-W39-4":               false,
+		"2018-270":                 false,
+		"20180927":                 false,
+		"2018-09-27T05":            false,
+		"2018-09-27T0500":          false,
+		"2018-09-27T05:00:30.1234": false,
+		"2018-09-27T05:00:30,5":    false,
+		"2018-09-27T05:00+05":      false,
+		"2018-09-27T05:00:30.5+0":  false,
+		"2018-09-27T05:00:30.5Zoo": false,
+	} {
+		_, err := p.Parse(s)
+		check("Parse", s, accept, err)
+	}
+	for s, accept := range map[string]bool{
+		"2018-09-27": true, "2018-09": true, "2018-W39": true, "2018": false, "2018-W39-4": false,
+	} {
+		_, err := p.ParseDate(s)
+		check("ParseDate", s, accept, err)
+	}
+	for s, accept := range map[string]bool{
+		"05:00": true, "05:00:30": true, "05:00:30.5": true, "05": false, "0500": false, "05:00Z": false,
+	} {
+		_, err := p.ParseTime(s)
+		check("ParseTime", s, accept, err)
+	}
+	for s, accept := range map[string]bool{
+		"P1D": true, "PT1H": true, "P1DT2H3M4.567S": true, "PT0.5S": true, "PT1M": true,
+		"P": false, "PT": false, "P1DT": false, "P1": false, "P1H": false, "P1Y": false,
+		"P1M": false, "P1W": false, "PT1.5H": false, "PT1.2345S": false, "PT1S2M": false,
+	} {
+		_, err := p.ParseDuration(s)
+		check("ParseDuration", s, accept, err)
+	}
+}
+
+func TestW3CDTFProfile(t *testing.T) {
+	p := NewParser(WithProfile(W3CDTF), WithLocation(time.UTC))
+	for s, accept := range map[string]bool{
+		"1997":                         true,
+		"1997-07":                      true,
+		"1997-07-16":                   true,
+		"1997-07-16T19:20+01:00":       true,
+		"1997-07-16T19:20:30+01:00":    true,
+		"1997-07-16T19:20:30.45+01:00": true,
+		"1997-07-16T19:20:30.45Z":      true,
+		"1997-07-16T19:20":             false,
+		"1997-07-16T19:20:30":          false,
+		"1997-07-16 19:20:30Z":         false,
+		"1997-07-16T19Z":               false,
+		"1997-07-16T19:20:30.Z":        false,
+		"1997-07-16T19:20:30+0100":     false,
+		"1997-W29":                     false,
+		"1997-197":                     false,
+		"19970716":                     false,
+	} {
+		_, err := p.Parse(s)
+		if accept && err != nil {
+			t.Errorf(`W3CDTF Parse(%q) -> %v (should be accepted)`, s, err)
+		} else if !accept && !errors.Is(err, ErrSyntax) {
+			t.Errorf(`W3CDTF Parse(%q) -> %v (should wrap %v)`, s, err, ErrSyntax)
+		}
+	}
+	if _, err := p.ParseDate("1997-07"); err != nil {
+		t.Errorf(`W3CDTF ParseDate("1997-07") -> %v`, err)
+	}
+	if _, err := p.ParseTime("19:20Z"); !errors.Is(err, ErrUnsupported) {
+		t.Errorf(`W3CDTF ParseTime("19:20Z") -> %v (should wrap %v)`, err, ErrUnsupported)
+	}
+}
+
+func TestXSDProfile(t *testing.T) {
+	p := NewParser(WithProfile(XSD), WithLocation(time.UTC))
+	for s, want := range map[string]time.Time{
+		"2018-09-27T05:00:00Z":      time.Date(2018, 9, 27, 5, 0, 0, 0, time.UTC),
+		"2018-09-27T05:00:00.5":     time.Date(2018, 9, 27, 5, 0, 0, 5e8, time.UTC),
+		"2018-09-27T24:00:00Z":      time.Date(2018, 9, 28, 0, 0, 0, 0, time.UTC),
+		"2018-09-27T24:00:00.000Z":  time.Date(2018, 9, 28, 0, 0, 0, 0, time.UTC),
+		"2018-09-27T05:00:00+14:00": time.Date(2018, 9, 26, 15, 0, 0, 0, time.UTC),
+		"2018-09-27T05:00:00-14:00": time.Date(2018, 9, 27, 19, 0, 0, 0, time.UTC),
+		"-0001-01-01T00:00:00Z":     time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC),
+		"12018-09-27T05:00:00Z":     time.Date(12018, 9, 27, 5, 0, 0, 0, time.UTC),
+		"2018-09-27":                time.Date(2018, 9, 27, 0, 0, 0, 0, time.UTC),
+		"2018-09-27-05:00":          time.Date(2018, 9, 27, 5, 0, 0, 0, time.UTC),
+	} {
+		if got, err := p.Parse(s); err != nil || !got.Equal(want) {
+			t.Errorf(`XSD Parse(%q) -> (%v, %v) (should be %v)`, s, got, err, want)
+		}
+	}
+
+	for s, kind := range map[string]error{
+		"0000-01-01T00:00:00Z":      ErrYearRange,
+		"02018-09-27T05:00:00Z":     ErrSyntax,
+		"2018-09-27T24:00:00.5Z":    ErrTimeRange,
+		"2018-09-27T24:00:01Z":      ErrTimeRange,
+		"2018-09-27T05:00:00+14:01": ErrInvalidOffset,
+		"2018-09-27T05:00:00+0100":  ErrInvalidOffset,
+		"2018-09-27T05:00Z":         ErrSyntax,
+		"20180927T050000Z":          ErrSyntax,
+		"2018-W39-4":                ErrSyntax,
+		"2018-270":                  ErrSyntax,
+		"2018-09":                   ErrSyntax,
+		"2018-02-30":                ErrInvalidDay,
+	} {
+		if _, err := p.Parse(s); !errors.Is(err, kind) {
+			t.Errorf(`XSD Parse(%q) -> %v (should wrap %v)`, s, err, kind)
+		}
+	}
+}
+
+func TestXSDProfileOtherKinds(t *testing.T) {
+	p := NewParser(WithProfile(XSD), WithLocation(time.UTC))
+	if _, err := p.ParseDate("2018-09-27"); err != nil {
+		t.Errorf(`XSD ParseDate("2018-09-27") -> %v`, err)
+	}
+	if _, err := p.ParseDate("2018-W39-4"); !errors.Is(err, ErrSyntax) {
+		t.Errorf(`XSD ParseDate("2018-W39-4") -> %v (should wrap %v)`, err, ErrSyntax)
+	}
+	if _, err := p.ParseTime("05:00:00-14:00"); err != nil {
+		t.Errorf(`XSD ParseTime("05:00:00-14:00") -> %v`, err)
+	}
+	if _, err := p.ParseTime("05:00"); !errors.Is(err, ErrSyntax) {
+		t.Errorf(`XSD ParseTime("05:00") -> %v (should wrap %v)`, err, ErrSyntax)
+	}
+	if _, err := p.ParseDuration("P1DT2H"); err != nil {
+		t.Errorf(`XSD ParseDuration("P1DT2H") -> %v`, err)
+	}
+	if _, err := p.ParseDuration("P1W"); !errors.Is(err, ErrSyntax) {
+		t.Errorf(`XSD ParseDuration("P1W") -> %v (should wrap %v)`, err, ErrSyntax)
+	}
+	if _, err := p.ParseDateTime("2018-09-27T05:00:00"); err != nil {
+		t.Errorf(`XSD ParseDateTime("2018-09-27T05:00:00") -> %v`, err)
+	}
+	if _, err := p.ParseDateTime("2018-09-27T05:00"); !errors.Is(err, ErrSyntax) {
+		t.Errorf(`XSD ParseDateTime("2018-09-27T05:00") -> %v (should wrap %v)`, err, ErrSyntax)
+	}
+}
+
+func TestICalendarProfile(t *testing.T) {
+	p := NewParser(WithProfile(ICalendar), WithLocation(time.UTC))
+	for s, want := range map[string]time.Time{
+		"19970714":         time.Date(1997, 7, 14, 0, 0, 0, 0, time.UTC),
+		"19970714T173000Z": time.Date(1997, 7, 14, 17, 30, 0, 0, time.UTC),
+		"19970714T173000":  time.Date(1997, 7, 14, 17, 30, 0, 0, time.UTC),
+	} {
+		if got, err := p.Parse(s); err != nil || !got.Equal(want) {
+			t.Errorf(`ICalendar Parse(%q) -> (%v, %v) (should be %v)`, s, got, err, want)
+		}
+	}
+	for _, s := range []string{
+		"1997-07-14",
+		"1997-07-14T17:30:00Z",
+		"19970714T1730Z",
+		"19970714T173000.5Z",
+		"19970714T173000+0100",
+		"19970714 173000",
+		"19970714T240000",
+		"1997W287",
+		"1997195",
+	} {
+		if _, err := p.Parse(s); !errors.Is(err, ErrSyntax) {
+			t.Errorf(`ICalendar Parse(%q) -> %v (should wrap %v)`, s, err, ErrSyntax)
+		}
+	}
+
+	// A floating time keeps its wall-clock reading.
+	if dt, err := p.ParseDateTime("19970714T173000"); err != nil || dt.String() != "1997-07-14T17:30:00" {
+		t.Errorf(`ICalendar ParseDateTime("19970714T173000") -> (%v, %v)`, dt, err)
+	}
+	if _, err := p.ParseTime("173000Z"); err != nil {
+		t.Errorf(`ICalendar ParseTime("173000Z") -> %v`, err)
+	}
+	if _, err := p.ParseDate("1997-07-14"); !errors.Is(err, ErrSyntax) {
+		t.Errorf(`ICalendar ParseDate("1997-07-14") -> %v (should wrap %v)`, err, ErrSyntax)
+	}
+}
+
+func TestICalendarProfileDurations(t *testing.T) {
+	p := NewParser(WithProfile(ICalendar))
+	for s, accept := range map[string]bool{
+		"P15DT5H0M20S": true,
+		"P7W":          true,
+		"-PT15M":       true,
+		"+P1D":         true,
+		"PT1H":         true,
+		"PT5M20S":      true,
+		"P1DT1S":       true,
+		"PT1H20S":      false,
+		"P1Y":          false,
+		"P1M":          false,
+		"P1W2D":        false,
+		"PT0.5S":       false,
+		"P1DT":         false,
+		"P":            false,
+		"PT":           false,
+		"1D":           false,
+	} {
+		_, err := p.ParseDuration(s)
+		if accept && err != nil {
+			t.Errorf(`ICalendar ParseDuration(%q) -> %v (should be accepted)`, s, err)
+		} else if !accept && !errors.Is(err, ErrSyntax) {
+			t.Errorf(`ICalendar ParseDuration(%q) -> %v (should wrap %v)`, s, err, ErrSyntax)
+		}
+	}
+}
+
+func TestRegisterProfile(t *testing.T) {
+	events := NewProfile("TestEventsAPI", WithProfile(RFC3339), WithOffsetMinutes(0))
+	RegisterProfile(events)
+	defer func() {
+		profilesMu.Lock()
+		delete(profiles, events.Name())
+		profilesMu.Unlock()
+	}()
+
+	profile, ok := LookupProfile("TestEventsAPI")
+	if !ok || profile != events {
+		t.Fatalf(`LookupProfile("TestEventsAPI") -> (%v, %v) (should be the registered profile)`, profile, ok)
+	}
+	p := NewParser(WithProfile(profile))
+	if _, err := p.Parse("2018-09-27T05:00:00Z"); err != nil {
+		t.Errorf(`TestEventsAPI Parse("2018-09-27T05:00:00Z") -> %v`, err)
+	}
+	if _, err := p.Parse("2018-09-27"); !errors.Is(err, ErrSyntax) {
+		t.Errorf(`TestEventsAPI Parse("2018-09-27") -> %v (should wrap %v)`, err, ErrSyntax)
+	}
+	if _, err := p.Parse("2018-09-27T05:00:00+05:30"); !errors.Is(err, ErrInvalidOffset) {
+		t.Errorf(`TestEventsAPI Parse("2018-09-27T05:00:00+05:30") -> %v (should wrap %v)`, err, ErrInvalidOffset)
+	}
+
+	for _, name := range []string{"ICalendar", "RFC3339", "W3CDTF", "WHATWG", "XSD"} {
+		if profile, ok := LookupProfile(name); !ok || profile.Name() != name {
+			t.Errorf(`LookupProfile(%q) -> (%v, %v) (should be registered)`, name, profile, ok)
+		}
+	}
+	if _, ok := LookupProfile("rfc3339"); ok {
+		t.Errorf(`LookupProfile("rfc3339") -> true (names should match exactly)`)
+	}
+	names := ProfileNames()
+	if !sort.StringsAreSorted(names) || len(names) != 6 {
+		t.Errorf(`ProfileNames() -> %q (should be the six registered names, sorted)`, names)
+	}
+}
+
+func TestRegisterProfilePanics(t *testing.T) {
+	for _, profile := range []*Profile{NewProfile(""), NewProfile("RFC3339")} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf(`RegisterProfile(%q) did not panic`, profile.Name())
+				}
+			}()
+			RegisterProfile(profile)
+		}()
+	}
+}
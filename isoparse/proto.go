@@ -0,0 +1,98 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"fmt"
+	"time"
+)
+
+// Protocol Buffers well-known types
+//
+// These helpers convert to and from the two fields (Seconds and Nanos) that make up both
+// google.protobuf.Timestamp and google.protobuf.Duration, so that this package doesn't
+// have to depend on the protobuf runtime.  Building the messages is one line:
+//
+//	seconds, nanos, err := isoparse.ProtoTimestamp(t)
+//	ts := &timestamppb.Timestamp{Seconds: seconds, Nanos: nanos}
+//
+// Unlike timestamppb.New and durationpb.New, out-of-range values are reported as errors
+// rather than producing messages that fail validation later on.
+
+// The valid ranges, as documented in google/protobuf/timestamp.proto and duration.proto.
+const (
+	minProtoTimestampSeconds = -62135596800 // 0001-01-01T00:00:00Z
+	maxProtoTimestampSeconds = 253402300799 // 9999-12-31T23:59:59Z
+	maxProtoDurationSeconds  = 315576000000 // About 10,000 years
+)
+
+// ProtoTimestamp returns the Seconds and Nanos fields of the google.protobuf.Timestamp
+// representing t.  It is an error for t to be outside the range of years 1 to 9999.
+func ProtoTimestamp(t time.Time) (seconds int64, nanos int32, err error) {
+	seconds = t.Unix()
+	if seconds < minProtoTimestampSeconds || seconds > maxProtoTimestampSeconds {
+		return 0, 0, fmt.Errorf("isoparse: %v out of range for google.protobuf.Timestamp", t)
+	}
+	return seconds, int32(t.Nanosecond()), nil
+}
+
+// FromProtoTimestamp returns the instant represented by the Seconds and Nanos fields of a
+// google.protobuf.Timestamp, in UTC.  It is an error for either field to be out of range.
+func FromProtoTimestamp(seconds int64, nanos int32) (time.Time, error) {
+	if seconds < minProtoTimestampSeconds || seconds > maxProtoTimestampSeconds {
+		return time.Time{}, fmt.Errorf("isoparse: google.protobuf.Timestamp seconds %d out of range", seconds)
+	}
+	if nanos < 0 || int(nanos) > maxNsec {
+		return time.Time{}, fmt.Errorf("isoparse: google.protobuf.Timestamp nanos %d out of range", nanos)
+	}
+	return time.Unix(seconds, int64(nanos)).UTC(), nil
+}
+
+// ProtoDuration returns the Seconds and Nanos fields of the google.protobuf.Duration
+// representing p.
+//
+// A google.protobuf.Duration is an exact span of time, so a Period with Years or Months
+// is an error: there is no single number of seconds in a month.  Weeks and days are taken
+// to be exactly 7*24 and 24 hours long, which is the usual convention for exact durations
+// (though see the Period documentation regarding DST).  It is also an error for the result
+// to be outside the range of about ±10,000 years that google.protobuf.Duration allows.
+func ProtoDuration(p Period) (seconds int64, nanos int32, err error) {
+	if p.Years != 0 || p.Months != 0 {
+		return 0, 0, fmt.Errorf("isoparse: %v has calendar years or months and cannot be a google.protobuf.Duration", p)
+	}
+	seconds = int64(p.Weeks)*7*24*60*60 + int64(p.Days)*24*60*60 + int64(p.Hours)*60*60 +
+		int64(p.Minutes)*60 + int64(p.Seconds) + int64(p.Nanoseconds/1e9)
+	nanos = int32(p.Nanoseconds % 1e9)
+	if seconds > maxProtoDurationSeconds {
+		return 0, 0, fmt.Errorf("isoparse: %v out of range for google.protobuf.Duration", p)
+	}
+	if p.Negative {
+		// Both fields must have the same sign.
+		seconds, nanos = -seconds, -nanos
+	}
+	return seconds, nanos, nil
+}
+
+// FromProtoDuration returns the Period represented by the Seconds and Nanos fields of a
+// google.protobuf.Duration.  The result uses only the clock components, normalized into
+// hours, minutes, and seconds, so 5400 seconds becomes PT1H30M.
+// It is an error for the fields to be out of range or to have differing signs.
+func FromProtoDuration(seconds int64, nanos int32) (Period, error) {
+	if seconds < -maxProtoDurationSeconds || seconds > maxProtoDurationSeconds {
+		return Period{}, fmt.Errorf("isoparse: google.protobuf.Duration seconds %d out of range", seconds)
+	}
+	if nanos <= -1e9 || nanos >= 1e9 || (seconds < 0 && nanos > 0) || (seconds > 0 && nanos < 0) {
+		return Period{}, fmt.Errorf("isoparse: google.protobuf.Duration nanos %d invalid for seconds %d", nanos, seconds)
+	}
+	var p Period
+	if seconds < 0 || nanos < 0 {
+		p.Negative = true
+		seconds, nanos = -seconds, -nanos
+	}
+	p.Hours = int(seconds / (60 * 60))
+	p.Minutes = int(seconds % (60 * 60) / 60)
+	p.Seconds = int(seconds % 60)
+	p.Nanoseconds = int(nanos)
+	return p, nil
+}
@@ -0,0 +1,89 @@
+package isoparse
+
+import (
+	"testing"
+	"time"
+)
+
+type protoFields struct {
+	seconds int64
+	nanos   int32
+}
+
+var protoTimestamps = map[time.Time]protoFields{
+	time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC):                 {0, 0},
+	time.Date(2018, 9, 27, 11, 52, 59, 5e8, time.UTC):           {1538049179, 5e8},
+	time.Date(2018, 9, 27, 6, 52, 59, 5e8, offsetZone(-5*3600)): {1538049179, 5e8},
+	time.Date(1969, 12, 31, 23, 59, 59, 1, time.UTC):            {-1, 1},
+	time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC):                    {minProtoTimestampSeconds, 0},
+	time.Date(9999, 12, 31, 23, 59, 59, 999999999, time.UTC):    {maxProtoTimestampSeconds, 999999999},
+}
+
+var outOfRangeTimestamps = []time.Time{
+	time.Date(0, 12, 31, 23, 59, 59, 0, time.UTC),
+	time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC),
+	// In range in its own zone, but not in UTC.
+	time.Date(9999, 12, 31, 23, 0, 0, 0, offsetZone(-5*3600)),
+}
+
+var protoDurations = map[protoFields]Period{
+	{0, 0}:         {},
+	{5400, 0}:      {Hours: 1, Minutes: 30},
+	{90061, 5e8}:   {Hours: 25, Minutes: 1, Seconds: 1, Nanoseconds: 5e8},
+	{-1, -5e8}:     {Negative: true, Seconds: 1, Nanoseconds: 5e8},
+	{0, -1}:        {Negative: true, Nanoseconds: 1},
+	{86400 * 8, 0}: {Hours: 192},
+}
+
+func TestProtoTimestamp(t *testing.T) {
+	for tm, want := range protoTimestamps {
+		seconds, nanos, err := ProtoTimestamp(tm)
+		if err != nil {
+			t.Errorf(`ProtoTimestamp(%v) -> non-nil error (%v) for in-range time`, tm, err)
+			continue
+		}
+		if seconds != want.seconds || nanos != want.nanos {
+			t.Errorf(`ProtoTimestamp(%v) -> (%d, %d) (should be (%d, %d))`, tm, seconds, nanos, want.seconds, want.nanos)
+		}
+		if back, err := FromProtoTimestamp(seconds, nanos); err != nil || !back.Equal(tm) || back.Location() != time.UTC {
+			t.Errorf(`FromProtoTimestamp(%d, %d) -> (%v, %v) (should be %v in UTC)`, seconds, nanos, back, err, tm)
+		}
+	}
+	for _, tm := range outOfRangeTimestamps {
+		if seconds, nanos, err := ProtoTimestamp(tm); err == nil {
+			t.Errorf(`ProtoTimestamp(%v) -> (%d, %d) returned nil error (out-of-range time should error)`, tm, seconds, nanos)
+		}
+	}
+	for _, f := range []protoFields{{maxProtoTimestampSeconds + 1, 0}, {0, -1}, {0, 1e9}} {
+		if tm, err := FromProtoTimestamp(f.seconds, f.nanos); err == nil {
+			t.Errorf(`FromProtoTimestamp(%d, %d) -> %v returned nil error (invalid fields should error)`, f.seconds, f.nanos, tm)
+		}
+	}
+}
+
+func TestProtoDuration(t *testing.T) {
+	for f, p := range protoDurations {
+		if got, err := FromProtoDuration(f.seconds, f.nanos); err != nil {
+			t.Errorf(`FromProtoDuration(%d, %d) -> non-nil error (%v) for valid fields`, f.seconds, f.nanos, err)
+		} else if got != p {
+			t.Errorf(`FromProtoDuration(%d, %d) -> %v (should be %v)`, f.seconds, f.nanos, got, p)
+		}
+		if seconds, nanos, err := ProtoDuration(p); err != nil || seconds != f.seconds || nanos != f.nanos {
+			t.Errorf(`ProtoDuration(%v) -> (%d, %d, %v) (should be (%d, %d))`, p, seconds, nanos, err, f.seconds, f.nanos)
+		}
+	}
+	// Weeks and days are exact multiples of 24 hours.
+	if seconds, _, err := ProtoDuration(Period{Weeks: 1, Days: 1}); err != nil || seconds != 86400*8 {
+		t.Errorf(`ProtoDuration(P1W1D) -> (%d, %v) (should be %d)`, seconds, err, 86400*8)
+	}
+	for _, p := range []Period{{Months: 1}, {Years: 1}, {Days: 999999999}} {
+		if seconds, nanos, err := ProtoDuration(p); err == nil {
+			t.Errorf(`ProtoDuration(%v) -> (%d, %d) returned nil error (unrepresentable period should error)`, p, seconds, nanos)
+		}
+	}
+	for _, f := range []protoFields{{1, -1}, {-1, 1}, {0, 1e9}, {maxProtoDurationSeconds + 1, 0}} {
+		if p, err := FromProtoDuration(f.seconds, f.nanos); err == nil {
+			t.Errorf(`FromProtoDuration(%d, %d) -> %v returned nil error (invalid fields should error)`, f.seconds, f.nanos, p)
+		}
+	}
+}
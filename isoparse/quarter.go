@@ -0,0 +1,102 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"fmt"
+	"time"
+)
+
+// Quarters
+//
+// Quarter notation, "2024-Q3", isn't part of ISO-8601, but financial data feeds use it
+// everywhere.  ParseYearQuarter parses it on its own, and a Parser created with
+// WithQuarters also accepts it wherever it accepts a reduced-precision date such as
+// "2024-07", as the quarter's first day.  (EDTF writes quarters as seasons instead:
+// "2024-35" is the same quarter as "2024-Q3".)
+
+// YearQuarter is a quarter of a given year, such as "2024-Q3", July through September.
+type YearQuarter struct {
+	Year    int
+	Quarter int // 1 to 4
+}
+
+// ParseYearQuarter parses a quarter in YYYY-Qq format, or YYYYQq.
+func ParseYearQuarter(s string) (YearQuarter, error) {
+	year, ok1 := scanXSDDigits(s, 0, 4)
+	pos := 4 + btoi(len(s) > 4 && s[4] == dateSep)
+	quarter, ok2 := scanXSDDigits(s, pos+1, 1)
+	if !ok1 || !ok2 || s[pos] != 'Q' || len(s) != pos+2 {
+		return YearQuarter{}, &ParseError{s, "quarter must be in YYYY-Qq format", 0, "quarter", ErrSyntax}
+	}
+	yq := YearQuarter{year, quarter}
+	if !yq.IsValid() {
+		return YearQuarter{}, &ParseError{s, "quarter out of valid range", pos + 1, "quarter", ErrInvalidMonth}
+	}
+	return yq, nil
+}
+
+// IsValid reports whether the quarter is from 1 to 4 and the year is in the range that
+// this package parses.
+func (yq YearQuarter) IsValid() bool {
+	return yq.Year >= minYear && yq.Year <= maxYear && yq.Quarter >= 1 && yq.Quarter <= 4
+}
+
+// String returns the quarter in YYYY-Qq format.
+func (yq YearQuarter) String() string {
+	return fmt.Sprintf("%04d-Q%d", yq.Year, yq.Quarter)
+}
+
+// FirstDay returns the first day of the quarter.  The quarter must be valid.
+func (yq YearQuarter) FirstDay() Date {
+	return Date{yq.Year, time.Month(3*yq.Quarter - 2), 1}
+}
+
+// LastDay returns the last day of the quarter.  The quarter must be valid.
+func (yq YearQuarter) LastDay() Date {
+	return YearMonth{yq.Year, time.Month(3 * yq.Quarter)}.LastDay()
+}
+
+// Interval returns the quarter as an Interval, from midnight at the start of its first
+// day to midnight at the end of its last, in loc.  The quarter must be valid.
+func (yq YearQuarter) Interval(loc *time.Location) Interval {
+	return Interval{yq.FirstDay().In(loc), yq.LastDay().AddDays(1).In(loc)}
+}
+
+// Season returns the quarter as an EDTF season, with one of the codes Quarter1 to
+// Quarter4.
+func (yq YearQuarter) Season() YearSeason {
+	return YearSeason{yq.Year, Quarter1 + Season(yq.Quarter-1)}
+}
+
+// QuarterOf returns the quarter that t falls in, in t's location.
+func QuarterOf(t time.Time) YearQuarter {
+	return YearQuarter{t.Year(), (int(t.Month()) + 2) / 3}
+}
+
+// WithQuarters makes Parse and ParseDate accept quarters, in the forms that
+// ParseYearQuarter does, as the first day of the quarter, like a reduced-precision date.
+func WithQuarters() Option {
+	return func(p *Parser) {
+		p.quarters = true
+	}
+}
+
+// isQuarter reports whether p accepts quarters and s looks like one: nothing else that
+// this package parses has a 'Q' after the year.
+func (p *Parser) isQuarter(s string) bool {
+	return p.quarters && len(s) > 5 && (s[4] == 'Q' || s[4] == dateSep && s[5] == 'Q')
+}
+
+// quarterDate returns the first day of the quarter s, for Parse and ParseDate.
+func (p *Parser) quarterDate(s string) (Date, error) {
+	yq, err := ParseYearQuarter(s)
+	if err != nil {
+		return Date{}, err
+	}
+	if p.completeDates {
+		return Date{}, &ParseError{s, "date must have a month and a day", len(s), "month", ErrSyntax}
+	}
+	return yq.FirstDay(), nil
+}
@@ -0,0 +1,93 @@
+package isoparse
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseYearQuarter(t *testing.T) {
+	for s, want := range map[string]YearQuarter{
+		"2024-Q3": {2024, 3},
+		"2024Q1":  {2024, 1},
+		"0001-Q4": {1, 4},
+	} {
+		if got, err := ParseYearQuarter(s); err != nil || got != want {
+			t.Errorf(`ParseYearQuarter(%q) -> (%v, %v) (should be %v)`, s, got, err, want)
+		}
+	}
+	for s, kind := range map[string]error{
+		"2024-Q5":  ErrInvalidMonth,
+		"2024-Q0":  ErrInvalidMonth,
+		"2024-Q":   ErrSyntax,
+		"2024-Q12": ErrSyntax,
+		"2024-q3":  ErrSyntax,
+		"2024-3":   ErrSyntax,
+		"24-Q3":    ErrSyntax,
+		"":         ErrSyntax,
+	} {
+		if _, err := ParseYearQuarter(s); !errors.Is(err, kind) {
+			t.Errorf(`ParseYearQuarter(%q) -> %v (should wrap %v)`, s, err, kind)
+		}
+	}
+}
+
+func TestYearQuarter(t *testing.T) {
+	for _, c := range []struct {
+		yq          YearQuarter
+		first, last Date
+	}{
+		{YearQuarter{2024, 1}, Date{2024, time.January, 1}, Date{2024, time.March, 31}},
+		{YearQuarter{2024, 2}, Date{2024, time.April, 1}, Date{2024, time.June, 30}},
+		{YearQuarter{2024, 3}, Date{2024, time.July, 1}, Date{2024, time.September, 30}},
+		{YearQuarter{2024, 4}, Date{2024, time.October, 1}, Date{2024, time.December, 31}},
+	} {
+		if got := c.yq.FirstDay(); got != c.first {
+			t.Errorf(`%v.FirstDay() -> %v (should be %v)`, c.yq, got, c.first)
+		}
+		if got := c.yq.LastDay(); got != c.last {
+			t.Errorf(`%v.LastDay() -> %v (should be %v)`, c.yq, got, c.last)
+		}
+		if got := c.yq.Season(); got.FirstDay() != c.first || got.LastDay() != c.last {
+			t.Errorf(`%v.Season() -> %v (should cover the same days)`, c.yq, got)
+		}
+		for d := c.first; !d.After(c.last); d = d.AddDays(45) {
+			if got := QuarterOf(d.In(time.UTC)); got != c.yq {
+				t.Errorf(`QuarterOf(%v) -> %v (should be %v)`, d, got, c.yq)
+			}
+		}
+	}
+	yq := YearQuarter{2024, 3}
+	if s := yq.String(); s != "2024-Q3" {
+		t.Errorf(`%v.String() -> %q (should be "2024-Q3")`, yq, s)
+	}
+	iv := yq.Interval(time.UTC)
+	if want := (Interval{time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)}); !iv.Equal(want) {
+		t.Errorf(`%v.Interval(time.UTC) -> %v (should be %v)`, yq, iv, want)
+	}
+}
+
+func TestWithQuarters(t *testing.T) {
+	p := NewParser(WithLocation(time.UTC), WithQuarters())
+	if got, err := p.Parse("2024-Q3"); err != nil || !got.Equal(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf(`Parse("2024-Q3") with quarters -> (%v, %v)`, got, err)
+	}
+	if got, err := p.ParseDate("2024Q4"); err != nil || got != (Date{2024, time.October, 1}) {
+		t.Errorf(`ParseDate("2024Q4") with quarters -> (%v, %v)`, got, err)
+	}
+	if got, err := p.ParseInterval("2024-Q1/2024-Q3"); err != nil || !got.End.Equal(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf(`ParseInterval("2024-Q1/2024-Q3") with quarters -> (%v, %v)`, got, err)
+	}
+	if _, err := p.Parse("2024-09-27T05:00:00Z"); err != nil {
+		t.Errorf(`Parse("2024-09-27T05:00:00Z") with quarters -> %v`, err)
+	}
+	if _, err := p.Parse("2024-Q5"); !errors.Is(err, ErrInvalidMonth) {
+		t.Errorf(`Parse("2024-Q5") with quarters -> %v (should wrap %v)`, err, ErrInvalidMonth)
+	}
+	if _, err := ParseISODatetime("2024-Q3"); err == nil {
+		t.Errorf(`ParseISODatetime("2024-Q3") returned nil error (quarters should be opt-in)`)
+	}
+	if _, err := NewParser(WithQuarters(), WithCompleteDates()).Parse("2024-Q3"); !errors.Is(err, ErrSyntax) {
+		t.Errorf(`Parse("2024-Q3") with quarters and complete dates -> %v (should wrap %v)`, err, ErrSyntax)
+	}
+}
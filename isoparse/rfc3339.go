@@ -0,0 +1,67 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import "time"
+
+// scanRFC3339 is a fast path for Parse.  It recognizes only the exact shape
+// YYYY-MM-DDThh:mm:ss[.fff]Z|±hh:mm, which is what nearly every JSON payload carries, and
+// fills in parts just as parseISODatetime would.  For anything else, including strings of
+// that shape with an out-of-range offset, it returns false, and the general parser takes
+// over (and produces the error, if there is one).
+//
+// Range checks on the date and time are left to the caller, as with parseISODatetime.
+func scanRFC3339(s string) (parts datetimeParts, ok bool) {
+	const minLen = len("YYYY-MM-DDThh:mm:ssZ")
+	if len(s) < minLen || s[4] != dateSep || s[7] != dateSep || s[10] != 'T' || s[13] != timeSep || s[16] != timeSep {
+		return parts, false
+	}
+	var ok1, ok2, ok3, ok4, ok5, ok6 bool
+	parts.date[0], ok1 = parseDigits(s[0:4])
+	parts.date[1], ok2 = parseDigits(s[5:7])
+	parts.date[2], ok3 = parseDigits(s[8:10])
+	parts.time[0], ok4 = parseDigits(s[11:13])
+	parts.time[1], ok5 = parseDigits(s[14:16])
+	parts.time[2], ok6 = parseDigits(s[17:19])
+	if !(ok1 && ok2 && ok3 && ok4 && ok5 && ok6) || parts.time[0] == 24 {
+		// The general parser checks that 24 is used only for midnight.
+		return parts, false
+	}
+
+	pos := 19
+	if s[pos] == '.' || s[pos] == ',' {
+		end := pos + 1
+		for end < len(s) && isDigit(s[end]) {
+			end++
+		}
+		if end == pos+1 {
+			return parts, false
+		}
+		parts.time[3] = fractionNanos(s[pos+1 : end])
+		pos = end
+	}
+
+	switch offset := s[pos:]; {
+	case offset == "Z":
+		parts.tz = time.UTC
+	case len(offset) == len("+hh:mm") && (offset[0] == '+' || offset[0] == '-') && offset[3] == timeSep:
+		hours, ok1 := parseDigits(offset[1:3])
+		minutes, ok2 := parseDigits(offset[4:6])
+		if !ok1 || !ok2 || hours > maxHour || minutes > maxMin {
+			return parts, false
+		}
+		secondsEast := hours*60*60 + minutes*60
+		if offset[0] == '-' {
+			secondsEast = -secondsEast
+		}
+		parts.tz = time.UTC
+		if secondsEast != 0 {
+			parts.tz = offsetZone(secondsEast)
+		}
+	default:
+		return parts, false
+	}
+	parts.hasOffset, parts.hasTime, parts.timePos = true, true, 11
+	return parts, true
+}
@@ -0,0 +1,51 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScanRFC3339(t *testing.T) {
+	// Each of these must take the fast path, and give the same parts as the general parser.
+	for _, datetime := range []string{
+		"2018-09-27T05:00:00Z",
+		"2018-09-27T05:00:00.512419Z",
+		"2018-09-27T05:00:00,5+05:30",
+		"2018-09-27T05:00:00.1234567891-08:00",
+		"2018-09-27T05:00:00-00:00",
+		"2018-02-30T05:00:00Z", // Range errors are left to the caller.
+	} {
+		got, ok := scanRFC3339(datetime)
+		if !ok {
+			t.Errorf(`scanRFC3339(%q) -> not ok`, datetime)
+			continue
+		}
+		want, err := parseISODatetime(datetime)
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf(`scanRFC3339(%q) -> %+v (should be %+v)`, datetime, got, want)
+		}
+	}
+
+	// These are left to the general parser.
+	for _, datetime := range []string{
+		"2018-09-27T05:00:00",
+		"2018-09-27 05:00:00Z",
+		"20180927T050000Z",
+		"2018-09-27T05:00Z",
+		"2018-09-27T05:00:00.Z",
+		"2018-09-27T05:00:00+0530",
+		"2018-09-27T05:00:00+05:60",
+		"2018-09-27T05:00:00z",
+		"2018-09-27T05:00:00Zjunk",
+		"2018-+9-27T05:00:00Z",
+		"2018-09-27T24:00:00+00:00",
+		"2018-09-27T24:00:01Z",
+	} {
+		if parts, ok := scanRFC3339(datetime); ok {
+			t.Errorf(`scanRFC3339(%q) -> %+v (should not be ok)`, datetime, parts)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"sort"
+	"time"
+)
+
+// SortISOStrings sorts datetimes, as accepted by ParseISODatetime, in chronological order.
+// Unlike a lexicographic sort, it gets mixed offsets and mixed basic and extended forms
+// right: "2018-09-27T12:00:00+02:00" sorts before "20180927T110000Z".  Strings with no
+// offset are read in time.Local.
+//
+// Each string is parsed once, as with Compare.  If any of them is invalid, datetimes is
+// left as it is, and the error is a *BatchError recording each failure, as with ParseAll.
+//
+// The sort is not guaranteed to be stable: strings that name the same instant may be
+// reordered.  Use SortStableISOStrings to keep them in their original order.
+func SortISOStrings(datetimes []string) error {
+	return sortISOStrings(datetimes, sort.Sort)
+}
+
+// SortStableISOStrings is like SortISOStrings, but keeps strings that name the same
+// instant in their original order.
+func SortStableISOStrings(datetimes []string) error {
+	return sortISOStrings(datetimes, sort.Stable)
+}
+
+func sortISOStrings(datetimes []string, sortFunc func(sort.Interface)) error {
+	keys := make([]instant, len(datetimes))
+	var batchErr BatchError
+	for i, s := range datetimes {
+		key, err := parseInstant(s)
+		if err != nil {
+			batchErr.Errors = append(batchErr.Errors, &ItemError{i, err})
+			continue
+		}
+		keys[i] = key
+	}
+	if batchErr.Errors != nil {
+		return &batchErr
+	}
+	sortFunc(byInstant{datetimes, keys})
+	return nil
+}
+
+// byInstant sorts strings by their parsed instants, which are kept alongside.
+type byInstant struct {
+	datetimes []string
+	keys      []instant
+}
+
+func (b byInstant) Len() int           { return len(b.datetimes) }
+func (b byInstant) Less(i, j int) bool { return b.keys[i].compare(b.keys[j]) < 0 }
+func (b byInstant) Swap(i, j int) {
+	b.datetimes[i], b.datetimes[j] = b.datetimes[j], b.datetimes[i]
+	b.keys[i], b.keys[j] = b.keys[j], b.keys[i]
+}
+
+// MinISO returns the earliest of datetimes, as accepted by ParseISODatetime, and its index.
+// Only that one is parsed into a time.Time; the rest are compared as with Compare.  Of
+// strings that name the same instant, the first wins.
+//
+// Invalid strings are skipped, and reported in a *BatchError as with ParseAll; the result
+// is still the earliest of the rest.  If there is none, the index is -1.
+func MinISO(datetimes []string) (t time.Time, index int, err error) {
+	return extremeISO(datetimes, -1)
+}
+
+// MaxISO is like MinISO, but returns the latest of datetimes.
+func MaxISO(datetimes []string) (t time.Time, index int, err error) {
+	return extremeISO(datetimes, +1)
+}
+
+// extremeISO does the work for MinISO (with sign -1) and MaxISO (with sign +1).
+func extremeISO(datetimes []string, sign int) (time.Time, int, error) {
+	index := -1
+	var best instant
+	var batchErr BatchError
+	for i, s := range datetimes {
+		key, err := parseInstant(s)
+		if err != nil {
+			batchErr.Errors = append(batchErr.Errors, &ItemError{i, err})
+			continue
+		}
+		if index < 0 || key.compare(best) == sign {
+			index, best = i, key
+		}
+	}
+	var t time.Time
+	if index >= 0 {
+		t, _ = ParseISODatetime(datetimes[index])
+	}
+	if batchErr.Errors != nil {
+		return t, index, &batchErr
+	}
+	return t, index, nil
+}
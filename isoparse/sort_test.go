@@ -0,0 +1,91 @@
+package isoparse
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSortISOStrings(t *testing.T) {
+	got := []string{
+		"20180927T110000Z",
+		"2018-09-27T12:00:00+02:00",
+		"2018-09-27T05:00:00-08:00",
+		"2018-W39-4T10:30Z",
+		"2018-270T09:00:00.5-01:00",
+		"2017-12-31T23:59:59.999999999Z",
+	}
+	want := []string{
+		"2017-12-31T23:59:59.999999999Z",
+		"2018-09-27T12:00:00+02:00",
+		"2018-270T09:00:00.5-01:00",
+		"2018-W39-4T10:30Z",
+		"20180927T110000Z",
+		"2018-09-27T05:00:00-08:00",
+	}
+	if err := SortISOStrings(got); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf(`SortISOStrings -> (%q, %v) (should be %q)`, got, err, want)
+	}
+}
+
+func TestSortStableISOStrings(t *testing.T) {
+	// The first three are the same instant.
+	got := []string{
+		"2018-09-27T12:00:00+02:00",
+		"20180927T100000Z",
+		"2018-09-27T05:00:00-05:00",
+		"2018-09-27T09:00:00Z",
+	}
+	want := []string{
+		"2018-09-27T09:00:00Z",
+		"2018-09-27T12:00:00+02:00",
+		"20180927T100000Z",
+		"2018-09-27T05:00:00-05:00",
+	}
+	if err := SortStableISOStrings(got); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf(`SortStableISOStrings -> (%q, %v) (should be %q)`, got, err, want)
+	}
+}
+
+func TestSortISOStringsInvalid(t *testing.T) {
+	datetimes := []string{"2018-09-27T12:00:00Z", "2018-02-30", "2017-01-01T00:00Z", "nope"}
+	orig := append([]string(nil), datetimes...)
+	err := SortISOStrings(datetimes)
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 2 || batchErr.Errors[0].Index != 1 || batchErr.Errors[1].Index != 3 {
+		t.Errorf(`SortISOStrings(%q) -> %v (should fail for items 1 and 3)`, orig, err)
+	}
+	if !reflect.DeepEqual(datetimes, orig) {
+		t.Errorf(`SortISOStrings(%q) reordered the input to %q despite the error`, orig, datetimes)
+	}
+}
+
+func TestMinMaxISO(t *testing.T) {
+	datetimes := []string{
+		"2018-09-27T11:00:00+02:00",
+		"20180927T090000Z",
+		"2018-09-27T05:00:00-05:00",
+		"2018-09-27T02:00:00-07:00",
+		"2018-09-27T15:00:00+05:00",
+	}
+	// Items 0, 1 and 3 are all earliest, and 2 and 4 latest; the first wins.
+	if got, i, err := MinISO(datetimes); err != nil || i != 0 || !got.Equal(time.Date(2018, 9, 27, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf(`MinISO(%q) -> (%v, %d, %v) (should be index 0)`, datetimes, got, i, err)
+	}
+	if got, i, err := MaxISO(datetimes); err != nil || i != 2 || got.Location().String() != "UTC-05:00" {
+		t.Errorf(`MaxISO(%q) -> (%v, %d, %v) (should be index 2)`, datetimes, got, i, err)
+	}
+}
+
+func TestMinMaxISOInvalid(t *testing.T) {
+	if got, i, err := MinISO(nil); err != nil || i != -1 || !got.IsZero() {
+		t.Errorf(`MinISO(nil) -> (%v, %d, %v) (should be index -1)`, got, i, err)
+	}
+	datetimes := []string{"nope", "2018-09-27T12:00:00Z", "2018-02-30T00:00Z", "2018-09-27T11:00:00Z"}
+	got, i, err := MinISO(datetimes)
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 2 || i != 3 || got.Hour() != 11 {
+		t.Errorf(`MinISO(%q) -> (%v, %d, %v) (should be index 3, with errors for items 0 and 2)`, datetimes, got, i, err)
+	}
+}
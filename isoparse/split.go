@@ -0,0 +1,128 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"bufio"
+	"io"
+	"time"
+)
+
+// SplitDatetimes is a bufio.SplitFunc that splits its input into the datetimes that
+// FindAll would find and the text between them, so that joining the tokens gives back the
+// input.  A token is either a whole datetime or text; a DatetimeScanner tells them apart.
+//
+// Text is returned in pieces as it is read, rather than as a single token, so a stream with
+// no datetimes in it needs only a small buffer.
+func SplitDatetimes(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	return defaultParser.SplitDatetimes(data, atEOF)
+}
+
+// SplitDatetimes is like the package-level SplitDatetimes, but parses with p.
+func (p *Parser) SplitDatetimes(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	advance, token, _ = p.splitDatetimes(data, atEOF)
+	return advance, token, nil
+}
+
+// splitDatetimes does the work for SplitDatetimes.  If the token is a datetime, it also
+// returns the match.
+func (p *Parser) splitDatetimes(data []byte, atEOF bool) (advance int, token []byte, m *Match) {
+	// Until EOF, only look for datetimes that start far enough back that the longest
+	// candidate is wholly in data, so that more input can't change the answer.
+	limit := len(data)
+	if limit == 0 {
+		return 0, nil, nil
+	}
+	if !atEOF {
+		limit -= maxCandidateLen + 1
+		if limit <= 0 {
+			return 0, nil, nil
+		}
+	}
+	text := string(data)
+	if match, ok := p.find(text, 0, limit); ok {
+		if match.Start == 0 {
+			p.observe(text[:match.End], nil)
+			return match.End, data[:match.End], &match
+		}
+		return match.Start, data[:match.Start], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	// End the text where the next call, which won't see the byte before, can't mistake the
+	// middle of a word for the start of a datetime.
+	for k := limit; k < len(data); k++ {
+		if !isWordByte(data[k-1]) || !isDigit(data[k]) {
+			return k, data[:k], nil
+		}
+	}
+	return 0, nil, nil
+}
+
+// A DatetimeScanner reads a stream as a series of tokens, each either a datetime or the
+// text between datetimes, as split by SplitDatetimes.  Use it as a bufio.Scanner:
+//
+//	scanner := isoparse.NewDatetimeScanner(r)
+//	for scanner.Scan() {
+//		if t, ok := scanner.Datetime(); ok {
+//			fmt.Println(scanner.Text(), "is", t.UTC())
+//		}
+//	}
+//	if err := scanner.Err(); err != nil {
+//		...
+//	}
+type DatetimeScanner struct {
+	scanner *bufio.Scanner
+	match   *Match
+}
+
+// NewDatetimeScanner returns a DatetimeScanner that reads from r.
+func NewDatetimeScanner(r io.Reader) *DatetimeScanner {
+	return defaultParser.NewDatetimeScanner(r)
+}
+
+// NewDatetimeScanner is like the package-level NewDatetimeScanner, but parses with p.
+func (p *Parser) NewDatetimeScanner(r io.Reader) *DatetimeScanner {
+	s := &DatetimeScanner{scanner: bufio.NewScanner(r)}
+	s.scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, m := p.splitDatetimes(data, atEOF)
+		if token != nil {
+			s.match = m
+		}
+		return advance, token, nil
+	})
+	return s
+}
+
+// Scan advances to the next token, which is then available through Bytes, Text, and
+// Datetime.  It returns false at the end of the input or on an error.
+func (s *DatetimeScanner) Scan() bool {
+	return s.scanner.Scan()
+}
+
+// Bytes returns the current token.  The underlying array may be overwritten by the next
+// call to Scan.
+func (s *DatetimeScanner) Bytes() []byte {
+	return s.scanner.Bytes()
+}
+
+// Text returns the current token as a string.
+func (s *DatetimeScanner) Text() string {
+	return s.scanner.Text()
+}
+
+// Datetime returns the parsed time and true if the current token is a datetime, or the
+// zero time and false if it is text.
+func (s *DatetimeScanner) Datetime() (time.Time, bool) {
+	if s.match == nil {
+		return time.Time{}, false
+	}
+	return s.match.Time, true
+}
+
+// Err returns the first error from reading the input, other than io.EOF.
+func (s *DatetimeScanner) Err() error {
+	return s.scanner.Err()
+}
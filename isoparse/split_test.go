@@ -0,0 +1,95 @@
+package isoparse
+
+import (
+	"bufio"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+const splitInput = "2018-09-27T05:00Z start; v2018-09-27 ignored\n" +
+	"retry at 2018-09-27 06:00:00.5+01:00, then 2018-W39 (20180927 is ignored)" +
+	"2018-09-27T07:00:00Z"
+
+var splitWant = []struct {
+	token      string
+	isDatetime bool
+}{
+	{"2018-09-27T05:00Z", true},
+	{" start; v2018-09-27 ignored\nretry at ", false},
+	{"2018-09-27 06:00:00.5+01:00", true},
+	{", then ", false},
+	{"2018-W39", true},
+	{" (20180927 is ignored)", false},
+	{"2018-09-27T07:00:00Z", true},
+}
+
+func TestDatetimeScanner(t *testing.T) {
+	var want []string
+	for _, w := range splitWant {
+		if w.isDatetime {
+			want = append(want, w.token)
+		}
+	}
+	// Reading a byte at a time checks that datetimes split across reads are put together.
+	for name, r := range map[string]io.Reader{
+		"all at once":      strings.NewReader(splitInput),
+		"a byte at a time": iotest.OneByteReader(strings.NewReader(splitInput)),
+	} {
+		p := NewParser(WithLocation(time.UTC))
+		scanner := p.NewDatetimeScanner(r)
+		var text strings.Builder
+		var datetimes []string
+		for scanner.Scan() {
+			text.WriteString(scanner.Text())
+			if got, ok := scanner.Datetime(); ok {
+				datetimes = append(datetimes, scanner.Text())
+				if want, _ := p.Parse(scanner.Text()); !got.Equal(want) {
+					t.Errorf(`DatetimeScanner (%s) %q -> %v (should be %v)`, name, scanner.Text(), got, want)
+				}
+			}
+		}
+		if err := scanner.Err(); err != nil || text.String() != splitInput {
+			t.Errorf(`DatetimeScanner (%s) -> (%q, %v) (should be %q)`, name, text.String(), err, splitInput)
+		}
+		if !reflect.DeepEqual(datetimes, want) {
+			t.Errorf(`DatetimeScanner (%s) datetimes -> %q (should be %q)`, name, datetimes, want)
+		}
+	}
+}
+
+func TestSplitDatetimes(t *testing.T) {
+	scanner := bufio.NewScanner(strings.NewReader(splitInput))
+	scanner.Split(SplitDatetimes)
+	var got []string
+	for scanner.Scan() {
+		got = append(got, scanner.Text())
+	}
+	var want []string
+	for _, w := range splitWant {
+		want = append(want, w.token)
+	}
+	if err := scanner.Err(); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf(`SplitDatetimes -> (%q, %v) (should be %q)`, got, err, want)
+	}
+}
+
+// A stream with no datetimes must not need a buffer as large as the stream.
+func TestSplitDatetimesLongText(t *testing.T) {
+	input := strings.Repeat("some text 1234 ", 1000) + "2018-09-27T05:00Z"
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	scanner.Buffer(make([]byte, 256), 256)
+	scanner.Split(SplitDatetimes)
+	var last string
+	var text strings.Builder
+	for scanner.Scan() {
+		last = scanner.Text()
+		text.WriteString(last)
+	}
+	if err := scanner.Err(); err != nil || text.String() != input || last != "2018-09-27T05:00Z" {
+		t.Errorf(`SplitDatetimes on long text -> (last token %q, %v)`, last, err)
+	}
+}
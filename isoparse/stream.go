@@ -0,0 +1,96 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// A LineError records a line of a stream that could not be parsed.
+type LineError struct {
+	Line int // 1-based line number
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("isoparse: line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error { return e.Err }
+
+// ScanDatetimes reads newline-delimited datetime strings from r, parses each as with
+// ParseISODatetime, and calls fn with the 1-based line number and the result.
+// Surrounding whitespace (including a "\r" before the newline) is ignored, and blank
+// lines are skipped without a call.
+//
+// A line that fails to parse is passed to fn with a zero time and a *LineError, so that fn
+// can decide whether to log and carry on (return nil) or stop (return the error).
+// ScanDatetimes returns the first non-nil error from fn, or else any error from reading r.
+// Lines longer than bufio.MaxScanTokenSize are a read error.
+func ScanDatetimes(r io.Reader, fn func(line int, t time.Time, err error) error) error {
+	return defaultParser.ScanDatetimes(r, fn)
+}
+
+// ScanDatetimes is like the package-level ScanDatetimes, but parses with p.
+func (p *Parser) ScanDatetimes(r io.Reader, fn func(line int, t time.Time, err error) error) error {
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		s := strings.TrimSpace(scanner.Text())
+		if s == "" {
+			continue
+		}
+		t, err := p.Parse(s)
+		if err != nil {
+			err = &LineError{line, err}
+		}
+		if err := fn(line, t, err); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// RewriteDatetimes copies src to dst, replacing each datetime that FindAll finds with the
+// result of rewrite, which is given the datetime as written and as parsed.  It is meant for
+// log normalization, where timestamps in assorted forms and offsets are brought to one:
+//
+//	err := isoparse.RewriteDatetimes(os.Stdout, os.Stdin, func(s string, t time.Time) string {
+//		return t.UTC().Format(time.RFC3339Nano)
+//	})
+//
+// rewrite can return s to leave a datetime as it is.  Datetimes are looked for a line at a
+// time, so one that spans a line break is left alone, but lines may be of any length.
+// RewriteDatetimes returns the first error from reading src or writing dst.
+func RewriteDatetimes(dst io.Writer, src io.Reader, rewrite func(s string, t time.Time) string) error {
+	return defaultParser.RewriteDatetimes(dst, src, rewrite)
+}
+
+// RewriteDatetimes is like the package-level RewriteDatetimes, but parses with p.
+func (p *Parser) RewriteDatetimes(dst io.Writer, src io.Reader, rewrite func(s string, t time.Time) string) error {
+	r := bufio.NewReader(src)
+	w := bufio.NewWriter(dst)
+	for {
+		line, readErr := r.ReadString('\n')
+		last := 0
+		for _, m := range p.FindAll(line) {
+			w.WriteString(line[last:m.Start])
+			w.WriteString(rewrite(line[m.Start:m.End], m.Time))
+			last = m.End
+		}
+		if _, err := w.WriteString(line[last:]); err != nil {
+			return err
+		}
+		if readErr == io.EOF {
+			return w.Flush()
+		}
+		if readErr != nil {
+			w.Flush()
+			return readErr
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package isoparse
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const timestampStream = "2018-09-27T05:00Z\r\n\n  20180927T0600Z  \nnot a timestamp\n2018-09-27T07:00Z"
+
+func TestScanDatetimes(t *testing.T) {
+	var lines []int
+	var times []time.Time
+	var errs []error
+	err := ScanDatetimes(strings.NewReader(timestampStream), func(line int, t time.Time, err error) error {
+		lines = append(lines, line)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			times = append(times, t)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf(`ScanDatetimes -> non-nil error (%v)`, err)
+	}
+	if want := []int{1, 3, 4, 5}; len(lines) != len(want) || lines[0] != 1 || lines[1] != 3 || lines[2] != 4 || lines[3] != 5 {
+		t.Errorf(`ScanDatetimes visited lines %v (should be %v)`, lines, want)
+	}
+	for i, got := range times {
+		if want := time.Date(2018, 9, 27, 5+i, 0, 0, 0, time.UTC); !got.Equal(want) {
+			t.Errorf(`ScanDatetimes time %d -> %v (should be %v)`, i, got, want)
+		}
+	}
+	var lineErr *LineError
+	var parseErr *ParseError
+	if len(errs) != 1 || !errors.As(errs[0], &lineErr) || lineErr.Line != 4 || !errors.As(errs[0], &parseErr) {
+		t.Errorf(`ScanDatetimes errors -> %v (should be one *LineError for line 4 wrapping a *ParseError)`, errs)
+	}
+}
+
+func TestScanDatetimesStop(t *testing.T) {
+	calls := 0
+	err := ScanDatetimes(strings.NewReader(timestampStream), func(line int, t time.Time, err error) error {
+		calls++
+		return err
+	})
+	var lineErr *LineError
+	if calls != 3 || !errors.As(err, &lineErr) || lineErr.Line != 4 {
+		t.Errorf(`ScanDatetimes -> %v after %d calls (should stop at line 4 after 3 calls)`, err, calls)
+	}
+}
+
+func TestRewriteDatetimes(t *testing.T) {
+	src := "I 2018-09-27T05:00:00+02:00 started\nW 20180927T0600Z, retrying\n" +
+		"E 2018-09-27 07:00:00.5-05:00: failed (code 2018)\nno timestamp"
+	want := "I 2018-09-27T03:00:00Z started\nW 2018-09-27T06:00:00Z, retrying\n" +
+		"E 2018-09-27T12:00:00.5Z: failed (code 2018)\nno timestamp"
+	var dst strings.Builder
+	err := RewriteDatetimes(&dst, strings.NewReader(src), func(s string, t time.Time) string {
+		return t.UTC().Format(time.RFC3339Nano)
+	})
+	if err != nil || dst.String() != want {
+		t.Errorf(`RewriteDatetimes -> (%q, %v) (should be %q)`, dst.String(), err, want)
+	}
+}
+
+type failingReader struct{ err error }
+
+func (r failingReader) Read([]byte) (int, error) { return 0, r.err }
+
+func TestRewriteDatetimesError(t *testing.T) {
+	readErr := errors.New("read failed")
+	var dst strings.Builder
+	err := RewriteDatetimes(&dst, failingReader{readErr}, func(s string, t time.Time) string { return s })
+	if err != readErr {
+		t.Errorf(`RewriteDatetimes -> %v (should be %v)`, err, readErr)
+	}
+}
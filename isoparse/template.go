@@ -0,0 +1,58 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"fmt"
+	"text/template"
+	"time"
+)
+
+// FuncMap returns template functions for parsing and formatting ISO-8601 strings.
+// It can be passed to the Funcs method of both text/template and html/template templates:
+//
+//	tmpl := template.New("report").Funcs(isoparse.FuncMap())
+//
+// The functions are:
+//
+//	isoparse STRING                 ParseISODatetime
+//	isodate STRING                  Parser.ParseDate, giving a Date
+//	isoduration STRING              ParseISODuration, giving a Period
+//	isoformat VALUE STYLE           FormatISO; VALUE may be a time.Time or a string to parse first
+//	isocanon STRING                 Canonicalize
+//
+// So {{ isoformat .CreatedAt "date" }} writes just the date of a time.Time field, and
+// {{ isoparse .Raw }} turns a string field into a time.Time.  Parse failures are returned as
+// errors, which stop template execution.
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"isoparse": ParseISODatetime,
+		"isodate": func(s string) (Date, error) {
+			return defaultParser.ParseDate(s)
+		},
+		"isoduration": ParseISODuration,
+		"isoformat":   templateFormat,
+		"isocanon":    Canonicalize,
+	}
+}
+
+// templateFormat is the isoformat template function.
+func templateFormat(v interface{}, style string) (string, error) {
+	switch v := v.(type) {
+	case time.Time:
+		return FormatISO(v, style)
+	case *time.Time:
+		if v == nil {
+			return "", fmt.Errorf("isoparse: isoformat of nil *time.Time")
+		}
+		return FormatISO(*v, style)
+	case string:
+		t, err := ParseISODatetime(v)
+		if err != nil {
+			return "", err
+		}
+		return FormatISO(t, style)
+	}
+	return "", fmt.Errorf("isoparse: isoformat of unsupported type %T", v)
+}
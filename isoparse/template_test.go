@@ -0,0 +1,57 @@
+package isoparse
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+)
+
+type templateReport struct {
+	CreatedAt time.Time
+	Raw       string
+}
+
+var templateCases = map[string]string{
+	`{{ isoformat .CreatedAt "date" }}`:                      "2018-09-27",
+	`{{ isoformat .CreatedAt "week" }}`:                      "2018-W39-4",
+	`{{ (isoparse .Raw).Year }}`:                             "1985",
+	`{{ isoformat .Raw "ordinal" }}`:                         "1985-102",
+	`{{ isocanon .Raw }}`:                                    "1985-04-12T10:15:00+04:00",
+	`{{ (isodate "2018-W39-4").Day }}`:                       "27",
+	`{{ (isoduration "PT1H30M").Minutes }}`:                  "30",
+	`{{ isoformat (isoparse "2018-09-27T05:00+05") "utc" }}`: "2018-09-27T00:00:00Z",
+}
+
+var templateFailures = []string{
+	`{{ isoparse "not a date" }}`,
+	`{{ isoformat .CreatedAt "bogus" }}`,
+	`{{ isoformat 42 "date" }}`,
+}
+
+func TestFuncMap(t *testing.T) {
+	data := templateReport{formatTime, "1985W155T1015+0400"}
+	for text, want := range templateCases {
+		var b strings.Builder
+		tmpl := template.Must(template.New("").Funcs(FuncMap()).Parse(text))
+		if err := tmpl.Execute(&b, data); err != nil {
+			t.Errorf(`template %s -> non-nil error (%v)`, text, err)
+		} else if b.String() != want {
+			t.Errorf(`template %s -> %q (should be %q)`, text, b.String(), want)
+		}
+	}
+	for _, text := range templateFailures {
+		var b strings.Builder
+		tmpl := template.Must(template.New("").Funcs(FuncMap()).Parse(text))
+		if err := tmpl.Execute(&b, data); err == nil {
+			t.Errorf(`template %s -> %q returned nil error`, text, b.String())
+		}
+	}
+	// The same map works with html/template.
+	var b strings.Builder
+	tmpl := htmltemplate.Must(htmltemplate.New("").Funcs(FuncMap()).Parse(`<time>{{ isoformat .CreatedAt "date" }}</time>`))
+	if err := tmpl.Execute(&b, data); err != nil || b.String() != "<time>2018-09-27</time>" {
+		t.Errorf(`html/template -> (%q, %v) (should be "<time>2018-09-27</time>")`, b.String(), err)
+	}
+}
@@ -0,0 +1,176 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"strconv"
+	"strings"
+)
+
+// TokenKind identifies what a Token is: a component of a date or time, or the
+// punctuation between them.
+type TokenKind int
+
+const (
+	YearToken              TokenKind = iota // 2018
+	MonthToken                              // 09
+	WeekToken                               // 39, after the 'W'
+	WeekdayToken                            // 4, in 2018-W39-4
+	DayToken                                // 27, or 270 in an ordinal date
+	HourToken                               // 05
+	MinuteToken                             // 30
+	SecondToken                             // 15
+	FractionToken                           // 25, the digits after the decimal sign
+	DateSeparatorToken                      // -
+	WeekDesignatorToken                     // W
+	DateTimeSeparatorToken                  // T
+	TimeSeparatorToken                      // :
+	DecimalSignToken                        // . or ,
+	OffsetToken                             // Z, +05:30, -0800, and so on
+	AnnotationToken                         // [Europe/Paris], [u-ca=iso8601], and so on
+)
+
+var tokenKindNames = [...]string{
+	YearToken:              "year",
+	MonthToken:             "month",
+	WeekToken:              "week",
+	WeekdayToken:           "weekday",
+	DayToken:               "day",
+	HourToken:              "hour",
+	MinuteToken:            "minute",
+	SecondToken:            "second",
+	FractionToken:          "fraction",
+	DateSeparatorToken:     "date separator",
+	WeekDesignatorToken:    "week designator",
+	DateTimeSeparatorToken: "date/time separator",
+	TimeSeparatorToken:     "time separator",
+	DecimalSignToken:       "decimal sign",
+	OffsetToken:            "offset",
+	AnnotationToken:        "annotation",
+}
+
+func (k TokenKind) String() string {
+	if k >= 0 && int(k) < len(tokenKindNames) {
+		return tokenKindNames[k]
+	}
+	return "TokenKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Token is one lexical element of an ISO-8601 string: Text is the element as written, and
+// Pos its index in the string.
+type Token struct {
+	Kind TokenKind
+	Text string
+	Pos  int
+}
+
+// Tokenize splits s, a date, a datetime, or a time, into its tokens, in order, so that
+// validators, linters, and rewriters can work on the grammar that this package parses
+// without re-deriving it.  The tokens cover s exactly: concatenating their Text gives s.
+//
+// s is checked against the grammar that ParseISODatetime and ParseISOTimeParts accept,
+// with RFC 9557 annotations, and if it doesn't match, Tokenize returns the error that
+// parsing would.  Apart from week numbers, which are checked as week dates are read, the
+// components aren't checked against the calendar, so a validator can see that
+// "2018-13-01" has a MonthToken of "13" and report it in its own terms.  Where a string
+// could be read either way, it is a date, as with ParseISODatetime: "2018" is a YearToken.
+func Tokenize(s string) ([]Token, error) {
+	rest, _, err := splitIXDTF(s)
+	if err != nil {
+		return nil, err
+	}
+	parts, err := parseISODatetime(rest)
+	if err != nil {
+		if _, _, _, terr := parseISOTime(rest); terr != nil {
+			return nil, err
+		}
+		parts.hasTime, parts.timePos = true, 0
+	}
+	tokens := tokenize(rest, parts)
+	for pos := len(rest); pos < len(s); {
+		end := pos + strings.IndexByte(s[pos:], ']') + 1
+		tokens = append(tokens, Token{AnnotationToken, s[pos:end], pos})
+		pos = end
+	}
+	return tokens, nil
+}
+
+// tokenize returns the tokens of s, which parts was parsed from and which has no
+// annotations.  parts.timePos is 0 for a time with no date.
+func tokenize(s string, parts datetimeParts) []Token {
+	var tokens []Token
+	if !parts.hasTime || parts.timePos > 0 {
+		date := s
+		if parts.hasTime {
+			date = s[:parts.timePos-1]
+		}
+		tokens = tokenizeDate(date)
+		if !parts.hasTime {
+			return tokens
+		}
+		tokens = append(tokens, Token{DateTimeSeparatorToken, s[parts.timePos-1 : parts.timePos], parts.timePos - 1})
+	}
+	return tokenizeTime(tokens, s, parts.timePos)
+}
+
+// tokenizeDate returns the tokens of date, a date that parseISODate accepts.
+func tokenizeDate(date string) []Token {
+	tokens := []Token{{YearToken, date[:4], 0}}
+	pos := 4
+	// add appends the token of kind that is the next n characters of date.
+	add := func(kind TokenKind, n int) {
+		tokens = append(tokens, Token{kind, date[pos : pos+n], pos})
+		pos += n
+	}
+	separator := func() {
+		if pos < len(date) && date[pos] == dateSep {
+			add(DateSeparatorToken, 1)
+		}
+	}
+	separator()
+	switch format := dateFormat(date); format {
+	case YearMonthDate:
+		add(MonthToken, 2)
+	case CalendarDateExtended, CalendarDateBasic:
+		add(MonthToken, 2)
+		separator()
+		add(DayToken, 2)
+	case OrdinalDateExtended, OrdinalDateBasic:
+		add(DayToken, 3)
+	case WeekDateExtended, WeekDateBasic:
+		add(WeekDesignatorToken, 1)
+		add(WeekToken, 2)
+		separator()
+		if pos < len(date) {
+			add(WeekdayToken, 1)
+		}
+	}
+	return tokens
+}
+
+// tokenizeTime appends to tokens the tokens of the time portion of s, a time that
+// parseISOTime accepts, which starts at timeStart.
+func tokenizeTime(tokens []Token, s string, timeStart int) []Token {
+	pos := timeStart
+	add := func(kind TokenKind, n int) {
+		tokens = append(tokens, Token{kind, s[pos : pos+n], pos})
+		pos += n
+	}
+	// A time may be no more than an offset, as in "-0530", in which case this adds nothing.
+	precision := timePrecision(s[timeStart:])
+	for kind := HourToken; kind <= SecondToken && HourPrecision+Precision(kind-HourToken) <= precision; kind++ {
+		if kind > HourToken && s[pos] == timeSep {
+			add(TimeSeparatorToken, 1)
+		}
+		add(kind, 2)
+	}
+	if pos < len(s) && (s[pos] == '.' || s[pos] == ',') {
+		add(DecimalSignToken, 1)
+		add(FractionToken, len(fractionDigits(s[pos-1:])))
+	}
+	if pos < len(s) {
+		add(OffsetToken, len(s)-pos)
+	}
+	return tokens
+}
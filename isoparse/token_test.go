@@ -0,0 +1,107 @@
+package isoparse
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var tokenizations = map[string][]Token{
+	"2018-09-27T05:30:15.25+05:30": {
+		{YearToken, "2018", 0}, {DateSeparatorToken, "-", 4}, {MonthToken, "09", 5},
+		{DateSeparatorToken, "-", 7}, {DayToken, "27", 8}, {DateTimeSeparatorToken, "T", 10},
+		{HourToken, "05", 11}, {TimeSeparatorToken, ":", 13}, {MinuteToken, "30", 14},
+		{TimeSeparatorToken, ":", 16}, {SecondToken, "15", 17}, {DecimalSignToken, ".", 19},
+		{FractionToken, "25", 20}, {OffsetToken, "+05:30", 22},
+	},
+	"2018W394T0500Z": {
+		{YearToken, "2018", 0}, {WeekDesignatorToken, "W", 4}, {WeekToken, "39", 5},
+		{WeekdayToken, "4", 7}, {DateTimeSeparatorToken, "T", 8}, {HourToken, "05", 9},
+		{MinuteToken, "00", 11}, {OffsetToken, "Z", 13},
+	},
+	"2018-W39": {
+		{YearToken, "2018", 0}, {DateSeparatorToken, "-", 4}, {WeekDesignatorToken, "W", 5},
+		{WeekToken, "39", 6},
+	},
+	"2018-270": {{YearToken, "2018", 0}, {DateSeparatorToken, "-", 4}, {DayToken, "270", 5}},
+	"2018":     {{YearToken, "2018", 0}},
+	"2018-13-32": {
+		{YearToken, "2018", 0}, {DateSeparatorToken, "-", 4}, {MonthToken, "13", 5},
+		{DateSeparatorToken, "-", 7}, {DayToken, "32", 8},
+	},
+	"05:30:00,5-08": {
+		{HourToken, "05", 0}, {TimeSeparatorToken, ":", 2}, {MinuteToken, "30", 3},
+		{TimeSeparatorToken, ":", 5}, {SecondToken, "00", 6}, {DecimalSignToken, ",", 8},
+		{FractionToken, "5", 9}, {OffsetToken, "-08", 10},
+	},
+	"0530": {{YearToken, "0530", 0}},
+	"2018-09-27T05Z[Europe/Paris][u-ca=iso8601]": {
+		{YearToken, "2018", 0}, {DateSeparatorToken, "-", 4}, {MonthToken, "09", 5},
+		{DateSeparatorToken, "-", 7}, {DayToken, "27", 8}, {DateTimeSeparatorToken, "T", 10},
+		{HourToken, "05", 11}, {OffsetToken, "Z", 13}, {AnnotationToken, "[Europe/Paris]", 14},
+		{AnnotationToken, "[u-ca=iso8601]", 28},
+	},
+
+	// A time that is only an offset reads as midnight.
+	"-0530": {{OffsetToken, "-0530", 0}},
+	"2018-09-27T+05": {
+		{YearToken, "2018", 0}, {DateSeparatorToken, "-", 4}, {MonthToken, "09", 5},
+		{DateSeparatorToken, "-", 7}, {DayToken, "27", 8}, {DateTimeSeparatorToken, "T", 10},
+		{OffsetToken, "+05", 11},
+	},
+	"2018-09-27T-05:00": {
+		{YearToken, "2018", 0}, {DateSeparatorToken, "-", 4}, {MonthToken, "09", 5},
+		{DateSeparatorToken, "-", 7}, {DayToken, "27", 8}, {DateTimeSeparatorToken, "T", 10},
+		{OffsetToken, "-05:00", 11},
+	},
+}
+
+func TestTokenize(t *testing.T) {
+	for s, want := range tokenizations {
+		if got, err := Tokenize(s); err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf(`Tokenize(%q) -> (%v, %v) (should be %v)`, s, got, err, want)
+		}
+	}
+}
+
+// The tokens of every valid string must cover it exactly, in order.
+func TestTokenizeCovers(t *testing.T) {
+	for s := range allFormats {
+		tokens, err := Tokenize(s)
+		if err != nil {
+			t.Errorf(`Tokenize(%q) -> %v`, s, err)
+			continue
+		}
+		checkTokensCover(t, s, tokens)
+	}
+}
+
+// checkTokensCover checks that tokens, the tokens of s, cover it exactly, in order.
+func checkTokensCover(t *testing.T, s string, tokens []Token) {
+	t.Helper()
+	var b strings.Builder
+	for _, tok := range tokens {
+		if tok.Pos != b.Len() {
+			t.Errorf(`Tokenize(%q) has %v at %d (should be at %d)`, s, tok.Kind, tok.Pos, b.Len())
+		}
+		b.WriteString(tok.Text)
+	}
+	if b.String() != s {
+		t.Errorf(`Tokenize(%q) covers %q`, s, b.String())
+	}
+}
+
+func TestTokenizeErrors(t *testing.T) {
+	for s, kind := range map[string]error{
+		"":                     ErrSyntax,
+		"2018-09-27T05:00:00.": ErrTrailingData,
+		"2018-W54":             ErrInvalidWeek,
+		"2018-09-27T05[Paris":  ErrSyntax,
+		"not a timestamp":      ErrSyntax,
+	} {
+		if _, err := Tokenize(s); !errors.Is(err, kind) {
+			t.Errorf(`Tokenize(%q) -> %v (should wrap %v)`, s, err, kind)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import "time"
+
+// Truncated times
+//
+// ISO 8601:2000 allowed times to be truncated by mutual agreement, leaving the higher
+// order components to be understood: "-30:15" (or "-3015") is minute 30, second 15 of the
+// current hour, "-30" is minute 30 of the current hour, and "--45" is second 45 of the
+// current minute.  The 2004 edition dropped them, but legacy telecom and EDI feeds still
+// send them, so a Parser created with WithTruncatedTimes reads them against a reference
+// time supplied by the caller.  The seconds may have a fraction, as in "--45.5".
+
+// WithTruncatedTimes makes Parse and ParseTime accept the truncated times of ISO
+// 8601:2000, which start with a hyphen, and resolve them against ref: the missing hour
+// (and minute, for "--ss") are ref's, in ref's location.  ref is typically the time that
+// a feed was received.  The result is the time in ref's current hour or minute, even if
+// it is before ref; truncated times never roll over into the next hour.  As the
+// reference is fixed, make a Parser for each feed, or each batch, rather than sharing one.
+func WithTruncatedTimes(ref time.Time) Option {
+	return func(p *Parser) {
+		p.truncatedRef = &ref
+	}
+}
+
+// truncatedTime is the parsed form of a truncated time.
+type truncatedTime struct {
+	minute, second, nsec int
+	hasMinute            bool // Whether the minute was given, or is the reference's.
+}
+
+// isTruncated reports whether p accepts truncated times and s looks like one.  Nothing
+// else that Parse or ParseTime accepts starts with a hyphen.
+func (p *Parser) isTruncated(s string) bool {
+	return p.truncatedRef != nil && len(s) > 0 && s[0] == dateSep
+}
+
+// parseTruncated parses s as a truncated time and resolves it against p's reference.
+func (p *Parser) parseTruncated(s string) (time.Time, error) {
+	tt, err := parseTruncatedTime(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return tt.resolve(*p.truncatedRef), nil
+}
+
+// parseTruncatedTime parses "-mm", "-mm:ss", "-mmss", or "--ss", where the seconds may
+// have a fraction.
+func parseTruncatedTime(s string) (tt truncatedTime, err error) {
+	if len(s) < 3 || s[0] != dateSep {
+		return tt, &ParseError{s, "truncated time must be in -mm:ss, -mm, or --ss format", 0, "minute", ErrSyntax}
+	}
+	pos := 2
+	if s[1] != dateSep {
+		var ok bool
+		if tt.minute, ok = scanXSDDigits(s, 1, 2); !ok {
+			return tt, &ParseError{s, "minute must be two digits", 1, "minute", ErrSyntax}
+		}
+		if tt.minute > 59 {
+			return tt, &ParseError{s, "minute out of range", 1, "minute", ErrTimeRange}
+		}
+		tt.hasMinute = true
+		if pos = 3; pos == len(s) {
+			return tt, nil
+		}
+		if s[pos] == timeSep {
+			pos++
+		}
+	}
+	second, ok := scanXSDDigits(s, pos, 2)
+	if !ok {
+		return tt, &ParseError{s, "second must be two digits", pos, "second", ErrSyntax}
+	}
+	if second > 59 {
+		return tt, &ParseError{s, "second out of range", pos, "second", ErrTimeRange}
+	}
+	tt.second = second
+	if pos += 2; pos < len(s) && (s[pos] == '.' || s[pos] == ',') {
+		end := pos + 1
+		for end < len(s) && isDigit(s[end]) {
+			end++
+		}
+		if end == pos+1 {
+			return tt, &ParseError{s, "decimal sign must be followed by digits", pos, "fraction", ErrSyntax}
+		}
+		tt.nsec = fractionNanos(s[pos+1 : end])
+		pos = end
+	}
+	if pos < len(s) {
+		return tt, &ParseError{s, "unused components", pos, "", ErrTrailingData}
+	}
+	return tt, nil
+}
+
+// resolve returns tt in the hour, or minute, of ref.  It works back from ref on the time
+// line, rather than through the wall clock, so that a repeated hour at the end of
+// daylight saving time stays the one that ref is in.
+func (tt truncatedTime) resolve(ref time.Time) time.Time {
+	start := ref.Add(-time.Duration(ref.Second())*time.Second - time.Duration(ref.Nanosecond()))
+	if tt.hasMinute {
+		start = start.Add(time.Duration(tt.minute-ref.Minute()) * time.Minute)
+	}
+	return start.Add(time.Duration(tt.second)*time.Second + time.Duration(tt.nsec))
+}
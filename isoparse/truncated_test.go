@@ -0,0 +1,66 @@
+package isoparse
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTruncatedTimes(t *testing.T) {
+	ref := time.Date(2018, 9, 27, 10, 42, 7, 500, time.UTC)
+	p := NewParser(WithTruncatedTimes(ref))
+	for s, want := range map[string]time.Time{
+		"-30:15":      time.Date(2018, 9, 27, 10, 30, 15, 0, time.UTC),
+		"-3015":       time.Date(2018, 9, 27, 10, 30, 15, 0, time.UTC),
+		"-59:59.25":   time.Date(2018, 9, 27, 10, 59, 59, 250000000, time.UTC),
+		"-05":         time.Date(2018, 9, 27, 10, 5, 0, 0, time.UTC),
+		"--45":        time.Date(2018, 9, 27, 10, 42, 45, 0, time.UTC),
+		"--00,123456": time.Date(2018, 9, 27, 10, 42, 0, 123456000, time.UTC),
+	} {
+		if got, err := p.Parse(s); err != nil || !got.Equal(want) {
+			t.Errorf(`Parse(%q) with reference %v -> (%v, %v) (should be %v)`, s, ref, got, err, want)
+		}
+		if got, err := p.ParseTime(s); err != nil || got.TimeOfDay != TimeOfDayOf(want) || got.Loc != time.UTC || got.HasOffset {
+			t.Errorf(`ParseTime(%q) with reference %v -> (%v, %v) (should be %v)`, s, ref, got, err, TimeOfDayOf(want))
+		}
+	}
+	for s, kind := range map[string]error{
+		"-60:00":   ErrTimeRange,
+		"-30:60":   ErrTimeRange,
+		"--60":     ErrTimeRange,
+		"-3":       ErrSyntax,
+		"-30:":     ErrSyntax,
+		"-30:1":    ErrSyntax,
+		"--4":      ErrSyntax,
+		"--45.":    ErrSyntax,
+		"-ab":      ErrSyntax,
+		"-30:15Z":  ErrTrailingData,
+		"--45:00":  ErrTrailingData,
+		"-30:15:0": ErrTrailingData,
+	} {
+		if _, err := p.Parse(s); !errors.Is(err, kind) {
+			t.Errorf(`Parse(%q) with truncated times -> %v (should wrap %v)`, s, err, kind)
+		}
+	}
+	if _, err := p.Parse("2018-09-27T05:00:00Z"); err != nil {
+		t.Errorf(`Parse("2018-09-27T05:00:00Z") with truncated times -> %v`, err)
+	}
+	if _, err := ParseISODatetime("-30:15"); err == nil {
+		t.Errorf(`ParseISODatetime("-30:15") returned nil error (truncated times should be opt-in)`)
+	}
+	if _, err := ParseISOTimeParts("--45"); err == nil {
+		t.Errorf(`ParseISOTimeParts("--45") returned nil error (truncated times should be opt-in)`)
+	}
+}
+
+// The hour that a truncated time is in must be the reference's, even when the wall clock
+// repeats it at the end of daylight saving time.
+func TestTruncatedTimesRepeatedHour(t *testing.T) {
+	loc := loadLocation(t, "America/New_York")
+	// 01:30 EST, the second time round, on 2018-11-04.
+	ref := time.Date(2018, 11, 4, 6, 30, 0, 0, time.UTC).In(loc)
+	got, err := NewParser(WithTruncatedTimes(ref)).Parse("-15:00")
+	if want := ref.Add(-15 * time.Minute); err != nil || !got.Equal(want) {
+		t.Errorf(`Parse("-15:00") with reference %v -> (%v, %v) (should be %v)`, ref, got, err, want)
+	}
+}
@@ -0,0 +1,8 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+//go:build isoparse_notzdata
+
+package tzdata
+
+const embedded = false
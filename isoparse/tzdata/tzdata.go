@@ -0,0 +1,23 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+// Package tzdata embeds the IANA time zone database for the zone-aware features of isoparse.
+//
+// isoparse itself relies on the system's zoneinfo files (via time.LoadLocation) for RFC 9557
+// annotations such as "[Europe/Paris]" and for named locations passed to WithLocation.
+// Minimal container images often lack /usr/share/zoneinfo, in which case those zones fail to
+// load.  Importing this package for its side effect fixes that by linking in time/tzdata:
+//
+//	import _ "github.com/bsolomon1124/isoparse/isoparse/tzdata"
+//
+// The embedded database adds about 450 KB to the binary, so isoparse does not import it
+// unconditionally.  The zones subpackage does embed it, being useless without it; build
+// with the isoparse_notzdata tag to leave it out of both packages and rely on the system's
+// files alone (for instance when the binary is built with -tags timetzdata already).
+package tzdata
+
+// Embedded reports whether this build embeds the time zone database, that is, whether it
+// was built without the isoparse_notzdata tag.
+func Embedded() bool {
+	return embedded
+}
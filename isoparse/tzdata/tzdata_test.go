@@ -0,0 +1,23 @@
+package tzdata
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEmbedded(t *testing.T) {
+	// Whatever the build tags, named zones must load: from the embedded copy if Embedded,
+	// and from the system otherwise (the test is skipped where there is none).
+	if !Embedded() {
+		t.Log("built with isoparse_notzdata; relying on system zoneinfo")
+	}
+	for _, name := range []string{"Europe/Paris", "America/New_York", "Asia/Kolkata"} {
+		if _, err := time.LoadLocation(name); err != nil {
+			if Embedded() {
+				t.Errorf(`time.LoadLocation(%q) -> %v with embedded tzdata`, name, err)
+			} else {
+				t.Skipf(`time.LoadLocation(%q) -> %v; no system zoneinfo`, name, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,279 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"strings"
+	"time"
+)
+
+// Validators
+//
+// These answer "would this parse?" for callers that don't want the result, such as
+// request-validation middleware.  They walk the string the same way as the parsing
+// functions, but without building errors or time.Time values, so they never allocate.
+// TestValidMatchesParse and FuzzValid keep the two in step.
+
+// ValidDatetime reports whether ParseISODatetime would accept s.
+func ValidDatetime(s string) bool {
+	if strings.IndexByte(s, '[') >= 0 {
+		// Checking a time zone annotation means loading the zone.
+		_, err := ParseISODatetime(s)
+		return err == nil
+	}
+	date, pos, ok := validDate(s)
+	if !ok {
+		return false
+	}
+	var clock [4]int
+	if pos < len(s) {
+		if s[pos] > 127 {
+			return false
+		}
+		if clock, ok = validTime(s[pos+1:]); !ok {
+			return false
+		}
+	}
+	return inRange(date, clock)
+}
+
+// ValidDate reports whether ParseISODate would accept s.
+func ValidDate(s string) bool {
+	date, pos, ok := validDate(s)
+	return ok && pos == len(s) && inRange(date, [4]int{})
+}
+
+// ValidTime reports whether ParseISOTimeParts would accept s.
+func ValidTime(s string) bool {
+	clock, ok := validTime(s)
+	return ok && (TimeOfDay{clock[0], clock[1], clock[2], clock[3]}).IsValid()
+}
+
+// inRange is the allocation-free counterpart of checkRanges.
+func inRange(date [3]int, clock [4]int) bool {
+	year, month, day := date[0], time.Month(date[1]), date[2]
+	return year >= minYear && year <= maxYear &&
+		month >= minMonth && month <= maxMonth &&
+		day >= 1 && day <= daysInMonth(year, month) &&
+		clock[0] >= minHour && clock[0] <= maxHour &&
+		clock[1] >= minMin && clock[1] <= maxMin &&
+		clock[2] >= minSec && clock[2] <= maxSec &&
+		clock[3] >= minNsec && clock[3] <= maxNsec
+}
+
+// validDate follows parseISODate.
+func validDate(s string) (date [3]int, pos int, ok bool) {
+	if date, pos, ok = validDateCommon(s); ok {
+		return date, pos, ok
+	}
+	return validDateUncommon(s)
+}
+
+// validDateCommon follows parseISODateCommon.
+func validDateCommon(s string) (date [3]int, pos int, ok bool) {
+	length := len(s)
+	if length < 4 {
+		return date, 0, false
+	}
+	date = [3]int{1, 1, 1}
+	if date[0], ok = parseDigits(s[:4]); !ok {
+		return date, 0, false
+	}
+	pos = 4
+	if pos >= length {
+		return date, pos, true
+	}
+	hasSep := s[pos] == dateSep
+	pos += btoi(hasSep)
+	if length-pos < 2 {
+		return date, pos, false
+	}
+	if date[1], ok = parseDigits(s[pos : pos+2]); !ok {
+		return date, pos, false
+	}
+	pos += 2
+	if pos >= length {
+		return date, pos, hasSep
+	}
+	if hasSep {
+		if s[pos] != dateSep {
+			return date, pos, false
+		}
+		pos++
+	}
+	if length-pos < 2 {
+		return date, pos, false
+	}
+	date[2], ok = parseDigits(s[pos : pos+2])
+	return date, pos + 2, ok
+}
+
+// validDateUncommon follows parseISODateUncommon.  Week and ordinal dates are checked
+// in full here, and the result is given as a date that inRange accepts if and only if
+// the year of the week date's day is in range.
+func validDateUncommon(s string) (date [3]int, pos int, ok bool) {
+	length := len(s)
+	if length < 4 {
+		return date, 0, false
+	}
+	year, ok := parseDigits(s[:4])
+	if !ok {
+		return date, 0, false
+	}
+	pos = 4
+	hasSep := s[pos] == dateSep
+	pos += btoi(hasSep)
+	if pos >= length {
+		return date, pos, false
+	}
+	if s[pos] == 'W' {
+		pos++
+		if length-pos < 2 {
+			return date, pos, false
+		}
+		week, ok := parseDigits(s[pos : pos+2])
+		if !ok {
+			return date, pos, false
+		}
+		pos += 2
+		day := 1
+		if length > pos {
+			if (s[pos] == dateSep) != hasSep {
+				return date, pos, false
+			}
+			pos += btoi(hasSep)
+			if pos >= length {
+				return date, pos, false
+			}
+			if day, ok = parseDigits(s[pos : pos+1]); !ok {
+				return date, pos, false
+			}
+			pos++
+		}
+		if week < minISOWeek || week > maxISOWeek || day < minISODay || day > maxISODay {
+			return date, pos, false
+		}
+		// The day may fall in the year before or after; only the year matters here.
+		ord := weekDateOrdinal(year, week, day)
+		switch {
+		case ord <= daysBeforeYear(minYear):
+			year = minYear - 1
+		case ord > daysBeforeYear(maxYear+1):
+			year = maxYear + 1
+		default:
+			year = minYear
+		}
+		return [3]int{year, 1, 1}, pos, true
+	}
+	if length-pos < 3 {
+		return date, pos, false
+	}
+	if length-pos == 4 && (s[length-3] == dateSep) != hasSep {
+		return date, pos, false
+	}
+	ordinal, ok := parseDigits(s[pos : pos+3])
+	if !ok {
+		return date, pos, false
+	}
+	pos += 3
+	if ordinal < 1 || ordinal > 365+btoi(isLeapYear(year)) {
+		return date, pos, false
+	}
+	return [3]int{year, 1, 1}, pos, true
+}
+
+// weekDateOrdinal returns the ordinal of an ISO week date, as ymdToOrd counts them:
+// 0001-01-01 is day 1.
+func weekDateOrdinal(year, week, day int) int {
+	jan4 := daysBeforeYear(year) + 4
+	if year == 0 {
+		// daysBeforeYear is only right for positive years; year 0 is a leap year.
+		jan4 = -366 + 4
+	}
+	weekday := (jan4%7 + 7) % 7
+	if weekday == 0 {
+		weekday = 7
+	}
+	return jan4 - (weekday - 1) + (week-1)*7 + (day - 1)
+}
+
+// validTime follows parseISOTime.
+func validTime(s string) (clock [4]int, ok bool) {
+	length := len(s)
+	if length < 2 {
+		return clock, false
+	}
+	hasSep := length >= 3 && s[2] == timeSep
+	pos := 0
+	for comp := 0; pos < length && comp <= 4; comp++ {
+		if start := s[pos]; start == 'Z' || start == '+' || start == '-' {
+			if !validTimezone(s[pos:]) {
+				return clock, false
+			}
+			pos = length
+			break
+		}
+		if comp < 3 {
+			if length-pos < 2 {
+				return clock, false
+			}
+			if clock[comp], ok = parseDigits(s[pos : pos+2]); !ok {
+				return clock, false
+			}
+			pos += 2
+			if hasSep && pos < length && s[pos] == timeSep {
+				pos++
+			}
+		}
+		if comp == 3 && (s[pos] == '.' || s[pos] == ',') {
+			end := pos + 1
+			for end < length && isDigit(s[end]) {
+				end++
+			}
+			if end > pos+1 {
+				clock[3] = fractionNanos(s[pos+1 : end])
+				pos = end
+			}
+		}
+	}
+	if pos < length {
+		return clock, false
+	}
+	if clock[0] == 24 && (clock[1] != 0 || clock[2] != 0 || clock[3] != 0) {
+		return clock, false
+	}
+	return clock, true
+}
+
+// validTimezone follows parseTimezone.
+func validTimezone(s string) bool {
+	if s == "Z" {
+		return true
+	}
+	length := len(s)
+	if length != 3 && length != 5 && length != 6 {
+		return false
+	}
+	if s[0] != '+' && s[0] != '-' {
+		return false
+	}
+	hours, ok := parseDigits(s[1:3])
+	if !ok {
+		return false
+	}
+	var minutes int
+	if length != 3 {
+		minuteString := s[3:]
+		if length == 6 {
+			if s[3] != ':' {
+				return false
+			}
+			minuteString = s[4:]
+		}
+		if minutes, ok = parseDigits(minuteString); !ok {
+			return false
+		}
+	}
+	return hours >= minHour && hours <= maxHour && minutes >= minMin && minutes <= maxMin
+}
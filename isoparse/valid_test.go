@@ -0,0 +1,73 @@
+package isoparse
+
+import "testing"
+
+// validInputs gathers the strings from the other tests, valid and invalid alike.
+func validInputs() []string {
+	var inputs []string
+	for s := range allFormats {
+		inputs = append(inputs, s)
+	}
+	for s := range uncommonDates {
+		inputs = append(inputs, s)
+	}
+	for s := range timesWithComponents {
+		inputs = append(inputs, s, "2018-09-27T"+s)
+	}
+	for s := range tzStrings {
+		inputs = append(inputs, "12:30"+s, "2018-09-27T12:30"+s)
+	}
+	for s := range detectFormats {
+		inputs = append(inputs, s)
+	}
+	inputs = append(inputs, invalidDates...)
+	inputs = append(inputs, invalidDatetimes...)
+	inputs = append(inputs, invalidTimes...)
+	inputs = append(inputs, invalidYYYYMM...)
+	inputs = append(inputs, truncatedInputs...)
+	inputs = append(inputs,
+		"", "0000-01-01", "0000-W52-7", "0000-W01-1", "0001-W01-1", "9999-W52-5", "9999-W52-7",
+		"0000-366", "9999-365", "2018-09-00", "2018-09-27T05:00:00+24:00", "2018-09-27T05:00:00+25:00",
+		"2018-09-27T05:00:00.1234567891Z", "2018-09-27T05:00:00.Z", "2018-09-27 05:00", "2018-09-2705:00",
+		"0001-01-01T24:00:01Z", "2018-09-27T24:00:00Z",
+		"2018-09-27T12:30:00+02:00[Europe/Paris]", "2018-09-27T12:30:00+05:00[Europe/Paris]",
+	)
+	return inputs
+}
+
+func checkValid(t *testing.T, s string) {
+	t.Helper()
+	if _, err := ParseISODatetime(s); ValidDatetime(s) != (err == nil) {
+		t.Errorf(`ValidDatetime(%q) -> %v (ParseISODatetime error is %v)`, s, ValidDatetime(s), err)
+	}
+	if _, err := ParseISODate(s); ValidDate(s) != (err == nil) {
+		t.Errorf(`ValidDate(%q) -> %v (ParseISODate error is %v)`, s, ValidDate(s), err)
+	}
+	if _, err := ParseISOTimeParts(s); ValidTime(s) != (err == nil) {
+		t.Errorf(`ValidTime(%q) -> %v (ParseISOTimeParts error is %v)`, s, ValidTime(s), err)
+	}
+}
+
+func TestValidMatchesParse(t *testing.T) {
+	for _, s := range validInputs() {
+		checkValid(t, s)
+	}
+}
+
+func FuzzValid(f *testing.F) {
+	for _, s := range validInputs() {
+		f.Add(s)
+	}
+	f.Fuzz(checkValid)
+}
+
+func TestValidAllocs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping allocation counts in short mode")
+	}
+	for _, s := range []string{"2018-09-27T05:00:00Z", "2018-W39-4T05:00", "2018-02-30", "not a timestamp"} {
+		assertMaxAllocs(t, "ValidDatetime("+s+")", 0, func() { ValidDatetime(s) })
+		assertMaxAllocs(t, "ValidDate("+s+")", 0, func() { ValidDate(s) })
+		assertMaxAllocs(t, "ValidTime("+s+")", 0, func() { ValidTime(s) })
+	}
+}
@@ -0,0 +1,63 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"fmt"
+	"time"
+)
+
+// ISO week dates
+//
+// An ISO week starts on Monday, and week 1 of an ISO year is the week containing the
+// year's first Thursday, so an ISO year has 52 or 53 weeks, and its first and last few
+// days may fall in the neighboring Gregorian years.
+
+// FromISOWeekDate returns the date of day isoDay (Monday is 1, Sunday 7) of week isoWeek
+// of ISO year isoYear.  It is the inverse of time.Time.ISOWeek, which the standard
+// library lacks.
+//
+// Unlike the week dates accepted by the parsing functions, which let week 53 of a
+// 52-week year roll over into the next year, isoWeek must exist in isoYear.  The error
+// wraps ErrInvalidWeek or ErrInvalidDay.
+func FromISOWeekDate(isoYear, isoWeek, isoDay int) (Date, error) {
+	if isoDay < minISODay || isoDay > maxISODay {
+		return Date{}, fmt.Errorf("isoparse: ISO weekday %d out of range: %w", isoDay, ErrInvalidDay)
+	}
+	if isoWeek < minISOWeek || isoWeek > WeeksInISOYear(isoYear) {
+		return Date{}, fmt.Errorf("isoparse: ISO year %d has no week %d: %w", isoYear, isoWeek, ErrInvalidWeek)
+	}
+	t, _ := calcWeekdate(isoYear, isoWeek, isoDay)
+	return DateOf(t), nil
+}
+
+// WeeksInISOYear returns the number of weeks in ISO year year, 52 or 53.  A year has 53
+// weeks if it starts on a Thursday, or is a leap year starting on a Wednesday.
+func WeeksInISOYear(year int) int {
+	// December 28 is always in the last week of its ISO year.
+	_, week := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return week
+}
+
+// ISOWeekRange returns the first and last dates, Monday and Sunday, of week isoWeek of
+// ISO year isoYear.  Either may fall in a neighboring Gregorian year.  As with
+// FromISOWeekDate, the week must exist in the year.
+func ISOWeekRange(isoYear, isoWeek int) (first, last Date, err error) {
+	if first, err = FromISOWeekDate(isoYear, isoWeek, minISODay); err != nil {
+		return Date{}, Date{}, err
+	}
+	return first, first.AddDays(6), nil
+}
+
+// ISOWeekInterval returns week isoWeek of ISO year isoYear as an Interval in loc, from
+// midnight at the start of its Monday to midnight at the start of the following Monday.
+// Since Interval.Contains excludes the end, it is the usual window for weekly reports.
+// Like Date.In, it panics if loc is nil.
+func ISOWeekInterval(isoYear, isoWeek int, loc *time.Location) (Interval, error) {
+	first, _, err := ISOWeekRange(isoYear, isoWeek)
+	if err != nil {
+		return Interval{}, err
+	}
+	return Interval{first.In(loc), first.AddDays(7).In(loc)}, nil
+}
@@ -0,0 +1,86 @@
+package isoparse
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// FromISOWeekDate must invert time.Time.ISOWeek over several years, including the days
+// that fall in a neighboring Gregorian year.
+func TestFromISOWeekDate(t *testing.T) {
+	for tm := time.Date(2003, 12, 1, 0, 0, 0, 0, time.UTC); tm.Year() < 2011; tm = tm.AddDate(0, 0, 1) {
+		year, week := tm.ISOWeek()
+		day := isoWeekday(tm)
+		if got, err := FromISOWeekDate(year, week, day); err != nil || got != DateOf(tm) {
+			t.Errorf(`FromISOWeekDate(%d, %d, %d) -> (%v, %v) (should be %v)`, year, week, day, got, err, DateOf(tm))
+		}
+	}
+}
+
+func TestFromISOWeekDateInvalid(t *testing.T) {
+	for _, c := range []struct {
+		year, week, day int
+		want            error
+	}{
+		{2018, 53, 1, ErrInvalidWeek}, // 2018 has 52 weeks
+		{2018, 0, 1, ErrInvalidWeek},
+		{2018, 54, 1, ErrInvalidWeek},
+		{2018, 1, 0, ErrInvalidDay},
+		{2018, 1, 8, ErrInvalidDay},
+	} {
+		if got, err := FromISOWeekDate(c.year, c.week, c.day); !errors.Is(err, c.want) {
+			t.Errorf(`FromISOWeekDate(%d, %d, %d) -> (%v, %v) (should wrap %v)`, c.year, c.week, c.day, got, err, c.want)
+		}
+	}
+	if got, err := FromISOWeekDate(2020, 53, 7); err != nil || got != (Date{2021, time.January, 3}) {
+		t.Errorf(`FromISOWeekDate(2020, 53, 7) -> (%v, %v) (should be 2021-01-03)`, got, err)
+	}
+}
+
+func TestWeeksInISOYear(t *testing.T) {
+	for year := 1; year <= 2400; year++ {
+		jan1 := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC).Weekday()
+		want := 52
+		if jan1 == time.Thursday || (isLeapYear(year) && jan1 == time.Wednesday) {
+			want = 53
+		}
+		if got := WeeksInISOYear(year); got != want {
+			t.Errorf(`WeeksInISOYear(%d) -> %d (should be %d)`, year, got, want)
+		}
+	}
+	for _, year := range []int{2004, 2009, 2015, 2020, 2026, 2032} {
+		if got := WeeksInISOYear(year); got != 53 {
+			t.Errorf(`WeeksInISOYear(%d) -> %d (should be 53)`, year, got)
+		}
+	}
+}
+
+func TestISOWeekRange(t *testing.T) {
+	for _, c := range []struct {
+		year, week  int
+		first, last Date
+	}{
+		{2018, 39, Date{2018, 9, 24}, Date{2018, 9, 30}},
+		{2020, 1, Date{2019, 12, 30}, Date{2020, 1, 5}},
+		{2020, 53, Date{2020, 12, 28}, Date{2021, 1, 3}},
+	} {
+		if first, last, err := ISOWeekRange(c.year, c.week); err != nil || first != c.first || last != c.last {
+			t.Errorf(`ISOWeekRange(%d, %d) -> (%v, %v, %v) (should be %v, %v)`, c.year, c.week, first, last, err, c.first, c.last)
+		}
+	}
+	if _, _, err := ISOWeekRange(2018, 53); !errors.Is(err, ErrInvalidWeek) {
+		t.Errorf(`ISOWeekRange(2018, 53) -> %v (should wrap ErrInvalidWeek)`, err)
+	}
+}
+
+func TestISOWeekInterval(t *testing.T) {
+	iv, err := ISOWeekInterval(2018, 39, time.UTC)
+	want := Interval{time.Date(2018, 9, 24, 0, 0, 0, 0, time.UTC), time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)}
+	if err != nil || !iv.Equal(want) {
+		t.Errorf(`ISOWeekInterval(2018, 39, UTC) -> (%v, %v) (should be %v)`, iv, err, want)
+	}
+	if !iv.Contains(time.Date(2018, 9, 30, 23, 59, 59, 0, time.UTC)) || iv.Contains(want.End) {
+		t.Errorf(`ISOWeekInterval(2018, 39, UTC) -> %v (should contain all of Sunday and none of Monday)`, iv)
+	}
+}
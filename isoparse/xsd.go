@@ -0,0 +1,352 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import (
+	"encoding"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// XML Schema datatypes
+//
+// XML Schema (https://www.w3.org/TR/xmlschema-2/) defines its own lexical forms for
+// xsd:date, xsd:dateTime, xsd:time, and xsd:duration.  They are close to ISO-8601's
+// extended format, but differ in ways that matter to validating consumers such as SOAP
+// stacks:
+//
+//   - Only the extended format is allowed, with every component present.
+//   - The year has at least four digits, may be negative, and may not be 0000.
+//     (This follows XSD 1.0, where there is no year zero: "-0001" is 1 BCE.  Values are
+//     stored with astronomical year numbering like time.Time, so "-0001" has Year 0.)
+//   - The timezone is optional, and its absence is meaningful: a value without one is
+//     not pinned to any particular offset.  When present it must be in the range ±14:00.
+//   - 24:00:00 is allowed, with no nonzero fraction, as the first instant of the next day.
+//   - Durations have no weeks, and only the seconds may have a fraction.
+//
+// The XSD types here implement encoding.TextMarshaler and encoding.TextUnmarshaler with
+// exactly those rules, so they can be embedded in structs used with encoding/xml.
+
+var (
+	_ encoding.TextMarshaler   = XSDDate{}
+	_ encoding.TextUnmarshaler = (*XSDDate)(nil)
+	_ encoding.TextMarshaler   = XSDDateTime{}
+	_ encoding.TextUnmarshaler = (*XSDDateTime)(nil)
+	_ encoding.TextMarshaler   = XSDTime{}
+	_ encoding.TextUnmarshaler = (*XSDTime)(nil)
+	_ encoding.TextMarshaler   = XSDDuration{}
+	_ encoding.TextUnmarshaler = (*XSDDuration)(nil)
+)
+
+// Maximum magnitude of an XSD timezone offset, in seconds.
+const maxXSDOffset = 14 * 60 * 60
+
+// XSDDate is an xsd:date value: a date with an optional timezone.
+type XSDDate struct {
+	Date        Date // Year uses astronomical numbering; see above.
+	HasTimezone bool
+	Offset      int // Seconds east of UTC; only meaningful if HasTimezone.
+}
+
+// XSDDateTime is an xsd:dateTime value.
+//
+// If HasTimezone is true, Time is in a location with the parsed offset.  Otherwise the
+// string had no timezone, and Time holds the wall-clock reading in time.UTC; callers
+// must decide for themselves which location it belongs to (see SetLoc).
+type XSDDateTime struct {
+	Time        time.Time
+	HasTimezone bool
+}
+
+// XSDTime is an xsd:time value: a time of day with an optional timezone.
+// 24:00:00 is read as 00:00:00, as XML Schema specifies.
+type XSDTime struct {
+	Time        TimeOfDay
+	HasTimezone bool
+	Offset      int // Seconds east of UTC; only meaningful if HasTimezone.
+}
+
+// XSDDuration is an xsd:duration value.  Its Period never has Weeks.
+type XSDDuration struct {
+	Period Period
+}
+
+// xsdError builds the error for a value that is not valid for an XSD datatype.
+// kind is one of the Err sentinels.
+func xsdError(s, typ, msg string, kind error) error {
+	return &ParseError{s, "invalid " + typ + ": " + msg, -1, "", kind}
+}
+
+// scanXSDDigits reads exactly n ASCII digits at pos.
+func scanXSDDigits(s string, pos, n int) (int, bool) {
+	if pos+n > len(s) {
+		return 0, false
+	}
+	return parseDigits(s[pos : pos+n])
+}
+
+// scanXSDDate reads -?YYYY-MM-DD at the start of s.
+// The year is returned with astronomical numbering.
+func scanXSDDate(s, typ string) (d Date, pos int, err error) {
+	negative := strings.HasPrefix(s, "-")
+	pos = btoi(negative)
+	start := pos
+	for pos < len(s) && isDigit(s[pos]) {
+		pos++
+	}
+	digits := s[start:pos]
+	switch {
+	case len(digits) < 4:
+		return d, pos, xsdError(s, typ, "year must have at least four digits", ErrSyntax)
+	case len(digits) > 4 && digits[0] == '0':
+		return d, pos, xsdError(s, typ, "year with more than four digits may not have leading zeros", ErrSyntax)
+	case len(digits) > 9:
+		return d, pos, xsdError(s, typ, "year out of valid range", ErrYearRange)
+	case digits == "0000":
+		return d, pos, xsdError(s, typ, "year 0000 is not allowed", ErrYearRange)
+	}
+	d.Year, _ = parseDigits(digits)
+	if negative {
+		d.Year = 1 - d.Year
+	}
+
+	month, ok1 := scanXSDDigits(s, pos+1, 2)
+	day, ok2 := scanXSDDigits(s, pos+4, 2)
+	if !ok1 || !ok2 || s[pos] != dateSep || s[pos+3] != dateSep {
+		return d, pos, xsdError(s, typ, "date must be in -?YYYY-MM-DD format", ErrSyntax)
+	}
+	d.Month, d.Day = time.Month(month), day
+	if d.Month < time.January || d.Month > time.December {
+		return d, pos, xsdError(s, typ, "month out of valid range", ErrInvalidMonth)
+	}
+	if !d.IsValid() {
+		return d, pos, xsdError(s, typ, "day out of valid range", ErrInvalidDay)
+	}
+	return d, pos + 6, nil
+}
+
+// scanXSDTime reads hh:mm:ss(.s+)? at pos.
+// Hour 24 is returned as-is; the caller decides what it means.
+func scanXSDTime(s string, pos int, typ string) (t TimeOfDay, newPos int, err error) {
+	var ok1, ok2, ok3 bool
+	t.Hour, ok1 = scanXSDDigits(s, pos, 2)
+	t.Minute, ok2 = scanXSDDigits(s, pos+3, 2)
+	t.Second, ok3 = scanXSDDigits(s, pos+6, 2)
+	if !ok1 || !ok2 || !ok3 || s[pos+2] != timeSep || s[pos+5] != timeSep {
+		return t, pos, xsdError(s, typ, "time must be in hh:mm:ss format", ErrSyntax)
+	}
+	pos += 8
+	if pos < len(s) && s[pos] == '.' {
+		pos++
+		start := pos
+		for pos < len(s) && isDigit(s[pos]) {
+			pos++
+		}
+		if pos == start {
+			return t, pos, xsdError(s, typ, "expected digits after decimal point", ErrSyntax)
+		}
+		// We have nanosecond precision; the rest is truncated as with ISO times.
+		t.Nanosecond = fractionNanos(s[start:pos])
+	}
+	if t.Hour == 24 && (t.Minute != 0 || t.Second != 0 || t.Nanosecond != 0) {
+		return t, pos, xsdError(s, typ, "hour 24 is only allowed as 24:00:00", ErrTimeRange)
+	}
+	if !t.IsValid() {
+		return t, pos, xsdError(s, typ, "time component out of valid range", ErrTimeRange)
+	}
+	return t, pos, nil
+}
+
+// scanXSDTimezone reads an optional (Z|(+|-)hh:mm) that must end s.
+func scanXSDTimezone(s string, pos int, typ string) (has bool, offset int, err error) {
+	switch {
+	case pos == len(s):
+		return false, 0, nil
+	case s[pos] == 'Z' && pos+1 == len(s):
+		return true, 0, nil
+	case (s[pos] == '+' || s[pos] == '-') && pos+6 == len(s) && s[pos+3] == timeSep:
+		hours, ok1 := scanXSDDigits(s, pos+1, 2)
+		minutes, ok2 := scanXSDDigits(s, pos+4, 2)
+		if !ok1 || !ok2 || minutes > maxMin {
+			break
+		}
+		offset = hours*60*60 + minutes*60
+		if offset > maxXSDOffset {
+			return false, 0, xsdError(s, typ, "timezone offset must be within ±14:00", ErrInvalidOffset)
+		}
+		if s[pos] == '-' {
+			offset = -offset
+		}
+		return true, offset, nil
+	}
+	return false, 0, xsdError(s, typ, "timezone must be Z or ±hh:mm, and must end the value", ErrInvalidOffset)
+}
+
+// formatXSDYear writes an astronomical year in XSD form.
+func formatXSDYear(b *strings.Builder, year int) {
+	if year <= 0 {
+		b.WriteByte('-')
+		year = 1 - year
+	}
+	fmt.Fprintf(b, "%04d", year)
+}
+
+// formatXSDTimezone writes an offset as Z or ±hh:mm.
+func formatXSDTimezone(b *strings.Builder, offset int) {
+	if offset == 0 {
+		b.WriteByte('Z')
+		return
+	}
+	sign := byte('+')
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+	b.WriteByte(sign)
+	fmt.Fprintf(b, "%02d:%02d", offset/3600, offset%3600/60)
+}
+
+// String returns d in xsd:date lexical form.
+func (d XSDDate) String() string {
+	var b strings.Builder
+	formatXSDYear(&b, d.Date.Year)
+	fmt.Fprintf(&b, "-%02d-%02d", d.Date.Month, d.Date.Day)
+	if d.HasTimezone {
+		formatXSDTimezone(&b, d.Offset)
+	}
+	return b.String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d XSDDate) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting only the xsd:date lexical form.
+func (d *XSDDate) UnmarshalText(data []byte) error {
+	s := string(data)
+	date, pos, err := scanXSDDate(s, "xsd:date")
+	if err != nil {
+		return err
+	}
+	has, offset, err := scanXSDTimezone(s, pos, "xsd:date")
+	if err != nil {
+		return err
+	}
+	*d = XSDDate{date, has, offset}
+	return nil
+}
+
+// String returns dt in xsd:dateTime lexical form.
+func (dt XSDDateTime) String() string {
+	var b strings.Builder
+	formatXSDYear(&b, dt.Time.Year())
+	fmt.Fprintf(&b, "-%02d-%02dT%s", dt.Time.Month(), dt.Time.Day(), TimeOfDayOf(dt.Time))
+	if dt.HasTimezone {
+		_, offset := dt.Time.Zone()
+		formatXSDTimezone(&b, offset)
+	}
+	return b.String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (dt XSDDateTime) MarshalText() ([]byte, error) {
+	return []byte(dt.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting only the xsd:dateTime lexical form.
+func (dt *XSDDateTime) UnmarshalText(data []byte) error {
+	s := string(data)
+	date, pos, err := scanXSDDate(s, "xsd:dateTime")
+	if err != nil {
+		return err
+	}
+	if pos >= len(s) || s[pos] != 'T' {
+		return xsdError(s, "xsd:dateTime", "date and time must be separated by T", ErrSyntax)
+	}
+	tod, pos, err := scanXSDTime(s, pos+1, "xsd:dateTime")
+	if err != nil {
+		return err
+	}
+	has, offset, err := scanXSDTimezone(s, pos, "xsd:dateTime")
+	if err != nil {
+		return err
+	}
+	loc := time.UTC
+	if has && offset != 0 {
+		loc = offsetZone(offset)
+	}
+	// time.Date rolls 24:00:00 over to the next day, as XML Schema specifies.
+	t := time.Date(date.Year, date.Month, date.Day, tod.Hour, tod.Minute, tod.Second, tod.Nanosecond, loc)
+	*dt = XSDDateTime{t, has}
+	return nil
+}
+
+// String returns t in xsd:time lexical form.
+func (t XSDTime) String() string {
+	var b strings.Builder
+	b.WriteString(t.Time.String())
+	if t.HasTimezone {
+		formatXSDTimezone(&b, t.Offset)
+	}
+	return b.String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t XSDTime) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting only the xsd:time lexical form.
+func (t *XSDTime) UnmarshalText(data []byte) error {
+	s := string(data)
+	tod, pos, err := scanXSDTime(s, 0, "xsd:time")
+	if err != nil {
+		return err
+	}
+	has, offset, err := scanXSDTimezone(s, pos, "xsd:time")
+	if err != nil {
+		return err
+	}
+	if tod.Hour == 24 {
+		tod.Hour = 0
+	}
+	*t = XSDTime{tod, has, offset}
+	return nil
+}
+
+// String returns d in xsd:duration lexical form.  Weeks, which xsd:duration lacks,
+// are written as days.
+func (d XSDDuration) String() string {
+	p := d.Period
+	p.Days += p.Weeks * 7
+	p.Weeks = 0
+	return p.String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d XSDDuration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting only the xsd:duration
+// lexical form: no weeks, no leading '+', and a '.' fraction on the seconds only.
+func (d *XSDDuration) UnmarshalText(data []byte) error {
+	s := string(data)
+	switch {
+	case strings.HasPrefix(s, "+"):
+		return xsdError(s, "xsd:duration", "leading + is not allowed", ErrSyntax)
+	case strings.ContainsAny(s, "W,"):
+		return xsdError(s, "xsd:duration", "weeks and comma decimal signs are not allowed", ErrSyntax)
+	}
+	if dot := strings.IndexByte(s, '.'); dot >= 0 && !strings.HasSuffix(s, "S") {
+		return xsdError(s, "xsd:duration", "only seconds may have a fraction", ErrSyntax)
+	}
+	p, err := parseISODuration(s)
+	if err != nil {
+		return err
+	}
+	*d = XSDDuration{p}
+	return nil
+}
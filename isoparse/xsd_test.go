@@ -0,0 +1,197 @@
+package isoparse
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+var xsdDates = map[string]XSDDate{
+	"2018-09-27":       {Date{2018, 9, 27}, false, 0},
+	"2018-09-27Z":      {Date{2018, 9, 27}, true, 0},
+	"2018-09-27+14:00": {Date{2018, 9, 27}, true, 14 * 60 * 60},
+	"2018-09-27-05:30": {Date{2018, 9, 27}, true, -(5*60 + 30) * 60},
+	"-0001-01-01":      {Date{0, 1, 1}, false, 0},
+	"-0044-03-15":      {Date{-43, 3, 15}, false, 0},
+	"12018-09-27":      {Date{12018, 9, 27}, false, 0},
+	"2000-02-29Z":      {Date{2000, 2, 29}, true, 0},
+	"0001-01-01-14:00": {Date{1, 1, 1}, true, -14 * 60 * 60},
+}
+
+var invalidXSDDates = []string{
+	"0000-01-01",       // No year zero
+	"018-09-27",        // Too few year digits
+	"02018-09-27",      // Leading zero with more than four digits
+	"20180927",         // Basic format
+	"2018-09",          // Reduced precision
+	"2018-W39-4",       // Week date
+	"2018-270",         // Ordinal date
+	"2018-02-29",       // Invalid day
+	"2018-09-27+14:01", // Offset out of range
+	"2018-09-27+15:00", // Offset out of range
+	"2018-09-27+0500",  // Basic offset
+	"2018-09-27+05",    // Reduced offset
+	"2018-09-27T00:00", // Time portion
+	"+2018-09-27",      // Leading plus
+}
+
+var xsdDateTimes = map[string]XSDDateTime{
+	"2018-09-27T11:52:59":           {time.Date(2018, 9, 27, 11, 52, 59, 0, time.UTC), false},
+	"2018-09-27T11:52:59Z":          {time.Date(2018, 9, 27, 11, 52, 59, 0, time.UTC), true},
+	"2018-09-27T11:52:59.5-05:00":   {time.Date(2018, 9, 27, 11, 52, 59, 5e8, offsetZone(-5*60*60)), true},
+	"2018-09-27T24:00:00Z":          {time.Date(2018, 9, 28, 0, 0, 0, 0, time.UTC), true},
+	"2018-09-27T24:00:00.000Z":      {time.Date(2018, 9, 28, 0, 0, 0, 0, time.UTC), true},
+	"-0001-12-31T23:59:59Z":         {time.Date(0, 12, 31, 23, 59, 59, 0, time.UTC), true},
+	"2018-09-27T11:52:59.123456789": {time.Date(2018, 9, 27, 11, 52, 59, 123456789, time.UTC), false},
+}
+
+var invalidXSDDateTimes = []string{
+	"2018-09-27",                // Missing time
+	"2018-09-27 11:52:59",       // Wrong separator
+	"2018-09-27T11:52",          // Missing seconds
+	"2018-09-27T115259",         // Basic time
+	"2018-09-27T11:52:59,5",     // Comma decimal sign
+	"2018-09-27T11:52:59.",      // No fraction digits
+	"2018-09-27T24:00:01Z",      // Hour 24 not at midnight
+	"2018-09-27T24:00:00.1Z",    // Hour 24 with a fraction
+	"2018-09-27T11:60:00Z",      // Minute out of range
+	"2018-09-27T11:52:60Z",      // Leap second
+	"2018-09-27T11:52:59+14:30", // Offset out of range
+	"2018-09-27T11:52:59Zjunk",  // Trailing data
+	"0000-09-27T11:52:59Z",      // No year zero
+}
+
+var xsdTimes = map[string]XSDTime{
+	"11:52:59":       {TimeOfDay{11, 52, 59, 0}, false, 0},
+	"11:52:59.25Z":   {TimeOfDay{11, 52, 59, 25e7}, true, 0},
+	"11:52:59+01:00": {TimeOfDay{11, 52, 59, 0}, true, 60 * 60},
+	"24:00:00":       {TimeOfDay{0, 0, 0, 0}, false, 0},
+}
+
+var invalidXSDTimes = []string{
+	"11:52",
+	"115259",
+	"24:30:00",
+	"11:52:59+1:00",
+	"11:52:59 Z",
+}
+
+var xsdDurations = map[string]XSDDuration{
+	"P1Y2M3DT10H30M": {Period{Years: 1, Months: 2, Days: 3, Hours: 10, Minutes: 30}},
+	"-P120D":         {Period{Negative: true, Days: 120}},
+	"PT1.5S":         {Period{Seconds: 1, Nanoseconds: 5e8}},
+	"P0Y1347M0D":     {Period{Months: 1347}},
+}
+
+var invalidXSDDurations = []string{
+	"P1W",    // No weeks
+	"+P1D",   // No leading plus
+	"PT1,5S", // No comma
+	"PT1.5H", // Fraction on hours
+	"P1.5D",  // Fraction on days
+	"P",      // No components
+	"P1DT",   // Time designator with no time components
+}
+
+func TestXSDDate(t *testing.T) {
+	for s, want := range xsdDates {
+		var got XSDDate
+		if err := got.UnmarshalText([]byte(s)); err != nil {
+			t.Errorf(`XSDDate.UnmarshalText(%q) -> non-nil error (%v) for valid xsd:date`, s, err)
+		} else if got != want {
+			t.Errorf(`XSDDate.UnmarshalText(%q) -> %#v (should be %#v)`, s, got, want)
+		} else if got.String() != s {
+			t.Errorf(`XSDDate.UnmarshalText(%q).String() -> %q (should round trip)`, s, got.String())
+		}
+	}
+	for _, s := range invalidXSDDates {
+		var got XSDDate
+		if err := got.UnmarshalText([]byte(s)); err == nil {
+			t.Errorf(`XSDDate.UnmarshalText(%q) -> %v returned nil error (invalid xsd:date should error)`, s, got)
+		}
+	}
+}
+
+func TestXSDDateTime(t *testing.T) {
+	for s, want := range xsdDateTimes {
+		var got XSDDateTime
+		if err := got.UnmarshalText([]byte(s)); err != nil {
+			t.Errorf(`XSDDateTime.UnmarshalText(%q) -> non-nil error (%v) for valid xsd:dateTime`, s, err)
+		} else if !got.Time.Equal(want.Time) || got.HasTimezone != want.HasTimezone {
+			t.Errorf(`XSDDateTime.UnmarshalText(%q) -> %v (should be %v)`, s, got, want)
+		}
+	}
+	// Canonical forms round trip exactly.
+	for _, s := range []string{"2018-09-27T11:52:59", "2018-09-27T11:52:59.5-05:00", "-0001-12-31T23:59:59Z"} {
+		var got XSDDateTime
+		if err := got.UnmarshalText([]byte(s)); err != nil || got.String() != s {
+			t.Errorf(`XSDDateTime.UnmarshalText(%q).String() -> %q, %v (should round trip)`, s, got.String(), err)
+		}
+	}
+	for _, s := range invalidXSDDateTimes {
+		var got XSDDateTime
+		if err := got.UnmarshalText([]byte(s)); err == nil {
+			t.Errorf(`XSDDateTime.UnmarshalText(%q) -> %v returned nil error (invalid xsd:dateTime should error)`, s, got)
+		}
+	}
+}
+
+func TestXSDTime(t *testing.T) {
+	for s, want := range xsdTimes {
+		var got XSDTime
+		if err := got.UnmarshalText([]byte(s)); err != nil {
+			t.Errorf(`XSDTime.UnmarshalText(%q) -> non-nil error (%v) for valid xsd:time`, s, err)
+		} else if got != want {
+			t.Errorf(`XSDTime.UnmarshalText(%q) -> %#v (should be %#v)`, s, got, want)
+		}
+	}
+	for _, s := range invalidXSDTimes {
+		var got XSDTime
+		if err := got.UnmarshalText([]byte(s)); err == nil {
+			t.Errorf(`XSDTime.UnmarshalText(%q) -> %v returned nil error (invalid xsd:time should error)`, s, got)
+		}
+	}
+}
+
+func TestXSDDuration(t *testing.T) {
+	for s, want := range xsdDurations {
+		var got XSDDuration
+		if err := got.UnmarshalText([]byte(s)); err != nil {
+			t.Errorf(`XSDDuration.UnmarshalText(%q) -> non-nil error (%v) for valid xsd:duration`, s, err)
+		} else if got != want {
+			t.Errorf(`XSDDuration.UnmarshalText(%q) -> %#v (should be %#v)`, s, got, want)
+		}
+	}
+	for _, s := range invalidXSDDurations {
+		var got XSDDuration
+		if err := got.UnmarshalText([]byte(s)); err == nil {
+			t.Errorf(`XSDDuration.UnmarshalText(%q) -> %v returned nil error (invalid xsd:duration should error)`, s, got)
+		}
+	}
+	if s := (XSDDuration{Period{Weeks: 2, Days: 1}}).String(); s != "P15D" {
+		t.Errorf(`XSDDuration{P2W1D}.String() -> %q (should be "P15D")`, s)
+	}
+}
+
+type soapEnvelope struct {
+	XMLName xml.Name    `xml:"order"`
+	Placed  XSDDateTime `xml:"placed,attr"`
+	Due     XSDDate     `xml:"due"`
+	Window  XSDDuration `xml:"window"`
+	Cutoff  XSDTime     `xml:"cutoff"`
+}
+
+func TestXSDEncodingXML(t *testing.T) {
+	const doc = `<order placed="2018-09-27T11:52:59-05:00"><due>2018-10-01Z</due><window>P3DT12H</window><cutoff>17:00:00</cutoff></order>`
+	var env soapEnvelope
+	if err := xml.Unmarshal([]byte(doc), &env); err != nil {
+		t.Fatalf(`xml.Unmarshal(%s) -> non-nil error (%v)`, doc, err)
+	}
+	out, err := xml.Marshal(env)
+	if err != nil {
+		t.Fatalf(`xml.Marshal(%v) -> non-nil error (%v)`, env, err)
+	}
+	if string(out) != doc {
+		t.Errorf(`xml.Marshal(xml.Unmarshal(%s)) -> %s (should round trip)`, doc, out)
+	}
+}
@@ -0,0 +1,58 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+package isoparse
+
+import "encoding"
+
+// YAML decoding
+//
+// gopkg.in/yaml.v3 hands the raw scalar to encoding.TextUnmarshaler, but gopkg.in/yaml.v2
+// only does so for scalars that it resolves as strings.  Unquoted values that YAML 1.1
+// considers something else, like the integer 2018 or the timestamp 2018-09-27, never reach
+// UnmarshalText there.  Each value type therefore also implements the yaml.Unmarshaler
+// interface in its original function-based form, which both versions honor (v3 as its
+// "obsolete" unmarshaler).  It reads the scalar as a string, quoted or not, and hands it
+// to UnmarshalText.  This needs no import of either yaml package.
+//
+// Encoding needs nothing extra: both versions use encoding.TextMarshaler.
+// YAML null leaves the value untouched, as both versions skip unmarshalers for null.
+
+// unmarshalYAMLText reads a YAML scalar as a string and passes it to u.
+func unmarshalYAMLText(unmarshal func(interface{}) error, u encoding.TextUnmarshaler) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return u.UnmarshalText([]byte(s))
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler (in the form shared by yaml.v2 and yaml.v3).
+func (d *Date) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, d)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler (in the form shared by yaml.v2 and yaml.v3).
+func (ym *YearMonth) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, ym)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler (in the form shared by yaml.v2 and yaml.v3).
+func (t *TimeOfDay) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, t)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler (in the form shared by yaml.v2 and yaml.v3).
+func (dt *DateTime) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, dt)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler (in the form shared by yaml.v2 and yaml.v3).
+func (p *Period) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, p)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler (in the form shared by yaml.v2 and yaml.v3).
+func (iv *Interval) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAMLText(unmarshal, iv)
+}
@@ -0,0 +1,64 @@
+package isoparse
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// yamlScalar mimics the unmarshal function a yaml decoder passes to UnmarshalYAML for a
+// scalar node: it stores the raw scalar text into a *string.
+func yamlScalar(raw string) func(interface{}) error {
+	return func(v interface{}) error {
+		s, ok := v.(*string)
+		if !ok {
+			return errors.New("yamlScalar: can only decode into *string")
+		}
+		*s = raw
+		return nil
+	}
+}
+
+type yamlUnmarshaler interface {
+	UnmarshalYAML(func(interface{}) error) error
+}
+
+// Raw scalars as they would appear in a YAML document (with any quotes already removed
+// by the decoder), and the value each should decode to.
+var yamlScalars = []struct {
+	raw  string
+	into yamlUnmarshaler
+	want interface{}
+}{
+	{"2018-09-27", new(Date), &Date{2018, time.September, 27}},
+	{"2018", new(Date), &Date{2018, time.January, 1}},
+	{"2018-09", new(YearMonth), &YearMonth{2018, time.September}},
+	{"12:30:00", new(TimeOfDay), &TimeOfDay{12, 30, 0, 0}},
+	{"2018-09-27T12:30:00", new(DateTime), &DateTime{Date{2018, time.September, 27}, TimeOfDay{12, 30, 0, 0}}},
+	{"PT30S", new(Period), &Period{Seconds: 30}},
+	{"P1D", new(Period), &Period{Days: 1}},
+}
+
+func TestUnmarshalYAML(t *testing.T) {
+	for _, c := range yamlScalars {
+		if err := c.into.UnmarshalYAML(yamlScalar(c.raw)); err != nil {
+			t.Errorf(`UnmarshalYAML(%q) into %T -> non-nil error (%v)`, c.raw, c.into, err)
+		} else if !reflect.DeepEqual(c.into, c.want) {
+			t.Errorf(`UnmarshalYAML(%q) into %T -> %v (should be %v)`, c.raw, c.into, c.into, c.want)
+		}
+	}
+	var iv Interval
+	if err := iv.UnmarshalYAML(yamlScalar("2018-09-27T00:00:00Z/P1D")); err != nil {
+		t.Errorf(`UnmarshalYAML("2018-09-27T00:00:00Z/P1D") into Interval -> non-nil error (%v)`, err)
+	}
+	var d Date
+	if err := d.UnmarshalYAML(yamlScalar("yesterday")); err == nil {
+		t.Errorf(`UnmarshalYAML("yesterday") into Date -> %v returned nil error`, d)
+	}
+	// Errors from the decoder itself are passed through.
+	decodeErr := errors.New("mapping where scalar expected")
+	if err := d.UnmarshalYAML(func(interface{}) error { return decodeErr }); err != decodeErr {
+		t.Errorf(`UnmarshalYAML with failing decoder -> %v (should be %v)`, err, decodeErr)
+	}
+}
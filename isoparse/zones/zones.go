@@ -0,0 +1,79 @@
+// Use of this source code is governed by Apache License, Version 2.0, that can be found
+// in the LICENSE file.
+
+// Package zones suggests IANA time zones for a parsed time.
+//
+// An ISO-8601 string carries at most a UTC offset, and isoparse attaches that offset as
+// a fixed zone named only after the offset itself, such as "UTC+05:30".  Candidates lists
+// the IANA zones that were at the same offset at the same instant, which is often enough
+// to narrow a timestamp down to a region:
+//
+//	t, _ := isoparse.ParseISODatetime("2018-07-01T12:00:00+05:30")
+//	zones.Candidates(t) // [Asia/Colombo Asia/Kolkata]
+//
+// The candidate names come from an embedded copy of the tz database's zone.tab, and this
+// package imports time/tzdata so that the zones can be loaded even where the system has
+// no zoneinfo files.  That adds about 450 KB to a binary, which is why it is not part of
+// isoparse itself.  Build with the isoparse_notzdata tag to rely on the system's files
+// instead, as with the tzdata subpackage.
+package zones
+
+import (
+	_ "embed"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed zones.txt
+var zoneList string
+
+var (
+	loadOnce  sync.Once
+	locations []*time.Location
+)
+
+// Names returns the IANA zone names that Candidates chooses from, in sorted order.
+func Names() []string {
+	var names []string
+	for _, line := range strings.Split(zoneList, "\n") {
+		if line != "" && !strings.HasPrefix(line, "#") {
+			names = append(names, line)
+		}
+	}
+	return names
+}
+
+// load loads every zone in Names, skipping any the time package does not know.
+func load() {
+	for _, name := range Names() {
+		if loc, err := time.LoadLocation(name); err == nil {
+			locations = append(locations, loc)
+		}
+	}
+}
+
+// Candidates returns the names of the zones whose UTC offset at the instant t equals t's
+// own offset, in sorted order.  It returns nil if no zone matches, as for an offset like
+// +05:17 that no zone uses.
+//
+// Only the offset of t's location is considered, not its name, so it makes no difference
+// whether t came from isoparse or from time.Parse.  For a time in UTC the result is the
+// zones that were on UTC at that instant, which may be a surprising number in winter.
+func Candidates(t time.Time) []string {
+	_, offset := t.Zone()
+	return CandidatesForOffset(t, offset)
+}
+
+// CandidatesForOffset is like Candidates, but matches the given offset, in seconds east
+// of UTC, rather than t's own.
+func CandidatesForOffset(t time.Time, secondsEast int) []string {
+	loadOnce.Do(load)
+	var names []string
+	for _, loc := range locations {
+		if _, offset := t.In(loc).Zone(); offset == secondsEast {
+			names = append(names, loc.String())
+		}
+	}
+	return names
+}
@@ -0,0 +1,61 @@
+package zones
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+var candidateCases = []struct {
+	t       time.Time
+	want    []string // must all be present
+	notWant []string // must all be absent
+}{
+	{time.Date(2018, 7, 1, 12, 0, 0, 0, time.FixedZone("", 5*60*60+30*60)), []string{"Asia/Kolkata", "Asia/Colombo"}, []string{"Asia/Kathmandu"}},
+	// -05:00 is Eastern Standard Time in January but Central Daylight Time in July.
+	{time.Date(2018, 1, 15, 12, 0, 0, 0, time.FixedZone("", -5*60*60)), []string{"America/New_York"}, []string{"America/Chicago"}},
+	{time.Date(2018, 7, 15, 12, 0, 0, 0, time.FixedZone("", -5*60*60)), []string{"America/Chicago"}, []string{"America/New_York"}},
+	{time.Date(2018, 1, 15, 12, 0, 0, 0, time.UTC), []string{"Europe/London"}, []string{"Europe/Paris"}},
+}
+
+func TestCandidates(t *testing.T) {
+	for _, c := range candidateCases {
+		got := Candidates(c.t)
+		if !sort.StringsAreSorted(got) {
+			t.Errorf(`Candidates(%v) -> %v (not sorted)`, c.t, got)
+		}
+		for _, name := range c.want {
+			if !contains(got, name) {
+				t.Errorf(`Candidates(%v) -> %v (should contain %s)`, c.t, got, name)
+			}
+		}
+		for _, name := range c.notWant {
+			if contains(got, name) {
+				t.Errorf(`Candidates(%v) -> %v (should not contain %s)`, c.t, got, name)
+			}
+		}
+	}
+}
+
+func TestNoCandidates(t *testing.T) {
+	tm := time.Date(2018, 7, 1, 12, 0, 0, 0, time.FixedZone("", 5*60*60+17*60))
+	if got := Candidates(tm); got != nil {
+		t.Errorf(`Candidates(%v) -> %v (should be nil)`, tm, got)
+	}
+}
+
+func TestNames(t *testing.T) {
+	names := Names()
+	if len(names) < 300 || !sort.StringsAreSorted(names) || !contains(names, "Europe/Oslo") {
+		t.Errorf(`Names() -> %d names (should be a sorted list of a few hundred zones)`, len(names))
+	}
+}